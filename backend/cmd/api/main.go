@@ -3,8 +3,9 @@ package main
 import (
 	"context"
 	"log"
-	"os"
+	"net"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
 	"github.com/kaldun-tech/token-vesting-backend/internal/config"
 	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
 )
 
 func main() {
@@ -19,6 +21,9 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("❌ Invalid configuration:\n%v", err)
+	}
 	log.Printf("📝 Environment: %s", cfg.Environment)
 
 	// Connect to database
@@ -28,52 +33,197 @@ func main() {
 	}
 	log.Println("✅ Database connected")
 
+	// Serve API reads from a replica unless a request asks for strong consistency
+	if cfg.DatabaseReplicaURL != "" {
+		if err := db.UseReadReplica(cfg.DatabaseReplicaURL); err != nil {
+			log.Fatalf("❌ Failed to connect to read replica: %v", err)
+		}
+		log.Println("✅ Read replica connected")
+	}
+
+	scheduleOrder, err := database.ParseScheduleOrder(cfg.ScheduleOrder)
+	if err != nil {
+		log.Fatalf("❌ Invalid SCHEDULES_DEFAULT_ORDER: %v", err)
+	}
+	db.SetScheduleOrder(scheduleOrder)
+
+	scheduleMode, err := database.ParseScheduleMode(cfg.ScheduleMode)
+	if err != nil {
+		log.Fatalf("❌ Invalid SCHEDULE_MODE: %v", err)
+	}
+	if err := db.ApplyScheduleMode(scheduleMode); err != nil {
+		log.Fatalf("❌ Failed to apply schedule mode: %v", err)
+	}
+
+	revocationMode, err := database.ParseRevocationMode(cfg.RevocationMode)
+	if err != nil {
+		log.Fatalf("❌ Invalid REVOCATION_MODE: %v", err)
+	}
+	db.SetRevocationMode(revocationMode)
+
+	// The indexer always works against the primary. Take the view after the
+	// settings above so it shares them.
+	primary := db.Primary()
+
+	vestingModel, err := models.ParseVestingModel(cfg.VestingModel)
+	if err != nil {
+		log.Fatalf("❌ Invalid VESTING_MODEL: %v", err)
+	}
+
+	if len(cfg.AdminAPIKeys) == 0 {
+		log.Println("📌 ADMIN_API_KEYS is empty; admin routes are disabled")
+	}
+
+	// Load merkle allocations if configured
+	if cfg.AllocationFile != "" {
+		allocations, err := database.LoadAllocationFile(cfg.AllocationFile)
+		if err != nil {
+			log.Fatalf("❌ Failed to load allocation file: %v", err)
+		}
+		if err := db.UpsertMerkleAllocations(allocations); err != nil {
+			log.Fatalf("❌ Failed to store allocations: %v", err)
+		}
+		log.Printf("✅ Loaded %d merkle allocations", len(allocations))
+	}
+
 	// Connect to blockchain
 	bc, err := blockchain.NewClient(cfg)
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to blockchain: %v", err)
 	}
-	defer bc.Close()
 	log.Println("✅ Blockchain client connected")
 
-	// Create event listener
-	listener := blockchain.NewEventListener(bc, db)
+	// Create event listener, fanning recorded events out to live streams
+	listener := blockchain.NewEventListener(bc, primary, cfg)
+	eventHub := blockchain.NewEventHub()
+	listener.SetEventHub(eventHub)
+	if err := listener.SetBeneficiaryAllowlist(cfg.BeneficiaryAllowlist); err != nil {
+		log.Fatalf("❌ Invalid BENEFICIARY_ALLOWLIST: %v", err)
+	}
+	if len(cfg.BeneficiaryAllowlist) > 0 {
+		log.Printf("📌 Indexing only %d allowlisted beneficiaries", len(cfg.BeneficiaryAllowlist))
+	}
 
-	// Start event listener in background
+	// Background workers run until shutdown; wg tracks them so the database
+	// and blockchain client are only closed once they have stopped
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	var wg sync.WaitGroup
+	background := func(run func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run()
+		}()
+	}
+
+	// Deliver recorded events to the operator's webhook
+	if cfg.Webhook.URL != "" {
+		webhook := blockchain.NewWebhookNotifier(cfg.Webhook)
+		listener.SetWebhookNotifier(webhook)
+		background(func() { webhook.Start(ctx) })
+		log.Printf("✅ Webhook notifications enabled")
+	}
 
-	go func() {
+	// Start event listener in background
+	background(func() {
 		if err := listener.Start(ctx, cfg.StartBlock); err != nil {
 			log.Printf("⚠️  Event listener error: %v", err)
 		}
-	}()
+		listener.Wait()
+	})
+
+	// Periodically reconcile released amounts with on-chain state
+	if cfg.ReleasedRefreshInterval > 0 {
+		refresher := blockchain.NewReleasedRefresher(bc, primary, cfg.ReleasedRefreshInterval, cfg.ReleasedRefreshBatchSize)
+		refresher.SetRevokedReleasePolicy(cfg.RevokedReleasePolicy)
+		background(func() { refresher.Start(ctx) })
+	}
+
+	// Periodically detect events left without a schedule
+	if cfg.OrphanedEventsInterval > 0 {
+		job := blockchain.NewOrphanedEventsJob(bc, primary, cfg.OrphanedEventsMode, cfg.OrphanedEventsInterval)
+		background(func() { job.Start(ctx) })
+	}
 
 	// Setup API router
-	handler := api.NewHandler(db, bc)
-	router := api.SetupRouter(handler)
+	handler := api.NewHandler(db, bc, listener)
+	handler.SetPrimaryDatabase(primary)
+	handler.SetVestingModel(vestingModel)
+	handler.SetExportMaxRows(cfg.ExportMaxRows)
+	handler.SetMaxOffset(cfg.MaxOffset)
+	handler.SetMaxCurvePoints(cfg.MaxCurvePoints)
+	handler.SetPaginationLinks(cfg.PaginationLinks)
+	handler.SetStatsDeadline(cfg.StatsDeadline)
+	handler.SetVestedFetch(cfg.VestedFetchConcurrency, cfg.VestedFetchTimeout)
+	handler.SetZeroVestedFallback(cfg.VestedZeroFallback)
+	handler.SetEventReplayer(listener)
+	handler.SetReplayMaxBlocks(cfg.ReplayMaxBlocks)
+	handler.SetEventStream(eventHub)
+	handler.SetSyncReadiness(listener)
+	handler.SetContractInfo(api.ContractInfo{
+		Address: cfg.TokenVestingAddress,
+		Token:   cfg.TokenAddress,
+		ChainID: cfg.ChainID,
+	})
+	handler.SetTokenDecimals(blockchain.NewDecimalsCache(bc, cfg.TokenAddress, cfg.TokenDecimals))
+	handler.SetAmountDisplayDecimals(cfg.AmountDisplayDecimals)
+	if cfg.MetricsBeneficiaryGauges {
+		if err := handler.SetBeneficiaryMetrics(cfg.MetricsBeneficiaryWatchlist); err != nil {
+			log.Fatalf("❌ Invalid METRICS_BENEFICIARY_WATCHLIST: %v", err)
+		}
+		log.Printf("📌 Exposing per-beneficiary metrics for %d addresses", len(cfg.MetricsBeneficiaryWatchlist))
+	}
+
+	// Fail health checks when the indexer stops keeping up with the chain, and
+	// flag responses while it lags or the RPC node is unreachable
+	if cfg.SyncStallWindow > 0 || cfg.DegradedSyncLag > 0 {
+		window := cfg.SyncStallWindow
+		if window == 0 {
+			window = time.Minute
+		}
+		monitor := blockchain.NewSyncProgressMonitor(bc, primary, listener, window)
+		background(func() { monitor.Start(ctx) })
+		if cfg.SyncStallWindow > 0 {
+			handler.SetSyncProgress(monitor)
+		}
+		if cfg.DegradedSyncLag > 0 {
+			handler.SetDegradedMode(monitor, cfg.DegradedSyncLag)
+		}
+	}
+
+	// Warn when the server clock drifts from block time, which skews vesting math
+	if cfg.MaxClockSkew > 0 {
+		clock := blockchain.NewClockSkewMonitor(bc, cfg.MaxClockSkew, cfg.ClockSkewCheckInterval, cfg.PreferBlockTime)
+		background(func() { clock.Start(ctx) })
+		handler.SetClock(clock)
+	}
+	router := api.SetupRouter(handler, cfg)
 
 	// Start HTTP server
 	serverAddr := ":" + cfg.ServerPort
+	ln, err := net.Listen("tcp", serverAddr)
+	if err != nil {
+		log.Fatalf("❌ Failed to start server: %v", err)
+	}
 	log.Printf("🌐 Server starting on %s", serverAddr)
 	log.Printf("📖 API Documentation available at http://localhost:%s/health", cfg.ServerPort)
 
-	// Graceful shutdown
-	go func() {
-		if err := router.Run(serverAddr); err != nil {
-			log.Fatalf("❌ Failed to start server: %v", err)
-		}
-	}()
-
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
+	// Serve until SIGINT or SIGTERM, then drain in-flight requests
+	signals, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	srv := api.NewServer(router, handler)
+	if err := api.Serve(signals, srv, ln, cfg.ShutdownTimeout); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
 	log.Println("🛑 Shutting down server...")
-	cancel()
 
-	// Give time for cleanup
-	time.Sleep(2 * time.Second)
+	// Stop background workers, then release what they and the handlers used
+	cancel()
+	wg.Wait()
+	bc.Close()
+	if err := db.Close(); err != nil {
+		log.Printf("⚠️  Failed to close database: %v", err)
+	}
 	log.Println("✅ Server stopped")
 }
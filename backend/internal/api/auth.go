@@ -0,0 +1,82 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyHeader carries the API key when API key authentication is enabled.
+// Keys may also be sent as "Authorization: Bearer <key>".
+const apiKeyHeader = "X-API-Key"
+
+// requireAPIKey rejects requests without one of the given API keys with 401.
+// Keys are compared as SHA-256 digests in constant time, and every key is
+// checked, so response timing reveals neither how much of a key matched nor
+// which key did. Register it on the route groups that need protection.
+func requireAPIKey(keys []string) gin.HandlerFunc {
+	digests := make([][sha256.Size]byte, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			digests = append(digests, sha256.Sum256([]byte(key)))
+		}
+	}
+
+	return func(c *gin.Context) {
+		key := requestAPIKey(c)
+		if key == "" {
+			rejectUnauthenticated(c, "API key required; send it in the "+apiKeyHeader+" header")
+			return
+		}
+
+		digest := sha256.Sum256([]byte(key))
+		match := 0
+		for i := range digests {
+			match |= subtle.ConstantTimeCompare(digest[:], digests[i][:])
+		}
+		if match != 1 {
+			rejectUnauthenticated(c, "Invalid API key")
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireAdmin rejects requests without one of the admin API keys. It fails
+// closed: with no admin keys configured every request is rejected with 403,
+// whether or not API_KEY_AUTH is on.
+func requireAdmin(keys []string) gin.HandlerFunc {
+	for _, key := range keys {
+		if strings.TrimSpace(key) != "" {
+			return requireAPIKey(keys)
+		}
+	}
+
+	return func(c *gin.Context) {
+		respondJSON(c, http.StatusForbidden, gin.H{"error": "Admin routes are disabled; set ADMIN_API_KEYS to enable them"})
+		c.Abort()
+	}
+}
+
+// requestAPIKey returns the API key sent with a request, preferring the
+// X-API-Key header over a bearer token
+func requestAPIKey(c *gin.Context) string {
+	if key := strings.TrimSpace(c.GetHeader(apiKeyHeader)); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(strings.TrimSpace(c.GetHeader("Authorization")), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+// rejectUnauthenticated aborts a request with 401
+func rejectUnauthenticated(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	respondJSON(c, http.StatusUnauthorized, gin.H{"error": message})
+	c.Abort()
+}
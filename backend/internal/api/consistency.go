@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readConsistencyHeader lets a client choose how fresh the data it reads must be
+const readConsistencyHeader = "X-Read-Consistency"
+
+// Read consistency levels
+const (
+	ReadConsistencyStrong   = "strong"   // Read from the primary database
+	ReadConsistencyEventual = "eventual" // Read from the replica when one is configured; may lag
+)
+
+// readConsistencyKey is the context key holding the request's read consistency
+const readConsistencyKey = "read_consistency"
+
+// SetPrimaryDatabase sets the database serving reads of requests that ask for
+// strong consistency, when other reads go to a replica
+func (h *Handler) SetPrimaryDatabase(primary DatabaseInterface) {
+	h.primary = primary
+}
+
+// readConsistency resolves the X-Read-Consistency header, rejecting unknown
+// levels. Requests read with eventual consistency by default.
+func readConsistency(c *gin.Context) {
+	level := strings.ToLower(strings.TrimSpace(c.GetHeader(readConsistencyHeader)))
+	switch level {
+	case "", ReadConsistencyEventual:
+		c.Set(readConsistencyKey, ReadConsistencyEventual)
+	case ReadConsistencyStrong:
+		c.Set(readConsistencyKey, ReadConsistencyStrong)
+	default:
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": readConsistencyHeader + " must be strong or eventual"})
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// store returns the database serving the request's reads: the primary for
+// strongly consistent requests, otherwise the default connection
+func (h *Handler) store(c *gin.Context) DatabaseInterface {
+	if h.primary != nil && c.GetString(readConsistencyKey) == ReadConsistencyStrong {
+		return h.primary
+	}
+	return h.db
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// maxCoverageEventBlocks caps the event blocks listed in a coverage report
+const maxCoverageEventBlocks = 1000
+
+// BlockRange is an inclusive range of blocks
+type BlockRange struct {
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+}
+
+// blocks returns the number of blocks in the range
+func (r BlockRange) blocks() uint64 {
+	return r.ToBlock - r.FromBlock + 1
+}
+
+// GetSyncCoverage reports which blocks of a window the indexer has scanned for
+// events, from the recorded sync history, along with the gaps between them and
+// the blocks holding events. Either bound may be omitted; the window then
+// extends to the first or last scanned block.
+// GET /api/v1/sync/coverage
+func (h *Handler) GetSyncCoverage(c *gin.Context) {
+	filter, err := parseBlockRange(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ranges, err := h.store(c).GetScannedRanges(filter)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sync coverage"})
+		return
+	}
+	scanned := mergeRanges(ranges)
+
+	window, ok := coverageWindow(filter, scanned)
+	if !ok {
+		// Nothing scanned and no complete window to report gaps in
+		respondJSON(c, http.StatusOK, gin.H{
+			"from_block":     filter.FromBlock,
+			"to_block":       filter.ToBlock,
+			"scanned":        []BlockRange{},
+			"gaps":           []BlockRange{},
+			"scanned_blocks": 0,
+			"window_blocks":  0,
+			"event_blocks":   []uint64{},
+		})
+		return
+	}
+	scanned = clipRanges(scanned, window)
+
+	eventFilter := database.EventFilter{FromBlock: &window.FromBlock, ToBlock: &window.ToBlock}
+	eventBlocks, err := h.store(c).GetEventBlocks(eventFilter, maxCoverageEventBlocks+1)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve event blocks"})
+		return
+	}
+	truncated := len(eventBlocks) > maxCoverageEventBlocks
+	if truncated {
+		eventBlocks = eventBlocks[:maxCoverageEventBlocks]
+	}
+
+	var scannedBlocks uint64
+	for _, r := range scanned {
+		scannedBlocks += r.blocks()
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"from_block":             window.FromBlock,
+		"to_block":               window.ToBlock,
+		"scanned":                scanned,
+		"gaps":                   coverageGaps(scanned, window),
+		"scanned_blocks":         scannedBlocks,
+		"window_blocks":          window.blocks(),
+		"event_blocks":           eventBlocks,
+		"event_blocks_truncated": truncated,
+	})
+}
+
+// mergeRanges merges recorded ranges, ordered by first block, into disjoint
+// ranges. Overlapping and adjacent ranges are joined.
+func mergeRanges(ranges []models.SyncRange) []BlockRange {
+	merged := make([]BlockRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.ToBlock < r.FromBlock {
+			continue
+		}
+		if n := len(merged); n > 0 && r.FromBlock <= merged[n-1].ToBlock+1 {
+			if r.ToBlock > merged[n-1].ToBlock {
+				merged[n-1].ToBlock = r.ToBlock
+			}
+			continue
+		}
+		merged = append(merged, BlockRange{FromBlock: r.FromBlock, ToBlock: r.ToBlock})
+	}
+	return merged
+}
+
+// coverageWindow resolves the window a coverage report covers, defaulting
+// missing bounds to the scanned history. Reports false when a bound is missing
+// and nothing has been scanned.
+func coverageWindow(filter database.EventFilter, scanned []BlockRange) (BlockRange, bool) {
+	var window BlockRange
+	switch {
+	case filter.FromBlock != nil:
+		window.FromBlock = *filter.FromBlock
+	case len(scanned) > 0:
+		window.FromBlock = scanned[0].FromBlock
+	default:
+		return window, false
+	}
+	switch {
+	case filter.ToBlock != nil:
+		window.ToBlock = *filter.ToBlock
+	case len(scanned) > 0:
+		window.ToBlock = scanned[len(scanned)-1].ToBlock
+	default:
+		return window, false
+	}
+	return window, window.FromBlock <= window.ToBlock
+}
+
+// clipRanges trims disjoint ordered ranges to the window, dropping those outside it
+func clipRanges(ranges []BlockRange, window BlockRange) []BlockRange {
+	clipped := make([]BlockRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.ToBlock < window.FromBlock || r.FromBlock > window.ToBlock {
+			continue
+		}
+		r.FromBlock = max(r.FromBlock, window.FromBlock)
+		r.ToBlock = min(r.ToBlock, window.ToBlock)
+		clipped = append(clipped, r)
+	}
+	return clipped
+}
+
+// coverageGaps returns the blocks of the window not covered by the disjoint
+// ordered scanned ranges inside it
+func coverageGaps(scanned []BlockRange, window BlockRange) []BlockRange {
+	gaps := make([]BlockRange, 0)
+	next := window.FromBlock
+	for _, r := range scanned {
+		if r.FromBlock > next {
+			gaps = append(gaps, BlockRange{FromBlock: next, ToBlock: r.FromBlock - 1})
+		}
+		if r.ToBlock == window.ToBlock {
+			return gaps
+		}
+		next = r.ToBlock + 1
+	}
+	if next <= window.ToBlock {
+		gaps = append(gaps, BlockRange{FromBlock: next, ToBlock: window.ToBlock})
+	}
+	return gaps
+}
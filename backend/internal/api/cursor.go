@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// errInvalidCursor is returned for cursor parameters that do not decode
+var errInvalidCursor = errors.New("cursor is invalid; pass the next_cursor of a previous page")
+
+// scheduleCursor is the decoded form of a schedule listing cursor
+type scheduleCursor struct {
+	ID uint `json:"id"`
+}
+
+// eventCursor is the decoded form of an event listing cursor
+type eventCursor struct {
+	BlockNumber uint64 `json:"block"`
+	LogIndex    uint   `json:"log"`
+	ID          uint   `json:"id"`
+}
+
+// encodeCursor encodes a cursor as opaque URL-safe base64
+func encodeCursor(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor decodes the cursor query parameter into value. Reports false
+// when the parameter is absent.
+func decodeCursor(c *gin.Context, value interface{}) (bool, error) {
+	raw := strings.TrimSpace(c.Query("cursor"))
+	if raw == "" {
+		return false, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(raw, "="))
+	if err != nil {
+		return true, errInvalidCursor
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(decoded)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return true, errInvalidCursor
+	}
+	return true, nil
+}
+
+// parseScheduleCursor parses the cursor of a schedule listing into the ID of
+// the last schedule seen. Returns 0 when the parameter is absent.
+func parseScheduleCursor(c *gin.Context) (uint, error) {
+	var cursor scheduleCursor
+	present, err := decodeCursor(c, &cursor)
+	if err != nil {
+		return 0, err
+	}
+	if present && cursor.ID == 0 {
+		return 0, errInvalidCursor
+	}
+	return cursor.ID, nil
+}
+
+// parseEventCursor parses the cursor of an event listing into the position of
+// the last event seen. Returns nil when the parameter is absent.
+func parseEventCursor(c *gin.Context) (*database.EventCursor, error) {
+	var cursor eventCursor
+	present, err := decodeCursor(c, &cursor)
+	if err != nil || !present {
+		return nil, err
+	}
+	if cursor.ID == 0 {
+		return nil, errInvalidCursor
+	}
+	return &database.EventCursor{BlockNumber: cursor.BlockNumber, LogIndex: cursor.LogIndex, ID: cursor.ID}, nil
+}
+
+// nextScheduleCursor returns the cursor of the page after schedules, or nil
+// when a short page shows the listing is exhausted
+func nextScheduleCursor(schedules []models.VestingSchedule, limit int) *string {
+	if limit <= 0 || len(schedules) < limit {
+		return nil
+	}
+	next := encodeCursor(scheduleCursor{ID: schedules[len(schedules)-1].ID})
+	return &next
+}
+
+// nextEventCursor returns the cursor of the page after events, or nil when a
+// short page shows the listing is exhausted
+func nextEventCursor(events []models.VestingEvent, limit int) *string {
+	if limit <= 0 || len(events) < limit {
+		return nil
+	}
+	last := events[len(events)-1]
+	next := encodeCursor(eventCursor{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex, ID: last.ID})
+	return &next
+}
+
+// setCursorLink adds an RFC 8288 Link header pointing at the next page of a
+// cursor listing. Cursors only move forward, so there is no prev link.
+func (h *Handler) setCursorLink(c *gin.Context, limit int, next *string) {
+	if !h.paginationLinks || c.Request == nil || next == nil {
+		return
+	}
+
+	target := *c.Request.URL
+	query := target.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("cursor", *next)
+	query.Del("offset")
+	target.RawQuery = query.Encode()
+	c.Header("Link", "<"+target.RequestURI()+`>; rel="next"`)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCurvePoints is the number of curve points returned when ?points= is omitted
+const defaultCurvePoints = 50
+
+// defaultMaxCurvePoints caps curve points when no limit is configured
+const defaultMaxCurvePoints = 500
+
+// CurvePoint is the amount of a schedule vested at one time
+type CurvePoint struct {
+	Time   time.Time `json:"time"`
+	Vested string    `json:"vested"`
+}
+
+// SetMaxCurvePoints caps the points a vesting curve returns. Requests for more
+// are downsampled to the cap rather than rejected.
+func (h *Handler) SetMaxCurvePoints(max int) {
+	h.maxCurvePoints = max
+}
+
+// curvePointLimit returns the most points a curve may return. Two is the
+// least that still holds both the start and the end.
+func (h *Handler) curvePointLimit() int {
+	if h.maxCurvePoints <= 0 {
+		return defaultMaxCurvePoints
+	}
+	if h.maxCurvePoints < 2 {
+		return 2
+	}
+	return h.maxCurvePoints
+}
+
+// GetVestingCurve samples a beneficiary's vested amount at evenly spaced times
+// from the schedule's start to its end, for charts. When more points are
+// requested than the configured maximum, an evenly spread subset of the
+// requested points is returned, always including the start and the end.
+// GET /api/v1/schedules/:address/curve?points=100
+func (h *Handler) GetVestingCurve(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	points := defaultCurvePoints
+	if raw := c.Query("points"); raw != "" {
+		var err error
+		if points, err = strconv.Atoi(raw); err != nil || points < 2 {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "points must be an integer of at least 2"})
+			return
+		}
+	}
+
+	normalizedAddress := address.Hex()
+
+	schedule, err := h.store(c).GetScheduleByBeneficiary(normalizedAddress)
+	if err != nil {
+		respondLookupError(c, err, "Schedule not found")
+		return
+	}
+
+	params := schedule.EffectiveVesting(h.vestingModel)
+	indices := sampleIndices(points, h.curvePointLimit())
+	curve := make([]CurvePoint, len(indices))
+	for i, index := range indices {
+		at := curveTime(schedule.Start, params.End, index, points)
+		curve[i] = CurvePoint{Time: at.UTC(), Vested: schedule.VestedAmount(h.vestingModel, at).String()}
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary":      normalizedAddress,
+		"model":            params.Model,
+		"requested_points": points,
+		"downsampled":      len(curve) < points,
+		"curve":            curve,
+	})
+}
+
+// sampleIndices chooses which of n evenly spaced points to return when at most
+// max (at least 2) are allowed. The chosen indices are spread evenly over the
+// series, rounded to the nearest point, so the first and last are always kept.
+func sampleIndices(n, max int) []int {
+	count := n
+	if count > max {
+		count = max
+	}
+
+	indices := make([]int, count)
+	if count == n {
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	// index = round(i * (n-1) / (max-1)), in big integers since n is client-chosen
+	last, steps := big.NewInt(int64(n-1)), big.NewInt(int64(count-1))
+	half := new(big.Int).Rsh(steps, 1)
+	for i := range indices {
+		index := new(big.Int).Mul(big.NewInt(int64(i)), last)
+		index.Add(index, half).Quo(index, steps)
+		indices[i] = int(index.Int64())
+	}
+	return indices
+}
+
+// curveTime returns the time of point index out of n spread evenly from start
+// to end, to the second, with the last point exactly at end
+func curveTime(start, end time.Time, index, n int) time.Time {
+	if index >= n-1 {
+		return end
+	}
+	offset := new(big.Int).Mul(big.NewInt(end.Unix()-start.Unix()), big.NewInt(int64(index)))
+	offset.Quo(offset, big.NewInt(int64(n-1)))
+	return start.Add(time.Duration(offset.Int64()) * time.Second)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestGetVestingCurve(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const duration = 99 * 3600
+	end := start.Add(duration * time.Second)
+	handler := &Handler{db: &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{Beneficiary: beneficiary, Start: start, Cliff: start, Duration: duration, Amount: "9900", Released: "0"}, nil
+		},
+	}}
+	handler.SetMaxCurvePoints(10)
+
+	type response struct {
+		RequestedPoints int          `json:"requested_points"`
+		Downsampled     bool         `json:"downsampled"`
+		Curve           []CurvePoint `json:"curve"`
+	}
+	request := func(t *testing.T, query string) (int, response) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary+"/curve"+query, nil)
+		c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+		handler.GetVestingCurve(c)
+
+		var result response
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		}
+		return w.Code, result
+	}
+
+	t.Run("Within the cap", func(t *testing.T) {
+		status, result := request(t, "?points=4")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.False(t, result.Downsampled)
+		assert.Equal(t, []CurvePoint{
+			{Time: start, Vested: "0"},
+			{Time: start.Add(33 * time.Hour), Vested: "3300"},
+			{Time: start.Add(66 * time.Hour), Vested: "6600"},
+			{Time: end, Vested: "9900"},
+		}, result.Curve)
+	})
+
+	t.Run("Above the cap is downsampled", func(t *testing.T) {
+		// 100 points are an hour apart; the cap keeps 10 of them
+		status, result := request(t, "?points=100")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.True(t, result.Downsampled)
+		assert.Equal(t, 100, result.RequestedPoints)
+		require.Len(t, result.Curve, 10)
+		assert.Equal(t, CurvePoint{Time: start, Vested: "0"}, result.Curve[0])
+		assert.Equal(t, CurvePoint{Time: end, Vested: "9900"}, result.Curve[9])
+
+		// Every point lies on the requested hourly grid, in order
+		for i, point := range result.Curve {
+			assert.Zero(t, point.Time.Sub(start)%time.Hour, "point %d", i)
+			if i > 0 {
+				assert.True(t, point.Time.After(result.Curve[i-1].Time), "point %d", i)
+			}
+		}
+	})
+
+	t.Run("Invalid points", func(t *testing.T) {
+		for _, query := range []string{"?points=1", "?points=-5", "?points=many"} {
+			status, _ := request(t, query)
+			assert.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+}
+
+func TestSampleIndices(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, sampleIndices(3, 10))
+	assert.Equal(t, []int{0, 2, 5, 7, 9}, sampleIndices(10, 5))
+	assert.Equal(t, []int{0, 9}, sampleIndices(10, 2))
+
+	// Huge requests are sampled without overflow
+	indices := sampleIndices(1<<62, 3)
+	assert.Equal(t, []int{0, 1 << 61, 1<<62 - 1}, indices)
+}
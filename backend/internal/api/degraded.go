@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// warningsKey is the context key holding the degraded-mode warnings of a request
+const warningsKey = "degraded_warnings"
+
+// SetDegradedMode adds a top-level _warnings array to API responses while the
+// blockchain client is unreachable or the indexer is more than maxLag blocks
+// behind the chain head
+func (h *Handler) SetDegradedMode(reporter SyncProgressReporter, maxLag uint64) {
+	h.degraded = reporter
+	h.maxSyncLag = maxLag
+}
+
+// degradedWarnings describes why served data may be incomplete or stale
+func (h *Handler) degradedWarnings() []string {
+	if h.degraded == nil {
+		return nil
+	}
+
+	progress := h.degraded.Progress()
+	var warnings []string
+	if progress.RPCError != "" {
+		warnings = append(warnings, "Blockchain client is disconnected; on-chain amounts may be unavailable and new events are not being indexed")
+	}
+	if h.maxSyncLag > 0 && progress.Lag > h.maxSyncLag {
+		warnings = append(warnings, fmt.Sprintf("Indexer is %d blocks behind the chain head; recent events may be missing", progress.Lag))
+	}
+	return warnings
+}
+
+// flagDegradedData computes the request's degraded-mode warnings once, for
+// respondJSON to add to successful responses
+func (h *Handler) flagDegradedData(c *gin.Context) {
+	if warnings := h.degradedWarnings(); len(warnings) > 0 {
+		c.Set(warningsKey, warnings)
+	}
+	c.Next()
+}
+
+// withWarnings inserts the request's degraded-mode warnings into an encoded
+// JSON object as its first field. Other payloads are returned unchanged.
+func withWarnings(c *gin.Context, encoded []byte) []byte {
+	value, ok := c.Get(warningsKey)
+	if !ok || len(encoded) == 0 || encoded[0] != '{' {
+		return encoded
+	}
+
+	field, err := json.Marshal(value)
+	if err != nil {
+		return encoded
+	}
+
+	var out bytes.Buffer
+	out.WriteString(`{"_warnings":`)
+	out.Write(field)
+	if rest := bytes.TrimSpace(encoded[1:]); len(rest) > 0 && rest[0] != '}' {
+		out.WriteByte(',')
+	}
+	out.Write(encoded[1:])
+	return out.Bytes()
+}
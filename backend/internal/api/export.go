@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// exportPageSize is the number of schedules read from the database per page while streaming
+const exportPageSize = 500
+
+// exportTruncatedTrailer reports whether an export stopped at the row cap. It is
+// sent as a trailer because truncation is only known once streaming finishes.
+const exportTruncatedTrailer = "X-Export-Truncated"
+
+// exportColumns is the CSV header row for schedule exports
+var exportColumns = []string{"beneficiary", "token", "start", "cliff", "duration", "amount", "released", "revocable", "revoked", "status"}
+
+// ExportSchedules streams schedules as CSV or NDJSON, capped at the configured row limit
+// GET /api/v1/schedules/export?format=csv&token=0x...&label=...
+func (h *Handler) ExportSchedules(c *gin.Context) {
+	h.exportSchedules(c, h.exportMaxRows)
+}
+
+// AdminExportSchedules streams every matching schedule without a row cap
+// GET /api/v1/admin/schedules/export?format=csv
+func (h *Handler) AdminExportSchedules(c *gin.Context) {
+	h.exportSchedules(c, 0)
+}
+
+// exportSchedules streams schedules page by page, stopping at maxRows when it is positive
+func (h *Handler) exportSchedules(c *gin.Context, maxRows int) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "ndjson" {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "format must be csv or ndjson"})
+		return
+	}
+
+	filter, err := parseScheduleFilter(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Read the first page before committing to a streaming response so
+	// database failures can still be reported as errors
+	requested := exportPageLimit(maxRows, 0)
+	page, err := h.store(c).GetAllSchedules(filter, requested, 0)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to export schedules"})
+		return
+	}
+
+	contentType := "text/csv"
+	if format == "ndjson" {
+		contentType = "application/x-ndjson"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Trailer", exportTruncatedTrailer)
+	c.Status(http.StatusOK)
+
+	writeRow, err := newExportWriter(c, format)
+	if err != nil {
+		log.Printf("⚠️  Schedule export aborted: %v", err)
+		return
+	}
+
+	now := h.now()
+	written := 0
+	for {
+		for i := range page {
+			page[i].Status = page[i].ComputeStatus(now)
+			if err := writeRow(&page[i]); err != nil {
+				log.Printf("⚠️  Schedule export aborted: %v", err)
+				return
+			}
+		}
+		written += len(page)
+
+		if len(page) < requested || (maxRows > 0 && written >= maxRows) {
+			break
+		}
+
+		requested = exportPageLimit(maxRows, written)
+		page, err = h.store(c).GetAllSchedules(filter, requested, written)
+		if err != nil {
+			log.Printf("⚠️  Schedule export aborted: %v", err)
+			return
+		}
+	}
+
+	truncated := false
+	if maxRows > 0 && written >= maxRows {
+		more, err := h.store(c).GetAllSchedules(filter, 1, written)
+		truncated = err != nil || len(more) > 0
+	}
+	c.Writer.Header().Set(exportTruncatedTrailer, strconv.FormatBool(truncated))
+}
+
+// exportPageLimit sizes the next page so an export never reads past its row cap
+func exportPageLimit(maxRows, written int) int {
+	if maxRows > 0 && maxRows-written < exportPageSize {
+		return maxRows - written
+	}
+	return exportPageSize
+}
+
+// newExportWriter returns a function writing one schedule in the given format,
+// flushing after each row so large exports stream instead of buffering. CSV
+// exports start with a header row.
+func newExportWriter(c *gin.Context, format string) (func(*models.VestingSchedule) error, error) {
+	if format == "ndjson" {
+		location := responseLocation(c)
+		return func(schedule *models.VestingSchedule) error {
+			encoded, err := json.Marshal(localizeTimes(schedule, location))
+			if err != nil {
+				return err
+			}
+			if _, err := c.Writer.Write(append(encoded, '\n')); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+			return nil
+		}, nil
+	}
+
+	location := responseLocation(c)
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	writer.Flush()
+	return func(schedule *models.VestingSchedule) error {
+		if err := writer.Write(scheduleCSVRecord(schedule, location)); err != nil {
+			return err
+		}
+		writer.Flush()
+		c.Writer.Flush()
+		return writer.Error()
+	}, nil
+}
+
+// scheduleCSVRecord formats a schedule as a CSV row matching exportColumns,
+// with timestamps rendered in location
+func scheduleCSVRecord(schedule *models.VestingSchedule, location *time.Location) []string {
+	return []string{
+		schedule.Beneficiary,
+		schedule.Token,
+		schedule.Start.In(location).Format(time.RFC3339),
+		schedule.Cliff.In(location).Format(time.RFC3339),
+		strconv.FormatInt(schedule.Duration, 10),
+		schedule.Amount,
+		schedule.Released,
+		strconv.FormatBool(schedule.Revocable),
+		strconv.FormatBool(schedule.Revoked),
+		string(schedule.Status),
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// pagedSchedules serves a fixed number of schedules honoring limit and offset
+func pagedSchedules(total int) func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+	return func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+		var page []models.VestingSchedule
+		for i := offset; i < total && len(page) < limit; i++ {
+			page = append(page, models.VestingSchedule{
+				ID:          uint(i + 1),
+				Beneficiary: fmt.Sprintf("0x%040x", i+1),
+				Start:       time.Unix(1700000000, 0),
+				Cliff:       time.Unix(1700000000, 0),
+				Duration:    3600,
+				Amount:      "1000",
+				Released:    "0",
+			})
+		}
+		return page, nil
+	}
+}
+
+func TestExportSchedules_RowCap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &Handler{db: &MockDatabase{GetAllSchedulesFunc: pagedSchedules(1200)}}
+	handler.SetExportMaxRows(700)
+
+	router := gin.New()
+	router.GET("/schedules/export", handler.ExportSchedules)
+	router.GET("/admin/schedules/export", handler.AdminExportSchedules)
+
+	tests := []struct {
+		name      string
+		path      string
+		rows      int
+		truncated string
+	}{
+		{name: "Public export truncates at the cap", path: "/schedules/export", rows: 700, truncated: "true"},
+		{name: "Admin export is uncapped", path: "/admin/schedules/export", rows: 1200, truncated: "false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			resp := w.Result()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+			records, err := csv.NewReader(resp.Body).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, tt.rows+1)
+			assert.Equal(t, exportColumns, records[0])
+			assert.Equal(t, tt.truncated, resp.Trailer.Get(exportTruncatedTrailer))
+		})
+	}
+}
+
+func TestAdminExportSchedules_RequiresAdminKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	export := func(cfg *config.Config, apiKey string) *httptest.ResponseRecorder {
+		handler := &Handler{db: &MockDatabase{GetAllSchedulesFunc: pagedSchedules(1200)}}
+		handler.SetExportMaxRows(700)
+		router := SetupRouter(handler, cfg)
+
+		req := httptest.NewRequest(http.MethodGet, adminPathPrefix+"/schedules/export", nil)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Admin routes fail closed without admin keys", func(t *testing.T) {
+		cfg := &config.Config{AccessLogMode: AccessLogOff}
+		assert.Equal(t, http.StatusForbidden, export(cfg, "").Code)
+
+		// An ordinary API key does not unlock them either
+		cfg = &config.Config{AccessLogMode: AccessLogOff, APIKeyAuth: true, APIKeys: []string{"key-one"}}
+		assert.Equal(t, http.StatusForbidden, export(cfg, "key-one").Code)
+	})
+
+	cfg := &config.Config{AccessLogMode: AccessLogOff, APIKeyAuth: true, APIKeys: []string{"key-one"}, AdminAPIKeys: []string{"admin-key"}}
+
+	t.Run("Non-admin callers are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, export(cfg, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, export(cfg, "key-one").Code)
+	})
+
+	t.Run("Admin callers get the uncapped export", func(t *testing.T) {
+		w := export(cfg, "admin-key")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		records, err := csv.NewReader(w.Result().Body).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, 1201)
+	})
+}
+
+func TestExportSchedules_UnderCap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &Handler{db: &MockDatabase{GetAllSchedulesFunc: pagedSchedules(700)}}
+	handler.SetExportMaxRows(700)
+
+	router := gin.New()
+	router.GET("/schedules/export", handler.ExportSchedules)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schedules/export?format=ndjson", nil))
+
+	resp := w.Result()
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	lines := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		assert.True(t, strings.HasPrefix(scanner.Text(), "{"))
+		lines++
+	}
+	assert.Equal(t, 700, lines)
+	// Exactly at the cap with nothing left over is not a truncation
+	assert.Equal(t, "false", resp.Trailer.Get(exportTruncatedTrailer))
+}
+
+func TestExportSchedules_InvalidFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/schedules/export?format=xml", nil)
+
+	handler := &Handler{db: &MockDatabase{}}
+	handler.ExportSchedules(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
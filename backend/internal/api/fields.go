@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// scheduleFields is the set of field names a sparse schedule response may request
+var scheduleFields = jsonFieldNames(reflect.TypeOf(scheduleWithVested{}))
+
+// jsonFieldNames collects the JSON names of a struct's serialized fields,
+// including those of embedded structs
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			for name := range jsonFieldNames(field.Type) {
+				names[name] = true
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// parseScheduleFields parses the fields query parameter into a sparse fieldset.
+// Returns nil when the parameter is absent, meaning all fields.
+func parseScheduleFields(c *gin.Context) ([]string, error) {
+	raw := strings.TrimSpace(c.Query("fields"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields, unknown []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		if !scheduleFields[field] {
+			unknown = append(unknown, field)
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown fields: %s (valid fields: %s)", strings.Join(unknown, ", "), strings.Join(validScheduleFields(), ", "))
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("fields must name at least one field")
+	}
+	return fields, nil
+}
+
+// validScheduleFields lists the selectable schedule fields in a stable order
+func validScheduleFields() []string {
+	names := make([]string, 0, len(scheduleFields))
+	for name := range scheduleFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sparseFields re-encodes v keeping only the requested fields, with times in
+// location. Fields omitted from v's JSON (e.g. empty optional ones) stay omitted.
+func sparseFields(v any, fields []string, location *time.Location) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(localizeTimes(v, location))
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return nil, err
+	}
+
+	sparse := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			sparse[field] = value
+		}
+	}
+	return sparse, nil
+}
+
+// sparseSchedules applies a sparse fieldset to each schedule in a listing,
+// returning the schedules unchanged when no fieldset was requested
+func sparseSchedules[T models.VestingSchedule | scheduleWithVested](schedules []T, fields []string, location *time.Location) (any, error) {
+	if fields == nil {
+		return schedules, nil
+	}
+
+	sparse := make([]map[string]json.RawMessage, len(schedules))
+	for i := range schedules {
+		item, err := sparseFields(schedules[i], fields, location)
+		if err != nil {
+			return nil, err
+		}
+		sparse[i] = item
+	}
+	return sparse, nil
+}
@@ -1,11 +1,21 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
 	"github.com/kaldun-tech/token-vesting-backend/internal/database"
@@ -13,167 +23,1598 @@ import (
 )
 
 const ERR_INVALID_ETH_ADDRESS = "Invalid Ethereum address"
+const ERR_ZERO_ADDRESS = "The zero address cannot be a beneficiary"
+
+// maxBeneficiariesPerQuery caps the number of addresses accepted in a single multi-beneficiary query
+const maxBeneficiariesPerQuery = 50
+
+// Limits on admin-assigned schedule labels
+const (
+	maxLabelsPerSchedule = 20
+	maxLabelLength       = 64
+)
 
 // DatabaseInterface defines the methods needed from the database
 type DatabaseInterface interface {
 	GetScheduleByBeneficiary(address string) (*models.VestingSchedule, error)
-	GetEventsByBeneficiary(address string, limit, offset int) ([]models.VestingEvent, error)
-	GetAllSchedules(limit, offset int) ([]models.VestingSchedule, error)
+	GetScheduleIncludingRevoked(address string) (*models.VestingSchedule, error)
+	GetSchedulesByBeneficiary(address string) ([]models.VestingSchedule, error)
+	GetTotalAllocated() (*big.Int, error)
+	CountSchedules(filter database.ScheduleFilter) (int64, error)
+	CountSchedulesByStatus(status string, filter database.ScheduleFilter, now time.Time) (int64, error)
+	CountEventsByBeneficiaries(addresses []string, filter database.EventFilter) (int64, error)
+	SumAmounts() (database.ScheduleSums, error)
+	GetBeneficiaryPresence(address string) (database.BeneficiaryPresence, error)
+	GetDeploymentBlock(contract string) (uint64, bool, error)
+	GetEventTypeHighWaterMarks() ([]database.EventTypeHighWater, error)
+	GetEventsByBeneficiary(address string, filter database.EventFilter, limit, offset int) ([]models.VestingEvent, error)
+	GetEventsByBeneficiaries(addresses []string, filter database.EventFilter, limit, offset int) ([]models.VestingEvent, error)
+	GetLatestEventsByBeneficiaries(addresses []string) (map[string]models.VestingEvent, error)
+	GetEventsInRange(filter database.EventFilter, limit, offset int) ([]models.VestingEvent, error)
+	GetEventBlocks(filter database.EventFilter, limit int) ([]uint64, error)
+	GetScannedRanges(filter database.EventFilter) ([]models.SyncRange, error)
+	GetAllSchedules(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error)
+	GetSchedulesByStatus(status string, filter database.ScheduleFilter, now time.Time, limit, offset int) ([]models.VestingSchedule, error)
+	GetMerkleAllocation(address string) (*models.MerkleAllocation, error)
+	SetScheduleLabels(address string, labels []string) error
+	Ping(ctx context.Context) error
+}
+
+// BlockchainInterface defines the methods needed from the blockchain client
+type BlockchainInterface interface {
+	GetVestedAmount(ctx context.Context, beneficiary common.Address) (*big.Int, error)
+	GetLatestBlockNumber(ctx context.Context) (uint64, error)
+}
+
+// LogReader fetches raw contract logs from chain
+type LogReader interface {
+	GetBeneficiaryLogs(ctx context.Context, beneficiary common.Address) ([]types.Log, error)
+}
+
+// SyncMonitor exposes the event listener's processing state
+type SyncMonitor interface {
+	Backlog() (buffered, retrying, unpublished int)
+	Throughput() []blockchain.ThroughputWindow
+}
+
+// ContractInfo identifies the vesting contract the API indexes
+type ContractInfo struct {
+	Address string
+	Token   string
+	ChainID int64
+}
+
+// EventReplayer republishes already-indexed events downstream
+type EventReplayer interface {
+	Replay(ctx context.Context, fromBlock, toBlock uint64) (int, error)
+}
+
+// SyncReadiness reports the outcome of the startup historical sync
+type SyncReadiness interface {
+	InitialSync() blockchain.InitialSyncStatus
+}
+
+// SyncProgressReporter reports whether the indexer is keeping up with the chain
+type SyncProgressReporter interface {
+	Progress() blockchain.SyncProgress
+}
+
+// Clock supplies the reference time for vesting computations
+type Clock interface {
+	Now() time.Time
+}
+
+// TokenDecimals resolves the decimals of a schedule's token
+type TokenDecimals interface {
+	Decimals(token string) uint8
 }
 
 type Handler struct {
-	db         DatabaseInterface
-	blockchain *blockchain.Client
+	db              DatabaseInterface
+	primary         DatabaseInterface   // Optional; serves strongly consistent reads when db reads from a replica
+	decimals        TokenDecimals       // Optional; formats amounts when set
+	roundAmounts    bool                // Round formatted amounts to displayDecimals places
+	displayDecimals int                 // Decimal places of formatted amounts when roundAmounts is set
+	vestingModel    models.VestingModel // When linear vesting begins; from_start when unset
+	clock           Clock               // Optional; the server clock is used when unset
+	blockchain      BlockchainInterface
+	logs            LogReader
+	listener        SyncMonitor
+	syncProgress    SyncProgressReporter
+	readiness       SyncReadiness
+	replayer        EventReplayer
+	replayMaxBlocks uint64               // Widest block range one replay may cover (0 means uncapped)
+	stream          EventStream          // Optional; enables live event streaming
+	contract        *ContractInfo        // Optional; enables /contract/info
+	exportMaxRows   int                  // Row cap for public exports (0 means uncapped)
+	maxOffset       int                  // Deepest pagination offset accepted (0 means uncapped)
+	paginationLinks bool                 // Whether listings send Link headers for their neighbouring pages
+	statsDeadline   time.Duration        // How long stats may compute before the cached result is served (0 waits)
+	degraded        SyncProgressReporter // Optional; enables _warnings on degraded data
+	maxSyncLag      uint64               // Blocks the indexer may trail the head before responses warn (0 disables)
+	stats           statsCache
+
+	vestedConcurrency int           // Concurrent vested lookups per multi-address request; defaultVestedFetchConcurrency when unset
+	vestedTimeout     time.Duration // Limit on each vested lookup (0 disables)
+	metricsWatchlist  []string      // Beneficiaries given per-address gauges on /metrics
+	maxCurvePoints    int           // Most points a vesting curve returns; defaultMaxCurvePoints when unset
+
+	zeroVestedFallback bool // Report local vesting math when the contract unexpectedly reports zero vested
+
+	// streamsClosed is closed by CloseStreams to end live event streams
+	streamsClosed chan struct{}
+	streamsInit   sync.Once
+	streamsClose  sync.Once
+}
+
+func NewHandler(db *database.Database, bc *blockchain.Client, listener SyncMonitor) *Handler {
+	h := &Handler{
+		db:       db,
+		listener: listener,
+	}
+	// Avoid storing a typed nil so handlers can detect a missing client
+	if bc != nil {
+		h.blockchain = bc
+		h.logs = bc
+	}
+	return h
+}
+
+// SetTokenDecimals enables formatted amounts in schedule responses
+func (h *Handler) SetTokenDecimals(decimals TokenDecimals) {
+	h.decimals = decimals
+}
+
+// SetAmountDisplayDecimals rounds formatted, human-readable amounts to the
+// given number of decimal places. Negative places keep every digit. Raw
+// base-unit amounts are never rounded.
+func (h *Handler) SetAmountDisplayDecimals(places int) {
+	h.roundAmounts = places >= 0
+	h.displayDecimals = places
+}
+
+// displayPlaces returns the decimal places formatted amounts are rounded to,
+// or -1 to keep every digit
+func (h *Handler) displayPlaces() int {
+	if !h.roundAmounts {
+		return -1
+	}
+	return h.displayDecimals
+}
+
+// formatSchedules adds amounts formatted with each schedule's token decimals
+func (h *Handler) formatSchedules(schedules []models.VestingSchedule) {
+	if h.decimals == nil {
+		return
+	}
+	for i := range schedules {
+		schedules[i].Format(h.decimals.Decimals(schedules[i].Token), h.displayPlaces())
+	}
+}
+
+// SetVestingModel sets the vesting model used by local vesting math and
+// reported in schedule responses
+func (h *Handler) SetVestingModel(model models.VestingModel) {
+	h.vestingModel = model
 }
 
-func NewHandler(db *database.Database, bc *blockchain.Client) *Handler {
-	return &Handler{
-		db:         db,
-		blockchain: bc,
+// describeVesting attaches each schedule's effective vesting parameters
+func (h *Handler) describeVesting(schedules []models.VestingSchedule) {
+	for i := range schedules {
+		params := schedules[i].EffectiveVesting(h.vestingModel)
+		schedules[i].Vesting = &params
 	}
 }
 
+// SetMaxOffset caps the pagination offset of listing endpoints
+func (h *Handler) SetMaxOffset(maxOffset int) {
+	h.maxOffset = maxOffset
+}
+
+// SetPaginationLinks toggles Link headers on paginated listings
+func (h *Handler) SetPaginationLinks(enabled bool) {
+	h.paginationLinks = enabled
+}
+
+// SetClock sets the reference time used for vesting computations
+func (h *Handler) SetClock(clock Clock) {
+	h.clock = clock
+}
+
+// now returns the reference time for vesting computations
+func (h *Handler) now() time.Time {
+	if h.clock == nil {
+		return time.Now()
+	}
+	return h.clock.Now()
+}
+
+// SetVestedFetch sets how many on-chain vested lookups a multi-address
+// request runs at once, and how long each may take before that address is
+// reported as timed out. A non-positive timeout disables the limit.
+func (h *Handler) SetVestedFetch(concurrency int, timeout time.Duration) {
+	h.vestedConcurrency = concurrency
+	h.vestedTimeout = timeout
+}
+
+// SetStatsDeadline bounds how long /stats waits for a fresh computation before
+// serving the last known-good result
+func (h *Handler) SetStatsDeadline(deadline time.Duration) {
+	h.statsDeadline = deadline
+}
+
+// SetExportMaxRows caps the rows returned by public exports
+func (h *Handler) SetExportMaxRows(maxRows int) {
+	h.exportMaxRows = maxRows
+}
+
+// SetSyncProgress makes the health check report unhealthy when sync stalls
+func (h *Handler) SetSyncProgress(reporter SyncProgressReporter) {
+	h.syncProgress = reporter
+}
+
+// SetSyncReadiness makes /ready wait for the startup historical sync
+func (h *Handler) SetSyncReadiness(readiness SyncReadiness) {
+	h.readiness = readiness
+}
+
+// SetContractInfo sets the contract served by /contract/info
+func (h *Handler) SetContractInfo(info ContractInfo) {
+	h.contract = &info
+}
+
+// SetEventReplayer enables the admin event replay endpoint
+func (h *Handler) SetEventReplayer(replayer EventReplayer) {
+	h.replayer = replayer
+}
+
+// SetReplayMaxBlocks caps the block range a single replay request may cover,
+// since a replay holds the request open until every event is republished
+func (h *Handler) SetReplayMaxBlocks(maxBlocks uint64) {
+	h.replayMaxBlocks = maxBlocks
+}
+
+// scheduleWithVested is a schedule annotated with its live on-chain vested amount
+type scheduleWithVested struct {
+	models.VestingSchedule
+	VestedAmount *string `json:"vested_amount"`          // nil when the on-chain lookup failed
+	VestedError  string  `json:"vested_error,omitempty"` // Why the lookup failed
+}
+
 // GetSchedule retrieves a vesting schedule for a beneficiary
 // GET /api/schedules/:address
 func (h *Handler) GetSchedule(c *gin.Context) {
-	address := c.Param("address")
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
 
-	// Validate address format and normalize to checksummed format
-	if !common.IsHexAddress(address) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ERR_INVALID_ETH_ADDRESS})
+	fields, err := parseScheduleFields(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Normalize address to checksummed format
-	normalizedAddress := common.HexToAddress(address).Hex()
+	normalizedAddress := address.Hex()
 
 	// Get from database
-	schedule, err := h.db.GetScheduleByBeneficiary(normalizedAddress)
+	schedule, err := h.store(c).GetScheduleByBeneficiary(normalizedAddress)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		respondLookupError(c, err, "Schedule not found")
+		return
+	}
+
+	now := h.now()
+	schedule.Status = schedule.ComputeStatus(now)
+	schedule.SetTimeRemaining(now)
+
+	if wantsScheduleV1(c) {
+		if err := checkScheduleV1Options(c, fields); err != nil {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		respondJSONAs(c, http.StatusOK, scheduleV1MediaType, newScheduleV1(schedule))
 		return
 	}
 
-	c.JSON(http.StatusOK, schedule)
+	if h.decimals != nil {
+		schedule.Format(h.decimals.Decimals(schedule.Token), h.displayPlaces())
+	}
+	params := schedule.EffectiveVesting(h.vestingModel)
+	schedule.Vesting = &params
+
+	if fields != nil {
+		sparse, err := sparseFields(schedule, fields, responseLocation(c))
+		if err != nil {
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to encode schedule"})
+			return
+		}
+		respondJSON(c, http.StatusOK, sparse)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, schedule)
 }
 
-// GetAllSchedules retrieves all vesting schedules with pagination
-// GET /api/schedules?limit=10&offset=0
+// GetAllSchedules retrieves all vesting schedules with pagination, optionally
+// only those in one vesting status
+// GET /api/schedules?limit=10&offset=0&cursor=...&status=vesting&include_vested=true&token=0x...&fields=beneficiary,amount
 func (h *Handler) GetAllSchedules(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseScheduleFilter(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A cursor takes precedence over offset
+	if filter.AfterID, err = parseScheduleCursor(c); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.AfterID != 0 {
+		offset = 0
+	} else if err := h.checkOffset(offset, schedulesDeepPagingHint); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fields, err := parseScheduleFields(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	v1 := wantsScheduleV1(c)
+	if v1 {
+		if err := checkScheduleV1Options(c, fields); err != nil {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	status, err := parseScheduleStatus(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := h.now()
+	var schedules []models.VestingSchedule
+	if status != "" {
+		schedules, err = h.store(c).GetSchedulesByStatus(status, filter, now, limit, offset)
+	} else {
+		schedules, err = h.store(c).GetAllSchedules(filter, limit, offset)
+	}
+	if errors.Is(err, database.ErrInvalidCursor) {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": errInvalidCursor.Error()})
+		return
+	}
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		return
+	}
+
+	// The total counts every matching schedule regardless of the page
+	var total int64
+	if status != "" {
+		total, err = h.store(c).CountSchedulesByStatus(status, filter, now)
+	} else {
+		total, err = h.store(c).CountSchedules(filter)
+	}
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to count schedules"})
+		return
+	}
+
+	nextCursor := nextScheduleCursor(schedules, limit)
+	if filter.AfterID != 0 {
+		h.setCursorLink(c, limit, nextCursor)
+	} else {
+		h.setPaginationLinks(c, limit, offset, len(schedules))
+	}
+	setStatuses(schedules, now)
+
+	if v1 {
+		body := make([]ScheduleV1, len(schedules))
+		for i := range schedules {
+			body[i] = newScheduleV1(&schedules[i])
+		}
+		respondJSONAs(c, http.StatusOK, scheduleV1MediaType, gin.H{
+			"schedules":   body,
+			"limit":       limit,
+			"offset":      offset,
+			"count":       len(body),
+			"total":       total,
+			"next_cursor": nextCursor,
+		})
+		return
+	}
+
+	h.formatSchedules(schedules)
+	h.describeVesting(schedules)
+
+	if c.Query("include_latest_event") == "true" {
+		if err := h.attachLatestEvents(c, schedules); err != nil {
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve latest events"})
+			return
+		}
+	}
 
-	if limit > 1000 {
-		limit = 1000
+	if c.Query("include_vested") == "true" {
+		if h.blockchain == nil {
+			respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Blockchain client not available"})
+			return
+		}
+
+		withVested, unavailable := h.attachVestedAmounts(c.Request.Context(), schedules)
+		body, err := sparseSchedules(withVested, fields, responseLocation(c))
+		if err != nil {
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to encode schedules"})
+			return
+		}
+		respondJSON(c, http.StatusOK, gin.H{
+			"schedules":          body,
+			"limit":              limit,
+			"offset":             offset,
+			"count":              len(withVested),
+			"total":              total,
+			"next_cursor":        nextCursor,
+			"vested_unavailable": unavailable,
+		})
+		return
 	}
 
-	schedules, err := h.db.GetAllSchedules(limit, offset)
+	body, err := sparseSchedules(schedules, fields, responseLocation(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to encode schedules"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"schedules": schedules,
-		"limit":     limit,
-		"offset":    offset,
-		"count":     len(schedules),
+	respondJSON(c, http.StatusOK, gin.H{
+		"schedules":   body,
+		"limit":       limit,
+		"offset":      offset,
+		"count":       len(schedules),
+		"total":       total,
+		"next_cursor": nextCursor,
 	})
 }
 
+// setStatuses populates the derived status and time remaining of each schedule
+func setStatuses(schedules []models.VestingSchedule, now time.Time) {
+	for i := range schedules {
+		schedules[i].Status = schedules[i].ComputeStatus(now)
+		schedules[i].SetTimeRemaining(now)
+	}
+}
+
+// attachLatestEvents attaches each schedule's newest event, fetched in one query
+func (h *Handler) attachLatestEvents(c *gin.Context, schedules []models.VestingSchedule) error {
+	beneficiaries := make([]string, len(schedules))
+	for i := range schedules {
+		beneficiaries[i] = schedules[i].Beneficiary
+	}
+
+	latest, err := h.store(c).GetLatestEventsByBeneficiaries(beneficiaries)
+	if err != nil {
+		return err
+	}
+
+	for i := range schedules {
+		if event, ok := latest[schedules[i].Beneficiary]; ok {
+			schedules[i].LatestEvent = &event
+		}
+	}
+	return nil
+}
+
+// attachVestedAmounts fetches on-chain vested amounts for each schedule.
+// Failed lookups leave the amount nil with the reason in vested_error; the
+// number of failures is returned.
+func (h *Handler) attachVestedAmounts(ctx context.Context, schedules []models.VestingSchedule) ([]scheduleWithVested, int) {
+	beneficiaries := make([]common.Address, len(schedules))
+	for i := range schedules {
+		beneficiaries[i] = common.HexToAddress(schedules[i].Beneficiary)
+	}
+	lookups := h.fetchVestedAmounts(ctx, beneficiaries)
+
+	results := make([]scheduleWithVested, len(schedules))
+	failed := 0
+	for i, lookup := range lookups {
+		results[i].VestingSchedule = schedules[i]
+		if lookup.err != nil {
+			log.Printf("⚠️  Failed to get vested amount for %s: %v", schedules[i].Beneficiary, lookup.err)
+			results[i].VestedError = vestedErrorMessage(lookup.err)
+			failed++
+			continue
+		}
+		vested := lookup.amount.String()
+		results[i].VestedAmount = &vested
+	}
+	return results, failed
+}
+
 // GetVestedAmount retrieves the current vested amount for a beneficiary
 // GET /api/vested/:address
 func (h *Handler) GetVestedAmount(c *gin.Context) {
-	address := c.Param("address")
-
-	// Validate address format
-	if !common.IsHexAddress(address) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ERR_INVALID_ETH_ADDRESS})
+	normalizedAddress, ok := parseAddressParam(c)
+	if !ok {
 		return
 	}
 
-	// Normalize address
-	normalizedAddress := common.HexToAddress(address)
+	if h.blockchain == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Blockchain client not available"})
+		return
+	}
 
 	// Get from blockchain
-	vestedAmount, err := h.blockchain.GetVestedAmount(normalizedAddress)
+	vestedAmount, err := h.blockchain.GetVestedAmount(c.Request.Context(), normalizedAddress)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get vested amount"})
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to get vested amount"})
 		return
 	}
 
 	// Also get schedule from database
-	schedule, err := h.db.GetScheduleByBeneficiary(normalizedAddress.Hex())
+	schedule, err := h.store(c).GetScheduleByBeneficiary(normalizedAddress.Hex())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		respondLookupError(c, err, "Schedule not found")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	var local *big.Int
+	if !schedule.Revoked {
+		local = schedule.VestedAmount(h.vestingModel, h.now())
+	}
+	vestedAmount, discrepancy, usedLocal := h.reconcileVested(normalizedAddress.Hex(), vestedAmount, local)
+
+	body := gin.H{
 		"beneficiary":   normalizedAddress.Hex(),
 		"vested_amount": vestedAmount.String(),
 		"total_amount":  schedule.Amount,
 		"released":      schedule.Released,
 		"unreleased":    vestedAmount.String(), // vested - released
+	}
+	if discrepancy {
+		body["vested_discrepancy"] = true
+	}
+	if usedLocal {
+		body["vested_source"] = "local"
+	}
+	respondJSON(c, http.StatusOK, body)
+}
+
+// SimulateRelease previews the outcome of a release without submitting a transaction
+// POST /api/admin/schedules/:address/simulate-release
+func (h *Handler) SimulateRelease(c *gin.Context) {
+	normalizedAddress, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.store(c).GetScheduleByBeneficiary(normalizedAddress.Hex())
+	if err != nil {
+		respondLookupError(c, err, "Schedule not found")
+		return
+	}
+
+	// Prefer the contract's view of vested; fall back to local math without a client
+	source := "onchain"
+	discrepancy := false
+	var vested *big.Int
+	if h.blockchain != nil {
+		vested, err = h.blockchain.GetVestedAmount(c.Request.Context(), normalizedAddress)
+		if err != nil {
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to get vested amount"})
+			return
+		}
+		if !schedule.Revoked {
+			var usedLocal bool
+			vested, discrepancy, usedLocal = h.reconcileVested(normalizedAddress.Hex(), vested, schedule.VestedAmount(h.vestingModel, h.now()))
+			if usedLocal {
+				source = "local"
+			}
+		}
+	} else {
+		source = "local"
+		vested = schedule.VestedAmount(h.vestingModel, h.now())
+	}
+
+	amount := parseAmount(schedule.Amount)
+	released := parseAmount(schedule.Released)
+
+	claimable := new(big.Int).Sub(vested, released)
+	if claimable.Sign() < 0 {
+		claimable = big.NewInt(0)
+	}
+	projectedReleased := new(big.Int).Add(released, claimable)
+	remaining := new(big.Int).Sub(amount, projectedReleased)
+
+	body := gin.H{
+		"beneficiary":        normalizedAddress.Hex(),
+		"vested_amount":      vested.String(),
+		"released":           released.String(),
+		"claimable":          claimable.String(),
+		"projected_released": projectedReleased.String(),
+		"remaining_balance":  remaining.String(),
+		"would_succeed":      claimable.Sign() > 0,
+		"vested_source":      source,
+	}
+	if discrepancy {
+		body["vested_discrepancy"] = true
+	}
+	respondJSON(c, http.StatusOK, body)
+}
+
+// setLabelsRequest is the body accepted by SetScheduleLabels
+type setLabelsRequest struct {
+	Labels []string `json:"labels"`
+}
+
+// SetScheduleLabels replaces the labels on a beneficiary's schedule
+// PUT /api/v1/admin/schedules/:address/labels
+func (h *Handler) SetScheduleLabels(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	var req setLabelsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Request body must be {\"labels\": [...]}"})
+		return
+	}
+
+	labels, err := normalizeLabels(req.Labels)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.db.SetScheduleLabels(normalizedAddress, labels); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondJSON(c, http.StatusNotFound, gin.H{"error": "Schedule not found"})
+			return
+		}
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to update labels"})
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary": normalizedAddress,
+		"labels":      labels,
+	})
+}
+
+// normalizeLabels trims and de-duplicates labels, preserving order, and
+// enforces the per-schedule limits
+func normalizeLabels(raw []string) ([]string, error) {
+	labels := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+
+	for _, label := range raw {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, errors.New("labels must not be empty")
+		}
+		if len(label) > maxLabelLength {
+			return nil, fmt.Errorf("labels must be at most %d characters", maxLabelLength)
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+
+	if len(labels) > maxLabelsPerSchedule {
+		return nil, fmt.Errorf("at most %d labels are allowed", maxLabelsPerSchedule)
+	}
+	return labels, nil
+}
+
+// GetRawLogs returns a beneficiary's raw contract logs straight from chain, unparsed, for debugging
+// GET /api/v1/admin/logs/:address
+func (h *Handler) GetRawLogs(c *gin.Context) {
+	normalizedAddress, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	if h.logs == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Blockchain client not available"})
+		return
+	}
+
+	logs, err := h.logs.GetBeneficiaryLogs(c.Request.Context(), normalizedAddress)
+	if err != nil {
+		log.Printf("⚠️  Failed to fetch raw logs for %s: %v", normalizedAddress.Hex(), err)
+		respondJSON(c, http.StatusBadGateway, gin.H{"error": "Failed to fetch logs from chain"})
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary": normalizedAddress.Hex(),
+		"logs":        logs,
+		"count":       len(logs),
+	})
+}
+
+// ReplayEvents republishes indexed events in a block range to downstream consumers
+// POST /api/v1/admin/replay?from_block=100&to_block=200
+func (h *Handler) ReplayEvents(c *gin.Context) {
+	filter, err := parseBlockRange(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.FromBlock == nil || filter.ToBlock == nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "from_block and to_block are required"})
+		return
+	}
+	if h.replayMaxBlocks > 0 && *filter.ToBlock-*filter.FromBlock >= h.replayMaxBlocks {
+		respondJSON(c, http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Replay range exceeds %d blocks; split it into smaller requests", h.replayMaxBlocks),
+		})
+		return
+	}
+
+	if h.replayer == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Event replay not available"})
+		return
+	}
+
+	replayed, err := h.replayer.Replay(c.Request.Context(), *filter.FromBlock, *filter.ToBlock)
+	if err != nil {
+		log.Printf("⚠️  Event replay of blocks %d-%d stopped after %d events: %v", *filter.FromBlock, *filter.ToBlock, replayed, err)
+		respondJSON(c, http.StatusBadGateway, gin.H{
+			"error":    "Event replay failed",
+			"replayed": replayed,
+		})
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"from_block": *filter.FromBlock,
+		"to_block":   *filter.ToBlock,
+		"replayed":   replayed,
+	})
+}
+
+// GetClaimable summarizes whether a beneficiary can claim tokens right now
+// GET /api/v1/beneficiaries/:address/claimable
+func (h *Handler) GetClaimable(c *gin.Context) {
+	normalizedAddress, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	// Include revoked schedules so the UI can explain why nothing is claimable
+	schedule, err := h.store(c).GetScheduleIncludingRevoked(normalizedAddress.Hex())
+	if err != nil {
+		respondLookupError(c, err, "Schedule not found")
+		return
+	}
+
+	now := h.now()
+	claimable := big.NewInt(0)
+	source := "local"
+	discrepancy := false
+
+	// A revoked schedule was settled on-chain at revocation
+	if !schedule.Revoked {
+		vested := schedule.VestedAmount(h.vestingModel, now)
+		if h.blockchain != nil {
+			onchain, err := h.blockchain.GetVestedAmount(c.Request.Context(), normalizedAddress)
+			if err != nil {
+				respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to get vested amount"})
+				return
+			}
+			var usedLocal bool
+			vested, discrepancy, usedLocal = h.reconcileVested(normalizedAddress.Hex(), onchain, vested)
+			if !usedLocal {
+				source = "onchain"
+			}
+		}
+
+		claimable.Sub(vested, parseAmount(schedule.Released))
+		if claimable.Sign() < 0 {
+			claimable.SetInt64(0)
+		}
+	}
+
+	body := gin.H{
+		"beneficiary":   normalizedAddress.Hex(),
+		"can_claim":     claimable.Sign() > 0,
+		"claimable":     claimable.String(),
+		"cliff_reached": !now.Before(schedule.Cliff),
+		"revoked":       schedule.Revoked,
+		"vested_source": source,
+	}
+	if discrepancy {
+		body["vested_discrepancy"] = true
+	}
+	respondJSON(c, http.StatusOK, body)
+}
+
+// vestingBreakdown is the vested position of one schedule, or the sum over several
+type vestingBreakdown struct {
+	ScheduleID uint   `json:"schedule_id,omitempty"`
+	Token      string `json:"token,omitempty"`
+	Amount     string `json:"amount"`
+	Vested     string `json:"vested"`
+	Released   string `json:"released"`
+	Claimable  string `json:"claimable"`
+	Revoked    bool   `json:"revoked,omitempty"`
+}
+
+// GetBeneficiaryVested breaks down vested, released and claimable amounts for
+// each of a beneficiary's schedules, plus the combined total. Amounts come from
+// the local vesting formula since the contract only reports a per-address total.
+// GET /api/v1/beneficiaries/:address/vested
+func (h *Handler) GetBeneficiaryVested(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	schedules, err := h.store(c).GetSchedulesByBeneficiary(normalizedAddress)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		return
+	}
+	if len(schedules) == 0 {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+
+	now := h.now()
+	totalAmount, totalVested, totalReleased, totalClaimable := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	breakdown := make([]vestingBreakdown, 0, len(schedules))
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		amount := parseAmount(schedule.Amount)
+		vested := schedule.VestedAmount(h.vestingModel, now)
+		released := parseAmount(schedule.Released)
+		claimable := schedule.ReleasableAmount(h.vestingModel, now)
+
+		breakdown = append(breakdown, vestingBreakdown{
+			ScheduleID: schedule.ID,
+			Token:      schedule.Token,
+			Amount:     amount.String(),
+			Vested:     vested.String(),
+			Released:   released.String(),
+			Claimable:  claimable.String(),
+			Revoked:    schedule.Revoked,
+		})
+
+		totalAmount.Add(totalAmount, amount)
+		totalVested.Add(totalVested, vested)
+		totalReleased.Add(totalReleased, released)
+		totalClaimable.Add(totalClaimable, claimable)
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary": normalizedAddress,
+		"schedules":   breakdown,
+		"total": vestingBreakdown{
+			Amount:    totalAmount.String(),
+			Vested:    totalVested.String(),
+			Released:  totalReleased.String(),
+			Claimable: totalClaimable.String(),
+		},
+		"as_of": now.UTC(),
+	})
+}
+
+// GetBeneficiaryExists reports whether an address has any schedule, active or
+// revoked, or any indexed event, without returning the records themselves
+// GET /api/v1/beneficiaries/:address/exists
+func (h *Handler) GetBeneficiaryExists(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	presence, err := h.store(c).GetBeneficiaryPresence(normalizedAddress)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary":   normalizedAddress,
+		"exists":        presence.HasSchedules || presence.HasEvents,
+		"has_schedules": presence.HasSchedules,
+		"has_events":    presence.HasEvents,
+	})
+}
+
+// sharePercentDecimals is the number of decimal places in allocation share percentages
+const sharePercentDecimals = 4
+
+// GetBeneficiaryShare reports a beneficiary's total allocation and its
+// percentage of all allocations across the contract, for cap-table views.
+// Revoked schedules are excluded from both sides since their unvested tokens
+// returned to the owner.
+// GET /api/v1/beneficiaries/:address/share
+func (h *Handler) GetBeneficiaryShare(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	schedules, err := h.store(c).GetSchedulesByBeneficiary(normalizedAddress)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		return
+	}
+
+	allocation := new(big.Int)
+	active := 0
+	for i := range schedules {
+		if schedules[i].Revoked {
+			continue
+		}
+		allocation.Add(allocation, parseAmount(schedules[i].Amount))
+		active++
+	}
+	if active == 0 {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+
+	total, err := h.store(c).GetTotalAllocated()
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to compute total allocation"})
+		return
+	}
+
+	percentage := new(big.Rat)
+	if total.Sign() > 0 {
+		percentage.SetFrac(new(big.Int).Mul(allocation, big.NewInt(100)), total)
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary":     normalizedAddress,
+		"allocation":      allocation.String(),
+		"total_allocated": total.String(),
+		"percentage":      percentage.FloatString(sharePercentDecimals),
 	})
 }
 
+// parseAmount parses a stored decimal token amount, treating malformed values as zero
+func parseAmount(value string) *big.Int {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return amount
+}
+
 // GetEvents retrieves events for a beneficiary
-// GET /api/events/:address?limit=10&offset=0
+// GET /api/events/:address?limit=10&offset=0&cursor=...&from_block=0&to_block=0&min_amount=0&order=desc
 func (h *Handler) GetEvents(c *gin.Context) {
-	address := c.Param("address")
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseEventFilter(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A cursor takes precedence over offset
+	if filter.After, err = parseEventCursor(c); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.After != nil {
+		offset = 0
+	} else if err := h.checkOffset(offset, eventsDeepPagingHint); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := h.store(c).GetEventsByBeneficiary(normalizedAddress, filter, limit, offset)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		return
+	}
+
+	total, err := h.store(c).CountEventsByBeneficiaries([]string{normalizedAddress}, filter)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to count events"})
+		return
+	}
+
+	nextCursor := nextEventCursor(events, limit)
+	if filter.After != nil {
+		h.setCursorLink(c, limit, nextCursor)
+	} else {
+		h.setPaginationLinks(c, limit, offset, len(events))
+	}
+	respondJSON(c, http.StatusOK, gin.H{
+		"events":      events,
+		"limit":       limit,
+		"offset":      offset,
+		"count":       len(events),
+		"total":       total,
+		"next_cursor": nextCursor,
+	})
+}
+
+// GetEventsForBeneficiaries retrieves events for several beneficiaries at once
+// GET /api/events?beneficiaries=0x...,0x...&limit=10&offset=0&cursor=...
+func (h *Handler) GetEventsForBeneficiaries(c *gin.Context) {
+	raw := c.Query("beneficiaries")
+	if raw == "" {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "beneficiaries query parameter is required"})
+		return
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxBeneficiariesPerQuery {
+		respondJSON(c, http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Too many beneficiaries (max %d)", maxBeneficiariesPerQuery),
+		})
+		return
+	}
+
+	// Validate and normalize each address, dropping duplicates
+	seen := make(map[string]bool, len(parts))
+	addresses := make([]string, 0, len(parts))
+	for _, part := range parts {
+		address := strings.TrimSpace(part)
+		if !common.IsHexAddress(address) {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": ERR_INVALID_ETH_ADDRESS, "address": address})
+			return
+		}
+		normalized := common.HexToAddress(address).Hex()
+		if !seen[normalized] {
+			seen[normalized] = true
+			addresses = append(addresses, normalized)
+		}
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseEventFilter(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A cursor takes precedence over offset
+	if filter.After, err = parseEventCursor(c); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.After != nil {
+		offset = 0
+	} else if err := h.checkOffset(offset, eventsDeepPagingHint); err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := h.store(c).GetEventsByBeneficiaries(addresses, filter, limit, offset)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		return
+	}
+
+	total, err := h.store(c).CountEventsByBeneficiaries(addresses, filter)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to count events"})
+		return
+	}
+
+	nextCursor := nextEventCursor(events, limit)
+	if filter.After != nil {
+		h.setCursorLink(c, limit, nextCursor)
+	} else {
+		h.setPaginationLinks(c, limit, offset, len(events))
+	}
+	respondJSON(c, http.StatusOK, gin.H{
+		"events":        events,
+		"beneficiaries": addresses,
+		"limit":         limit,
+		"offset":        offset,
+		"count":         len(events),
+		"total":         total,
+		"next_cursor":   nextCursor,
+	})
+}
+
+// GetAllocationProof retrieves the merkle leaf and proof for a beneficiary's allocation
+// GET /api/allocations/:address/proof
+func (h *Handler) GetAllocationProof(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	allocation, err := h.store(c).GetMerkleAllocation(normalizedAddress)
+	if err != nil {
+		respondLookupError(c, err, "Allocation not found")
+		return
+	}
+
+	respondJSON(c, http.StatusOK, allocation)
+}
+
+// statusGroup aggregates the schedules sharing a derived status
+type statusGroup struct {
+	Count       int    `json:"count"`
+	TotalAmount string `json:"total_amount"`
+}
+
+// GetSchedulesByStatus returns schedule counts and total amounts grouped by derived status
+// GET /api/schedules/by-status
+func (h *Handler) GetSchedulesByStatus(c *gin.Context) {
+	schedules, err := h.allSchedules(c, database.ScheduleFilter{IncludeRevoked: true})
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		return
+	}
 
-	// Validate address format
-	if !common.IsHexAddress(address) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ERR_INVALID_ETH_ADDRESS})
+	statuses := []models.ScheduleStatus{
+		models.StatusPending, models.StatusCliff, models.StatusVesting, models.StatusVested, models.StatusRevoked,
+	}
+	counts := make(map[models.ScheduleStatus]int, len(statuses))
+	totals := make(map[models.ScheduleStatus]*big.Int, len(statuses))
+	for _, status := range statuses {
+		totals[status] = big.NewInt(0)
+	}
+
+	now := h.now()
+	for i := range schedules {
+		status := schedules[i].ComputeStatus(now)
+		counts[status]++
+		if amount, ok := new(big.Int).SetString(schedules[i].Amount, 10); ok {
+			totals[status].Add(totals[status], amount)
+		}
+	}
+
+	groups := make(map[models.ScheduleStatus]statusGroup, len(statuses))
+	for _, status := range statuses {
+		groups[status] = statusGroup{Count: counts[status], TotalAmount: totals[status].String()}
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"groups": groups,
+		"total":  len(schedules),
+	})
+}
+
+// GetReleasableNow computes the total releasable right now across all active schedules
+// GET /api/releasable-now
+func (h *Handler) GetReleasableNow(c *gin.Context) {
+	schedules, err := h.allSchedules(c, database.ScheduleFilter{})
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
 		return
 	}
 
-	// Normalize address
-	normalizedAddress := common.HexToAddress(address).Hex()
+	now := h.now()
+	total := big.NewInt(0)
+	for i := range schedules {
+		total.Add(total, schedules[i].ReleasableAmount(h.vestingModel, now))
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"releasable":     total.String(),
+		"schedule_count": len(schedules),
+		"as_of":          now.UTC(),
+	})
+}
+
+// maxVelocityDays bounds the forecast window of the velocity endpoint
+const maxVelocityDays = 3660
 
-	if limit > 1000 {
-		limit = 1000
+// GetVelocity forecasts how many tokens vest across all active schedules over
+// the next days (default 30), as the vested total at the end of the window
+// minus the vested total now
+// GET /api/v1/velocity?days=90
+func (h *Handler) GetVelocity(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > maxVelocityDays {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days must be an integer between 1 and %d", maxVelocityDays)})
+		return
 	}
 
-	events, err := h.db.GetEventsByBeneficiary(normalizedAddress, limit, offset)
+	schedules, err := h.allSchedules(c, database.ScheduleFilter{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"events": events,
-		"limit":  limit,
-		"offset": offset,
-		"count":  len(events),
+	now := h.now()
+	end := now.AddDate(0, 0, days)
+	vesting := big.NewInt(0)
+	for i := range schedules {
+		vesting.Add(vesting, schedules[i].VestedAmount(h.vestingModel, end))
+		vesting.Sub(vesting, schedules[i].VestedAmount(h.vestingModel, now))
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"days":           days,
+		"from":           now.UTC(),
+		"to":             end.UTC(),
+		"vesting":        vesting.String(),
+		"daily_average":  new(big.Int).Div(vesting, big.NewInt(int64(days))).String(),
+		"schedule_count": len(schedules),
 	})
 }
 
+// GetCompletion reports how far vesting has progressed across the contract:
+// the average completion of all active schedules, weighted by allocation.
+// Completions are exact fractions, so the average loses no precision before
+// it is formatted.
+// GET /api/v1/completion
+func (h *Handler) GetCompletion(c *gin.Context) {
+	schedules, err := h.allSchedules(c, database.ScheduleFilter{})
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		return
+	}
+
+	now := h.now()
+	allocated := new(big.Int)
+	weighted := new(big.Rat)
+	for i := range schedules {
+		amount := parseAmount(schedules[i].Amount)
+		if amount.Sign() <= 0 {
+			continue
+		}
+		allocated.Add(allocated, amount)
+		share := schedules[i].VestedFraction(h.vestingModel, now)
+		weighted.Add(weighted, share.Mul(share, new(big.Rat).SetInt(amount)))
+	}
+
+	percentage := new(big.Rat)
+	if allocated.Sign() > 0 {
+		percentage.Quo(weighted, new(big.Rat).SetInt(allocated))
+		percentage.Mul(percentage, big.NewRat(100, 1))
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"completion_percentage": percentage.FloatString(sharePercentDecimals),
+		"total_allocated":       allocated.String(),
+		"schedule_count":        len(schedules),
+		"as_of":                 now.UTC(),
+	})
+}
+
+// allSchedules loads every schedule matching the filter, paging through the database
+func (h *Handler) allSchedules(c *gin.Context, filter database.ScheduleFilter) ([]models.VestingSchedule, error) {
+	const pageSize = 1000
+
+	var all []models.VestingSchedule
+	for offset := 0; ; offset += pageSize {
+		page, err := h.store(c).GetAllSchedules(filter, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < pageSize {
+			return all, nil
+		}
+	}
+}
+
 // HealthCheck endpoint
 // GET /health
 func (h *Handler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
+	if h.syncProgress == nil {
+		respondJSON(c, http.StatusOK, gin.H{
+			"status":  "ok",
+			"service": "token-vesting-api",
+		})
+		return
+	}
+
+	progress := h.syncProgress.Progress()
+	status, code := "ok", http.StatusOK
+	if !progress.Healthy {
+		status, code = "unhealthy", http.StatusServiceUnavailable
+	}
+
+	respondJSON(c, code, gin.H{
+		"status":  status,
 		"service": "token-vesting-api",
+		"sync":    progress,
 	})
 }
 
+// readinessCheckTimeout bounds each dependency check made by /ready
+const readinessCheckTimeout = 2 * time.Second
+
+// DependencyStatus is the outcome of one readiness check
+type DependencyStatus struct {
+	Status string `json:"status"`          // "ok" or "down"
+	Error  string `json:"error,omitempty"` // Why the check failed; details are only logged
+}
+
+// ReadinessCheck reports whether the API can serve traffic: the database
+// answers a ping, the blockchain node returns its latest block, and the
+// startup historical sync has completed, since indexed history may otherwise
+// have gaps. It returns 503 with the status of each dependency when any check
+// fails. Unlike /health, it makes a round trip to every dependency.
+// GET /ready
+func (h *Handler) ReadinessCheck(c *gin.Context) {
+	checks := h.checkDependencies(c.Request.Context())
+
+	ready := true
+	for _, check := range checks {
+		if check.Status != "ok" {
+			ready = false
+		}
+	}
+
+	response := gin.H{"checks": checks}
+	if h.readiness != nil {
+		initialSync := h.readiness.InitialSync()
+		ready = ready && initialSync.Ready()
+		response["initial_sync"] = initialSync
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not_ready", http.StatusServiceUnavailable
+	}
+	response["status"] = status
+
+	respondJSON(c, code, response)
+}
+
+// checkDependencies pings the database and blockchain node concurrently,
+// skipping dependencies the handler was built without
+func (h *Handler) checkDependencies(ctx context.Context) map[string]DependencyStatus {
+	probes := map[string]func(context.Context) error{}
+	if h.db != nil {
+		probes["database"] = h.db.Ping
+	}
+	if h.blockchain != nil {
+		probes["blockchain"] = func(ctx context.Context) error {
+			_, err := h.blockchain.GetLatestBlockNumber(ctx)
+			return err
+		}
+	}
+
+	checks := make(map[string]DependencyStatus, len(probes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, probe := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+			defer cancel()
+
+			check := DependencyStatus{Status: "ok"}
+			if err := probe(probeCtx); err != nil {
+				// Connection errors can name internal hosts, so only the log sees them
+				log.Printf("⚠️  Readiness check for %s failed: %v", name, err)
+				check = DependencyStatus{Status: "down", Error: "unreachable"}
+				if errors.Is(err, context.DeadlineExceeded) {
+					check.Error = "timed out"
+				}
+			}
+			mu.Lock()
+			checks[name] = check
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return checks
+}
+
 // GetStats retrieves statistics about vesting schedules
 // GET /api/stats
+//
+// When the aggregate takes longer than the configured deadline, the last
+// successfully computed stats are served instead with "stale": true.
 func (h *Handler) GetStats(c *gin.Context) {
-	// This would aggregate data from the database
-	// For now, return basic stats
-	schedules, err := h.db.GetAllSchedules(1000, 0)
+	snapshot, stale, err := h.stats.get(h.statsDeadline, h.computeStats)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve stats"})
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve stats"})
+		return
+	}
+
+	body := gin.H{
+		"stale":       stale,
+		"computed_at": snapshot.computedAt,
+	}
+	for key, value := range snapshot.body {
+		body[key] = value
+	}
+	respondJSON(c, http.StatusOK, body)
+}
+
+// computeStats aggregates schedule statistics in the database. Amounts cover
+// active schedules: total_vesting is their allocation, total_released what
+// has been released from them, and total_locked what the contract still holds.
+func (h *Handler) computeStats() (gin.H, error) {
+	total, err := h.db.CountSchedules(database.ScheduleFilter{IncludeRevoked: true})
+	if err != nil {
+		return nil, err
+	}
+	active, err := h.db.CountSchedules(database.ScheduleFilter{})
+	if err != nil {
+		return nil, err
+	}
+	sums, err := h.db.SumAmounts()
+	if err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"total_schedules":   total,
+		"active_schedules":  active,
+		"revoked_schedules": total - active,
+		"total_vesting":     sums.Allocated.String(),
+		"total_released":    sums.Released.String(),
+		"total_locked":      new(big.Int).Sub(sums.Allocated, sums.Released).String(),
+	}, nil
+}
+
+// GetBeneficiaryStats aggregates one address's schedules: what has vested
+// (read from chain when available), what was released, what is still pending
+// release, and when the last release happened
+// GET /api/v1/stats/:address
+func (h *Handler) GetBeneficiaryStats(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	schedules, err := h.store(c).GetSchedulesByBeneficiary(normalizedAddress)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+		return
+	}
+	if len(schedules) == 0 {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+
+	positions := []portfolioPosition{localPosition(normalizedAddress, schedules, h.vestingModel, h.now())}
+	h.attachOnChainVested(c.Request.Context(), positions)
+	position := positions[0]
+
+	pending := new(big.Int).Sub(position.vested, position.released)
+	if pending.Sign() < 0 {
+		pending.SetInt64(0)
+	}
+
+	releases, err := h.store(c).GetEventsByBeneficiary(normalizedAddress, database.EventFilter{EventType: "TokensReleased"}, 1, 0)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		return
+	}
+
+	stats := models.BeneficiaryStats{
+		Beneficiary:   normalizedAddress,
+		TotalVested:   position.vested.String(),
+		TotalReleased: position.released.String(),
+		TotalPending:  pending.String(),
+		ScheduleCount: len(schedules),
+		VestedSource:  position.VestedSource,
+	}
+	if len(releases) > 0 {
+		stats.LastReleaseDate = &releases[0].Timestamp
+	}
+
+	respondJSON(c, http.StatusOK, stats)
+}
+
+// GetContractInfo describes the indexed vesting contract, including the block
+// it was deployed in once that has been configured or detected
+// GET /api/v1/contract/info
+func (h *Handler) GetContractInfo(c *gin.Context) {
+	if h.contract == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Contract info not available"})
+		return
+	}
+
+	address := common.HexToAddress(h.contract.Address).Hex()
+	var deploymentBlock *uint64
+	block, found, err := h.store(c).GetDeploymentBlock(address)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve deployment block"})
+		return
+	}
+	if found {
+		deploymentBlock = &block
+	}
+
+	token := ""
+	if common.IsHexAddress(h.contract.Token) {
+		token = common.HexToAddress(h.contract.Token).Hex()
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"address":          address,
+		"token":            token,
+		"chain_id":         h.contract.ChainID,
+		"deployment_block": deploymentBlock,
+	})
+}
+
+// GetSyncBacklog reports the event processing backlog depth
+// GET /api/sync/backlog
+func (h *Handler) GetSyncBacklog(c *gin.Context) {
+	if h.listener == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Event listener not running"})
+		return
+	}
+
+	buffered, retrying, unpublished := h.listener.Backlog()
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"buffered":    buffered,
+		"retrying":    retrying,
+		"unpublished": unpublished,
+		"total":       buffered + retrying + unpublished,
+	})
+}
+
+// GetSyncEventTypes reports the latest block at which each event type was indexed
+// GET /api/v1/sync/event-types
+func (h *Handler) GetSyncEventTypes(c *gin.Context) {
+	marks, err := h.store(c).GetEventTypeHighWaterMarks()
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve event type progress"})
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"event_types": marks,
+	})
+}
+
+// GetSyncThroughput reports events processed per minute over recent windows
+// GET /api/sync/throughput
+func (h *Handler) GetSyncThroughput(c *gin.Context) {
+	if h.listener == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Event listener not running"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"total_schedules":  len(schedules),
-		"active_schedules": len(schedules), // Count non-revoked
+	respondJSON(c, http.StatusOK, gin.H{
+		"windows": h.listener.Throughput(),
 	})
 }
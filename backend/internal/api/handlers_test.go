@@ -1,38 +1,266 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 
+	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
 	"github.com/kaldun-tech/token-vesting-backend/internal/models"
 )
 
 // MockDatabase implements database methods for testing
 type MockDatabase struct {
-	GetScheduleFunc func(address string) (*models.VestingSchedule, error)
+	GetScheduleFunc               func(address string) (*models.VestingSchedule, error)
+	GetAllSchedulesFunc           func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error)
+	GetSchedulesByBeneficiaryFunc func(address string) ([]models.VestingSchedule, error)
+	GetTotalAllocatedFunc         func() (*big.Int, error)
+	CountSchedulesFunc            func(filter database.ScheduleFilter) (int64, error)
+	DeploymentBlocks              map[string]uint64
+	EventTypeHighWaterMarks       []database.EventTypeHighWater
+	Events                        []models.VestingEvent // Stored oldest first
+	ScannedRanges                 []models.SyncRange    // Ordered by first block
+	PingErr                       error                 // Returned by Ping to simulate a lost connection
 }
 
 func (m *MockDatabase) GetScheduleByBeneficiary(address string) (*models.VestingSchedule, error) {
 	if m.GetScheduleFunc != nil {
 		return m.GetScheduleFunc(address)
 	}
-	return nil, errors.New("not found")
+	return nil, gorm.ErrRecordNotFound
 }
 
-func (m *MockDatabase) GetEventsByBeneficiary(address string, limit, offset int) ([]models.VestingEvent, error) {
+func (m *MockDatabase) GetScheduleIncludingRevoked(address string) (*models.VestingSchedule, error) {
+	if m.GetScheduleFunc != nil {
+		return m.GetScheduleFunc(address)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockDatabase) GetSchedulesByBeneficiary(address string) ([]models.VestingSchedule, error) {
+	if m.GetSchedulesByBeneficiaryFunc != nil {
+		return m.GetSchedulesByBeneficiaryFunc(address)
+	}
+	return nil, nil
+}
+
+func (m *MockDatabase) GetTotalAllocated() (*big.Int, error) {
+	if m.GetTotalAllocatedFunc != nil {
+		return m.GetTotalAllocatedFunc()
+	}
+	return big.NewInt(0), nil
+}
+
+func (m *MockDatabase) CountSchedules(filter database.ScheduleFilter) (int64, error) {
+	if m.CountSchedulesFunc != nil {
+		return m.CountSchedulesFunc(filter)
+	}
+	filter.AfterID = 0
+	schedules, err := m.GetAllSchedules(filter, math.MaxInt32, 0)
+	return int64(len(schedules)), err
+}
+
+func (m *MockDatabase) CountSchedulesByStatus(status string, filter database.ScheduleFilter, now time.Time) (int64, error) {
+	filter.AfterID = 0
+	schedules, err := m.GetSchedulesByStatus(status, filter, now, math.MaxInt32, 0)
+	return int64(len(schedules)), err
+}
+
+func (m *MockDatabase) CountEventsByBeneficiaries(addresses []string, filter database.EventFilter) (int64, error) {
+	var count int64
+	for _, address := range addresses {
+		events, err := m.GetEventsByBeneficiary(address, filter, math.MaxInt32, 0)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(events))
+	}
+	return count, nil
+}
+
+func (m *MockDatabase) SumAmounts() (database.ScheduleSums, error) {
+	sums := database.ScheduleSums{Allocated: new(big.Int), Released: new(big.Int)}
+	schedules, err := m.GetAllSchedules(database.ScheduleFilter{}, math.MaxInt32, 0)
+	for _, schedule := range schedules {
+		sums.Allocated.Add(sums.Allocated, parseAmount(schedule.Amount))
+		sums.Released.Add(sums.Released, parseAmount(schedule.Released))
+	}
+	return sums, err
+}
+
+func (m *MockDatabase) GetBeneficiaryPresence(address string) (database.BeneficiaryPresence, error) {
+	var presence database.BeneficiaryPresence
+	if schedules, err := m.GetSchedulesByBeneficiary(address); err == nil && len(schedules) > 0 {
+		presence.HasSchedules = true
+	}
+	for _, event := range m.Events {
+		if event.Beneficiary == address {
+			presence.HasEvents = true
+		}
+	}
+	return presence, nil
+}
+
+func (m *MockDatabase) GetDeploymentBlock(contract string) (uint64, bool, error) {
+	block, ok := m.DeploymentBlocks[contract]
+	return block, ok, nil
+}
+
+func (m *MockDatabase) GetEventTypeHighWaterMarks() ([]database.EventTypeHighWater, error) {
+	return m.EventTypeHighWaterMarks, nil
+}
+
+func (m *MockDatabase) GetEventsByBeneficiary(address string, filter database.EventFilter, limit, offset int) ([]models.VestingEvent, error) {
+	events := []models.VestingEvent{}
+	for _, event := range m.Events {
+		if event.Beneficiary == address && (filter.EventType == "" || event.EventType == filter.EventType) {
+			events = append(events, event)
+		}
+	}
+	if offset >= len(events) {
+		return []models.VestingEvent{}, nil
+	}
+	events = events[offset:]
+	if limit < len(events) {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (m *MockDatabase) GetEventsByBeneficiaries(addresses []string, filter database.EventFilter, limit, offset int) ([]models.VestingEvent, error) {
 	return []models.VestingEvent{}, nil
 }
 
-func (m *MockDatabase) GetAllSchedules(limit, offset int) ([]models.VestingSchedule, error) {
+func (m *MockDatabase) GetEventBlocks(filter database.EventFilter, limit int) ([]uint64, error) {
+	blocks := []uint64{}
+	for _, event := range m.Events {
+		if (filter.FromBlock == nil || event.BlockNumber >= *filter.FromBlock) &&
+			(filter.ToBlock == nil || event.BlockNumber <= *filter.ToBlock) &&
+			!slices.Contains(blocks, event.BlockNumber) {
+			blocks = append(blocks, event.BlockNumber)
+		}
+	}
+	slices.Sort(blocks)
+	if limit < len(blocks) {
+		blocks = blocks[:limit]
+	}
+	return blocks, nil
+}
+
+func (m *MockDatabase) GetScannedRanges(filter database.EventFilter) ([]models.SyncRange, error) {
+	ranges := []models.SyncRange{}
+	for _, r := range m.ScannedRanges {
+		if (filter.FromBlock == nil || r.ToBlock >= *filter.FromBlock) &&
+			(filter.ToBlock == nil || r.FromBlock <= *filter.ToBlock) {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
+
+func (m *MockDatabase) GetEventsInRange(filter database.EventFilter, limit, offset int) ([]models.VestingEvent, error) {
+	events := []models.VestingEvent{}
+	for _, event := range m.Events {
+		if (filter.EventType == "" || event.EventType == filter.EventType) &&
+			(filter.FromBlock == nil || event.BlockNumber >= *filter.FromBlock) &&
+			(filter.ToBlock == nil || event.BlockNumber <= *filter.ToBlock) {
+			events = append(events, event)
+		}
+	}
+	if offset >= len(events) {
+		return []models.VestingEvent{}, nil
+	}
+	events = events[offset:]
+	if limit < len(events) {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (m *MockDatabase) GetLatestEventsByBeneficiaries(addresses []string) (map[string]models.VestingEvent, error) {
+	return map[string]models.VestingEvent{}, nil
+}
+
+func (m *MockDatabase) GetAllSchedules(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+	if m.GetAllSchedulesFunc != nil {
+		return m.GetAllSchedulesFunc(filter, limit, offset)
+	}
 	return []models.VestingSchedule{}, nil
 }
 
+// GetSchedulesByStatus filters GetAllSchedules in memory with the status boundaries
+func (m *MockDatabase) GetSchedulesByStatus(status string, filter database.ScheduleFilter, now time.Time, limit, offset int) ([]models.VestingSchedule, error) {
+	filter.IncludeRevoked = true
+	all, err := m.GetAllSchedules(filter, math.MaxInt32, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []models.VestingSchedule{}
+	for _, schedule := range all {
+		phase := schedule.ComputeStatus(now)
+		var match bool
+		switch status {
+		case database.StatusFilterActive:
+			match = phase != models.StatusRevoked
+		case database.StatusFilterCliff:
+			match = phase == models.StatusPending || phase == models.StatusCliff
+		case database.StatusFilterVesting:
+			match = phase == models.StatusVesting
+		case database.StatusFilterCompleted:
+			match = phase == models.StatusVested
+		case database.StatusFilterRevoked:
+			match = phase == models.StatusRevoked
+		default:
+			return nil, database.ErrUnknownStatus
+		}
+		if match {
+			matches = append(matches, schedule)
+		}
+	}
+
+	if offset >= len(matches) {
+		return []models.VestingSchedule{}, nil
+	}
+	matches = matches[offset:]
+	if limit < len(matches) {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (m *MockDatabase) SetScheduleLabels(address string, labels []string) error {
+	return nil
+}
+
+func (m *MockDatabase) Ping(ctx context.Context) error {
+	return m.PingErr
+}
+
+func (m *MockDatabase) GetMerkleAllocation(address string) (*models.MerkleAllocation, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
 func (m *MockDatabase) CreateOrUpdateSchedule(schedule *models.VestingSchedule) error {
 	return nil
 }
@@ -41,7 +269,7 @@ func (m *MockDatabase) CreateEvent(event *models.VestingEvent) error {
 	return nil
 }
 
-func (m *MockDatabase) UpdateReleased(beneficiary, amount string) error {
+func (m *MockDatabase) UpdateReleased(id uint, amount string) error {
 	return nil
 }
 
@@ -53,6 +281,30 @@ func (m *MockDatabase) GetLastProcessedBlock() (uint64, error) {
 	return 0, nil
 }
 
+// MockBlockchain implements blockchain client methods for testing
+type MockBlockchain struct {
+	GetVestedAmountFunc      func(beneficiary common.Address) (*big.Int, error)
+	GetVestedAmountCtxFunc   func(ctx context.Context, beneficiary common.Address) (*big.Int, error) // Takes precedence when set
+	GetLatestBlockNumberFunc func(ctx context.Context) (uint64, error)
+}
+
+func (m *MockBlockchain) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	if m.GetLatestBlockNumberFunc != nil {
+		return m.GetLatestBlockNumberFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockBlockchain) GetVestedAmount(ctx context.Context, beneficiary common.Address) (*big.Int, error) {
+	if m.GetVestedAmountCtxFunc != nil {
+		return m.GetVestedAmountCtxFunc(ctx, beneficiary)
+	}
+	if m.GetVestedAmountFunc != nil {
+		return m.GetVestedAmountFunc(beneficiary)
+	}
+	return big.NewInt(0), nil
+}
+
 // TestGetSchedule_InvalidAddress tests address validation
 func TestGetSchedule_InvalidAddress(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -124,7 +376,7 @@ func TestGetSchedule_ValidAddress(t *testing.T) {
 	validAddresses := []string{
 		"0xF25DA65784D566fFCC60A1f113650afB688A14ED",
 		"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0", // Fixed: added last char
-		"0x0000000000000000000000000000000000000000", // Zero address is valid format
+		"0x0000000000000000000000000000000000000001", // Near-zero address is a valid beneficiary
 		"0xffffffffffffffffffffffffffffffffffffffff", // All lowercase
 		"0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF", // All uppercase
 	}
@@ -153,6 +405,28 @@ func TestGetSchedule_ValidAddress(t *testing.T) {
 	}
 }
 
+// TestGetSchedule_DatabaseError tests that database failures are not reported as missing records
+func TestGetSchedule_DatabaseError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "address", Value: "0xF25DA65784D566fFCC60A1f113650afB688A14ED"}}
+
+	handler := &Handler{
+		db: &MockDatabase{
+			GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+				return nil, errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+			},
+		},
+	}
+
+	handler.GetSchedule(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "Schedule not found")
+}
+
 // TestGetVestedAmount_AddressValidation tests the vested amount endpoint validation
 func TestGetVestedAmount_AddressValidation(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -198,6 +472,59 @@ func TestGetEvents_AddressValidation(t *testing.T) {
 	assert.Equal(t, ERR_INVALID_ETH_ADDRESS, response["error"])
 }
 
+// TestZeroAddressRejected tests that beneficiary lookups reject the zero address
+func TestZeroAddressRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &Handler{
+		db: &MockDatabase{
+			GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+				t.Fatal("database should not be queried for the zero address")
+				return nil, nil
+			},
+		},
+		blockchain: &MockBlockchain{},
+	}
+
+	endpoints := map[string]gin.HandlerFunc{
+		"schedule":           handler.GetSchedule,
+		"events":             handler.GetEvents,
+		"vested":             handler.GetVestedAmount,
+		"beneficiary vested": handler.GetBeneficiaryVested,
+		"claimable":          handler.GetClaimable,
+		"simulate release":   handler.SimulateRelease,
+		"allocation proof":   handler.GetAllocationProof,
+		"schedule labels":    handler.SetScheduleLabels,
+		"raw logs":           handler.GetRawLogs,
+		"beneficiary stats":  handler.GetBeneficiaryStats,
+		"beneficiary exists": handler.GetBeneficiaryExists,
+		"schedule summary":   handler.GetScheduleSummaryPDF,
+	}
+
+	for _, address := range []string{
+		"0x0000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000",
+	} {
+		for name, endpoint := range endpoints {
+			t.Run(name+" "+address, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+				c.Params = gin.Params{{Key: "address", Value: address}}
+
+				endpoint(c)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+
+				var response map[string]string
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, ERR_ZERO_ADDRESS, response["error"])
+			})
+		}
+	}
+}
+
 // TestHealthCheck tests the health check endpoint
 func TestHealthCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -216,3 +543,1994 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, "ok", response["status"])
 	assert.Equal(t, "token-vesting-api", response["service"])
 }
+
+// mockSyncProgress reports a fixed sync progress
+type mockSyncProgress struct {
+	progress blockchain.SyncProgress
+}
+
+func (m *mockSyncProgress) Progress() blockchain.SyncProgress {
+	return m.progress
+}
+
+func TestHealthCheck_SyncStalled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	handler := &Handler{}
+	handler.SetSyncProgress(&mockSyncProgress{progress: blockchain.SyncProgress{
+		Healthy:   false,
+		Reason:    "indexer stuck",
+		Head:      1020,
+		Processed: 990,
+	}})
+	handler.HealthCheck(c)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "unhealthy", response["status"])
+	syncStatus := response["sync"].(map[string]interface{})
+	assert.Equal(t, false, syncStatus["healthy"])
+	assert.Equal(t, "indexer stuck", syncStatus["reason"])
+}
+
+// mockSyncMonitor reports a fixed backlog and throughput
+type mockSyncMonitor struct {
+	buffered    int
+	retrying    int
+	unpublished int
+	throughput  []blockchain.ThroughputWindow
+}
+
+func (m *mockSyncMonitor) Backlog() (buffered, retrying, unpublished int) {
+	return m.buffered, m.retrying, m.unpublished
+}
+
+func (m *mockSyncMonitor) Throughput() []blockchain.ThroughputWindow {
+	return m.throughput
+}
+
+// TestGetSyncBacklog tests the backlog endpoint
+func TestGetSyncBacklog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Reports buffered, retrying and unpublished events", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		handler := &Handler{listener: &mockSyncMonitor{buffered: 5, retrying: 2, unpublished: 4}}
+		handler.GetSyncBacklog(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]int
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, response["buffered"])
+		assert.Equal(t, 2, response["retrying"])
+		assert.Equal(t, 4, response["unpublished"])
+		assert.Equal(t, 11, response["total"])
+	})
+
+	t.Run("Listener not running", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		handler := &Handler{}
+		handler.GetSyncBacklog(c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+// TestGetSyncThroughput tests the throughput endpoint
+func TestGetSyncThroughput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	handler := &Handler{listener: &mockSyncMonitor{throughput: []blockchain.ThroughputWindow{
+		{Window: "1m0s", Events: 6, PerMinute: 6},
+		{Window: "5m0s", Events: 10, PerMinute: 2},
+	}}}
+	handler.GetSyncThroughput(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Windows []blockchain.ThroughputWindow `json:"windows"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	require.Len(t, response.Windows, 2)
+	assert.Equal(t, float64(6), response.Windows[0].PerMinute)
+	assert.Equal(t, 10, response.Windows[1].Events)
+}
+
+// TestGetEvents_BlockParams tests validation of block-number query params
+func TestGetEvents_BlockParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"Valid range", "from_block=100&to_block=200", http.StatusOK},
+		{"Negative from_block", "from_block=-1", http.StatusBadRequest},
+		{"Negative to_block", "to_block=-500", http.StatusBadRequest},
+		{"Overflowing uint64", "from_block=18446744073709551616", http.StatusBadRequest},
+		{"Overflowing int64", "to_block=9223372036854775808", http.StatusBadRequest},
+		{"Non-numeric", "from_block=latest", http.StatusBadRequest},
+		{"Inverted range", "from_block=200&to_block=100", http.StatusBadRequest},
+		{"Valid before_block", "from_block=100&before_block=200", http.StatusOK},
+		{"Negative before_block", "before_block=-1", http.StatusBadRequest},
+		{"Zero before_block", "before_block=0", http.StatusBadRequest},
+		{"Empty before_block range", "from_block=200&before_block=200", http.StatusBadRequest},
+		{"Both to_block and before_block", "to_block=100&before_block=200", http.StatusBadRequest},
+		{"Valid min_amount", "min_amount=1000000000000000000000", http.StatusOK},
+		{"Negative min_amount", "min_amount=-1", http.StatusBadRequest},
+		{"Decimal min_amount", "min_amount=1.5", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: "0xF25DA65784D566fFCC60A1f113650afB688A14ED"}}
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+
+			handler := &Handler{db: &MockDatabase{}}
+			handler.GetEvents(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+// TestParseBlockRange_BeforeBlock tests that before_block excludes its own block
+func TestParseBlockRange_BeforeBlock(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?from_block=100&before_block=200", nil)
+
+	filter, err := parseBlockRange(c)
+	require.NoError(t, err)
+	require.NotNil(t, filter.FromBlock)
+	require.NotNil(t, filter.ToBlock)
+	assert.Equal(t, uint64(100), *filter.FromBlock)
+	assert.Equal(t, uint64(199), *filter.ToBlock)
+}
+
+// TestGetAllSchedules_IncludeVested tests attaching on-chain vested amounts to a page
+func TestGetAllSchedules_IncludeVested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	carol := "0x0000000000000000000000000000000000000001"
+
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return []models.VestingSchedule{
+				{ID: 1, Beneficiary: alice, Amount: "1000"},
+				{ID: 2, Beneficiary: bob, Amount: "2000"},
+				{ID: 3, Beneficiary: carol, Amount: "3000"},
+			}, nil
+		},
+	}
+	bc := &MockBlockchain{
+		GetVestedAmountFunc: func(beneficiary common.Address) (*big.Int, error) {
+			switch beneficiary.Hex() {
+			case alice:
+				return big.NewInt(250), nil
+			case bob:
+				return big.NewInt(1500), nil
+			}
+			return nil, errors.New("rpc unavailable")
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?include_vested=true", nil)
+
+	handler := &Handler{db: db, blockchain: bc}
+	handler.GetAllSchedules(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Schedules []struct {
+			Beneficiary  string  `json:"beneficiary"`
+			Amount       string  `json:"amount"`
+			VestedAmount *string `json:"vested_amount"`
+		} `json:"schedules"`
+		VestedUnavailable int `json:"vested_unavailable"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Schedules, 3)
+
+	// Order is preserved and each schedule carries its own amount
+	assert.Equal(t, alice, response.Schedules[0].Beneficiary)
+	assert.Equal(t, "1000", response.Schedules[0].Amount)
+	assert.Equal(t, "250", *response.Schedules[0].VestedAmount)
+	assert.Equal(t, "1500", *response.Schedules[1].VestedAmount)
+
+	// Failed lookups degrade gracefully
+	assert.Nil(t, response.Schedules[2].VestedAmount)
+	assert.Equal(t, 1, response.VestedUnavailable)
+}
+
+func TestGetAllSchedules_IncludeVestedTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fast := []string{"0xF25DA65784D566fFCC60A1f113650afB688A14ED", "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"}
+	slow := []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"}
+
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return []models.VestingSchedule{
+				{ID: 1, Beneficiary: slow[0], Amount: "1000"},
+				{ID: 2, Beneficiary: fast[0], Amount: "2000"},
+				{ID: 3, Beneficiary: slow[1], Amount: "3000"},
+				{ID: 4, Beneficiary: fast[1], Amount: "4000"},
+			}, nil
+		},
+	}
+	bc := &MockBlockchain{
+		GetVestedAmountCtxFunc: func(ctx context.Context, beneficiary common.Address) (*big.Int, error) {
+			if slices.Contains(fast, beneficiary.Hex()) {
+				return big.NewInt(100), nil
+			}
+			// Slow addresses hang until the per-call timeout
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to get vested amount: %w", ctx.Err())
+			case <-time.After(5 * time.Second):
+				return big.NewInt(1), nil
+			}
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?include_vested=true", nil)
+
+	// With a single worker, the slow addresses would hold up the fast ones
+	// without the per-call timeout
+	handler := &Handler{db: db, blockchain: bc}
+	handler.SetVestedFetch(1, 50*time.Millisecond)
+
+	started := time.Now()
+	handler.GetAllSchedules(c)
+	assert.Less(t, time.Since(started), 2*time.Second)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Schedules []struct {
+			Beneficiary  string  `json:"beneficiary"`
+			VestedAmount *string `json:"vested_amount"`
+			VestedError  string  `json:"vested_error"`
+		} `json:"schedules"`
+		VestedUnavailable int `json:"vested_unavailable"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Schedules, 4)
+
+	for _, schedule := range response.Schedules {
+		if slices.Contains(fast, schedule.Beneficiary) {
+			if assert.NotNil(t, schedule.VestedAmount, schedule.Beneficiary) {
+				assert.Equal(t, "100", *schedule.VestedAmount)
+			}
+			assert.Empty(t, schedule.VestedError)
+		} else {
+			assert.Nil(t, schedule.VestedAmount, schedule.Beneficiary)
+			assert.Equal(t, "Timed out fetching vested amount", schedule.VestedError)
+		}
+	}
+	assert.Equal(t, 2, response.VestedUnavailable)
+}
+
+// TestGetReleasableNow tests the aggregate releasable amount across schedules
+func TestGetReleasableNow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	schedules := []models.VestingSchedule{
+		// Fully vested, partially released
+		{Beneficiary: "0x01", Start: now.Add(-2000 * time.Hour), Cliff: now.Add(-2000 * time.Hour), Duration: 3600, Amount: "1000", Released: "400"},
+		// Before cliff
+		{Beneficiary: "0x02", Start: now.Add(-time.Hour), Cliff: now.Add(time.Hour), Duration: 86400, Amount: "5000", Released: "0"},
+		// Mid vesting
+		{Beneficiary: "0x03", Start: now.Add(-12 * time.Hour), Cliff: now.Add(-12 * time.Hour), Duration: 86400, Amount: "1000000000000000000000", Released: "1000"},
+	}
+
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			if offset > 0 {
+				return nil, nil
+			}
+			return schedules, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	handler := &Handler{db: db}
+	handler.GetReleasableNow(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Releasable    string `json:"releasable"`
+		ScheduleCount int    `json:"schedule_count"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.ScheduleCount)
+
+	// Aggregate equals the sum of individual claimables. The mid-vesting
+	// schedule keeps accruing, so bound it between before and after the call.
+	sum := func(at time.Time) *big.Int {
+		total := big.NewInt(0)
+		for i := range schedules {
+			total.Add(total, schedules[i].ReleasableAmount(models.VestingFromStart, at))
+		}
+		return total
+	}
+	got, ok := new(big.Int).SetString(response.Releasable, 10)
+	assert.True(t, ok)
+	assert.True(t, got.Cmp(sum(now)) >= 0)
+	assert.True(t, got.Cmp(sum(time.Now())) <= 0)
+	assert.True(t, got.Cmp(big.NewInt(600)) > 0)
+}
+
+func TestGetVelocity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	schedules := []models.VestingSchedule{
+		// Fully vested, nothing left
+		{Start: now.Add(-200 * day), Cliff: now.Add(-200 * day), Duration: 100 * 86400, Amount: "1000"},
+		// Mid vesting: 30 of 100 days
+		{Start: now.Add(-10 * day), Cliff: now.Add(-10 * day), Duration: 100 * 86400, Amount: "1000"},
+		// Cliff inside the window releases the 35 days accrued by its end
+		{Start: now.Add(-5 * day), Cliff: now.Add(15 * day), Duration: 50 * 86400, Amount: "5000"},
+		// Finishes inside the window: the last 20 of 100 days
+		{Start: now.Add(-80 * day), Cliff: now.Add(-80 * day), Duration: 100 * 86400, Amount: "1000"},
+	}
+	handler := &Handler{
+		db: &MockDatabase{
+			GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+				if offset > 0 {
+					return nil, nil
+				}
+				return schedules, nil
+			},
+		},
+		clock: fixedClock(now),
+	}
+
+	request := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/velocity"+query, nil)
+		handler.GetVelocity(c)
+		return w
+	}
+
+	w := request("")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Days          int       `json:"days"`
+		To            time.Time `json:"to"`
+		Vesting       string    `json:"vesting"`
+		DailyAverage  string    `json:"daily_average"`
+		ScheduleCount int       `json:"schedule_count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 30, response.Days)
+	assert.Equal(t, now.Add(30*day), response.To)
+	assert.Equal(t, "4000", response.Vesting) // 300 + 3500 + 200
+	assert.Equal(t, "133", response.DailyAverage)
+	assert.Equal(t, 4, response.ScheduleCount)
+
+	// A longer window completes the mid-vesting and cliff schedules
+	w = request("?days=90")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "6100", response.Vesting) // 900 + 5000 + 200
+
+	for _, query := range []string{"?days=0", "?days=abc", "?days=100000"} {
+		assert.Equal(t, http.StatusBadRequest, request(query).Code, query)
+	}
+}
+
+func TestGetCompletion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	request := func(schedules []models.VestingSchedule) *httptest.ResponseRecorder {
+		handler := &Handler{
+			db: &MockDatabase{
+				GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+					if offset > 0 {
+						return nil, nil
+					}
+					return schedules, nil
+				},
+			},
+			clock: fixedClock(now),
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/completion", nil)
+		handler.GetCompletion(c)
+		return w
+	}
+
+	type response struct {
+		CompletionPercentage string `json:"completion_percentage"`
+		TotalAllocated       string `json:"total_allocated"`
+		ScheduleCount        int    `json:"schedule_count"`
+	}
+
+	t.Run("Weighted by allocation", func(t *testing.T) {
+		w := request([]models.VestingSchedule{
+			// Fully vested: 100% of 1000
+			{Start: now.Add(-200 * day), Cliff: now.Add(-200 * day), Duration: 100 * 86400, Amount: "1000"},
+			// A third of the way: 10 of 30 days of 3000
+			{Start: now.Add(-10 * day), Cliff: now.Add(-10 * day), Duration: 30 * 86400, Amount: "3000"},
+			// Before the cliff: 0% of 6000
+			{Start: now.Add(-5 * day), Cliff: now.Add(15 * day), Duration: 50 * 86400, Amount: "6000"},
+			// A seventh of the way: 1 of 7 days of 2000
+			{Start: now.Add(-1 * day), Cliff: now.Add(-1 * day), Duration: 7 * 86400, Amount: "2000"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var result response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		// (1000 + 1000 + 0 + 2000/7) / 12000 = 19.047619...%. Summing vested
+		// amounts rounded to base units would give 19.0417%.
+		assert.Equal(t, "19.0476", result.CompletionPercentage)
+		assert.Equal(t, "12000", result.TotalAllocated)
+		assert.Equal(t, 4, result.ScheduleCount)
+	})
+
+	t.Run("No schedules", func(t *testing.T) {
+		w := request(nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var result response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, "0.0000", result.CompletionPercentage)
+		assert.Equal(t, "0", result.TotalAllocated)
+	})
+}
+
+// TestGetSchedulesByStatus tests grouping schedules by derived status
+func TestGetSchedulesByStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	day := 24 * time.Hour
+	schedules := []models.VestingSchedule{
+		// pending
+		{Start: now.Add(day), Cliff: now.Add(2 * day), Duration: 86400 * 10, Amount: "100"},
+		{Start: now.Add(day), Cliff: now.Add(2 * day), Duration: 86400 * 10, Amount: "200"},
+		// cliff
+		{Start: now.Add(-day), Cliff: now.Add(day), Duration: 86400 * 10, Amount: "1000"},
+		// vesting
+		{Start: now.Add(-5 * day), Cliff: now.Add(-day), Duration: 86400 * 10, Amount: "5000"},
+		// vested
+		{Start: now.Add(-20 * day), Cliff: now.Add(-15 * day), Duration: 86400 * 10, Amount: "7"},
+		// revoked
+		{Start: now.Add(-5 * day), Cliff: now.Add(-day), Duration: 86400 * 10, Amount: "300", Revoked: true},
+	}
+
+	var requested database.ScheduleFilter
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			requested = filter
+			if offset > 0 {
+				return nil, nil
+			}
+			return schedules, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	handler := &Handler{db: db}
+	handler.GetSchedulesByStatus(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, requested.IncludeRevoked, "grouping should cover revoked schedules")
+
+	var response struct {
+		Groups map[string]statusGroup `json:"groups"`
+		Total  int                    `json:"total"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 6, response.Total)
+	assert.Equal(t, statusGroup{Count: 2, TotalAmount: "300"}, response.Groups["pending"])
+	assert.Equal(t, statusGroup{Count: 1, TotalAmount: "1000"}, response.Groups["cliff"])
+	assert.Equal(t, statusGroup{Count: 1, TotalAmount: "5000"}, response.Groups["vesting"])
+	assert.Equal(t, statusGroup{Count: 1, TotalAmount: "7"}, response.Groups["vested"])
+	assert.Equal(t, statusGroup{Count: 1, TotalAmount: "300"}, response.Groups["revoked"])
+}
+
+// TestSimulateRelease tests previewing a release against mocked state
+func TestSimulateRelease(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	db := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{Beneficiary: address, Amount: "1000", Released: "250"}, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		vested   int64
+		expected map[string]interface{}
+	}{
+		{
+			name:   "Claimable tokens",
+			vested: 600,
+			expected: map[string]interface{}{
+				"claimable":          "350",
+				"projected_released": "600",
+				"remaining_balance":  "400",
+				"would_succeed":      true,
+			},
+		},
+		{
+			name:   "Nothing to claim",
+			vested: 250,
+			expected: map[string]interface{}{
+				"claimable":          "0",
+				"projected_released": "250",
+				"remaining_balance":  "750",
+				"would_succeed":      false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/admin/schedules/"+beneficiary+"/simulate-release", nil)
+			c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+			bc := &MockBlockchain{
+				GetVestedAmountFunc: func(common.Address) (*big.Int, error) {
+					return big.NewInt(tt.vested), nil
+				},
+			}
+			handler := &Handler{db: db, blockchain: bc}
+			handler.SimulateRelease(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "250", response["released"])
+			assert.Equal(t, "onchain", response["vested_source"])
+			for key, value := range tt.expected {
+				assert.Equal(t, value, response[key], key)
+			}
+		})
+	}
+}
+
+func TestGetClaimable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		schedule     models.VestingSchedule
+		canClaim     bool
+		cliffReached bool
+	}{
+		{
+			name: "Before cliff",
+			schedule: models.VestingSchedule{
+				Start:    now.Add(-time.Hour),
+				Cliff:    now.Add(time.Hour),
+				Duration: 4 * 3600,
+				Amount:   "1000",
+				Released: "0",
+			},
+			canClaim:     false,
+			cliffReached: false,
+		},
+		{
+			name: "Mid vesting",
+			schedule: models.VestingSchedule{
+				Start:    now.Add(-2 * time.Hour),
+				Cliff:    now.Add(-time.Hour),
+				Duration: 4 * 3600,
+				Amount:   "1000",
+				Released: "100",
+			},
+			canClaim:     true,
+			cliffReached: true,
+		},
+		{
+			name: "Revoked",
+			schedule: models.VestingSchedule{
+				Start:    now.Add(-2 * time.Hour),
+				Cliff:    now.Add(-time.Hour),
+				Duration: 4 * 3600,
+				Amount:   "1000",
+				Released: "500",
+				Revoked:  true,
+			},
+			canClaim:     false,
+			cliffReached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+			db := &MockDatabase{
+				GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+					schedule := tt.schedule
+					schedule.Beneficiary = address
+					return &schedule, nil
+				},
+			}
+			handler := &Handler{db: db}
+			handler.GetClaimable(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.canClaim, response["can_claim"])
+			assert.Equal(t, tt.cliffReached, response["cliff_reached"])
+			assert.Equal(t, tt.schedule.Revoked, response["revoked"])
+			assert.Equal(t, "local", response["vested_source"])
+
+			claimable, ok := new(big.Int).SetString(response["claimable"].(string), 10)
+			require.True(t, ok)
+			if tt.canClaim {
+				// Roughly half vested (500) minus 100 released
+				assert.True(t, claimable.Cmp(big.NewInt(390)) > 0 && claimable.Cmp(big.NewInt(410)) < 0, claimable.String())
+			} else {
+				assert.Equal(t, "0", claimable.String())
+			}
+		})
+	}
+}
+
+func TestGetClaimable_ZeroOnChainVested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Half of 1000 has vested locally, 100 released
+	schedule := models.VestingSchedule{Beneficiary: beneficiary, Start: now.Add(-50 * time.Second), Cliff: now.Add(-50 * time.Second), Duration: 100, Amount: "1000", Released: "100"}
+	db := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			s := schedule
+			return &s, nil
+		},
+	}
+	// As from a misconfigured contract address
+	bc := &MockBlockchain{
+		GetVestedAmountFunc: func(common.Address) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		fallback  bool
+		claimable string
+		source    string
+	}{
+		{name: "Warns without fallback", fallback: false, claimable: "0", source: "onchain"},
+		{name: "Falls back to local math", fallback: true, claimable: "400", source: "local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			original := log.Writer()
+			log.SetOutput(&buf)
+			defer log.SetOutput(original)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/beneficiaries/"+beneficiary+"/claimable", nil)
+			c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+			handler := &Handler{db: db, blockchain: bc, clock: fixedClock(now)}
+			handler.SetZeroVestedFallback(tt.fallback)
+			handler.GetClaimable(c)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.claimable, response["claimable"])
+			assert.Equal(t, tt.source, response["vested_source"])
+			assert.Equal(t, true, response["vested_discrepancy"])
+			assert.Contains(t, buf.String(), "On-chain vested amount for "+beneficiary+" is 0 but local vesting math gives 500")
+		})
+	}
+
+	t.Run("No discrepancy before anything vests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/beneficiaries/"+beneficiary+"/claimable", nil)
+		c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+		handler := &Handler{db: db, blockchain: bc, clock: fixedClock(now.Add(-time.Hour))}
+		handler.SetZeroVestedFallback(true)
+		handler.GetClaimable(c)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "onchain", response["vested_source"])
+		assert.NotContains(t, response, "vested_discrepancy")
+	})
+}
+
+func TestGetBeneficiaryVested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	past := time.Now().Add(-48 * time.Hour)
+	future := time.Now().Add(48 * time.Hour)
+
+	db := &MockDatabase{
+		GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+			return []models.VestingSchedule{
+				// Fully vested, partly released
+				{ID: 1, Beneficiary: address, Token: "0x1111111111111111111111111111111111111111", Start: past, Cliff: past, Duration: 3600, Amount: "1000", Released: "400"},
+				// Still before its cliff
+				{ID: 2, Beneficiary: address, Token: "0x2222222222222222222222222222222222222222", Start: past, Cliff: future, Duration: 7 * 24 * 3600, Amount: "500", Released: "0"},
+			}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "address", Value: strings.ToLower(beneficiary)}}
+
+	handler := &Handler{db: db}
+	handler.GetBeneficiaryVested(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Beneficiary string             `json:"beneficiary"`
+		Schedules   []vestingBreakdown `json:"schedules"`
+		Total       vestingBreakdown   `json:"total"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, beneficiary, response.Beneficiary)
+	require.Len(t, response.Schedules, 2)
+	assert.Equal(t, vestingBreakdown{ScheduleID: 1, Token: "0x1111111111111111111111111111111111111111", Amount: "1000", Vested: "1000", Released: "400", Claimable: "600"}, response.Schedules[0])
+	assert.Equal(t, vestingBreakdown{ScheduleID: 2, Token: "0x2222222222222222222222222222222222222222", Amount: "500", Vested: "0", Released: "0", Claimable: "0"}, response.Schedules[1])
+	assert.Equal(t, vestingBreakdown{Amount: "1500", Vested: "1000", Released: "400", Claimable: "600"}, response.Total)
+
+	t.Run("No schedules", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+		handler := &Handler{db: &MockDatabase{}}
+		handler.GetBeneficiaryVested(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// mockTokenDecimals serves fixed decimals per token
+type mockTokenDecimals map[string]uint8
+
+func (m mockTokenDecimals) Decimals(token string) uint8 {
+	return m[token]
+}
+
+func TestGetAllSchedules_FormatsWithTokenDecimals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	usdc := "0x1111111111111111111111111111111111111111"
+	vest := "0x2222222222222222222222222222222222222222"
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return []models.VestingSchedule{
+				{ID: 1, Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", Token: usdc, Amount: "1500000", Released: "250000"},
+				{ID: 2, Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Token: vest, Amount: "1500000000000000000000", Released: "0"},
+			}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules", nil)
+
+	handler := &Handler{db: db}
+	handler.SetTokenDecimals(mockTokenDecimals{usdc: 6, vest: 18})
+	handler.GetAllSchedules(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Schedules []models.VestingSchedule `json:"schedules"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Schedules, 2)
+
+	assert.Equal(t, &models.FormattedAmounts{Decimals: 6, Amount: "1.5", Released: "0.25"}, response.Schedules[0].Formatted)
+	assert.Equal(t, &models.FormattedAmounts{Decimals: 18, Amount: "1500", Released: "0"}, response.Schedules[1].Formatted)
+	// Raw base-unit amounts are unchanged
+	assert.Equal(t, "1500000", response.Schedules[0].Amount)
+}
+
+func TestGetSchedule_AmountDisplayDecimals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	token := "0x2222222222222222222222222222222222222222"
+	db := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{Beneficiary: beneficiary, Token: token, Amount: "1234567890123456789012", Released: "987654321098765432"}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary, nil)
+	c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+	handler := &Handler{db: db}
+	handler.SetTokenDecimals(mockTokenDecimals{token: 18})
+	handler.SetAmountDisplayDecimals(4)
+	handler.GetSchedule(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var schedule models.VestingSchedule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &schedule))
+	assert.Equal(t, &models.FormattedAmounts{Decimals: 18, Amount: "1234.5679", Released: "0.9877"}, schedule.Formatted)
+	// The raw base-unit amounts keep every digit
+	assert.Equal(t, "1234567890123456789012", schedule.Amount)
+	assert.Equal(t, "987654321098765432", schedule.Released)
+}
+
+func TestGetSchedule_TimeRemaining(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{Beneficiary: beneficiary, Start: start, Cliff: start, Duration: 3600, Amount: "1000", Released: "0"}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary, nil)
+	c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+	handler := &Handler{db: db, clock: fixedClock(start.Add(20 * time.Minute))}
+	handler.GetSchedule(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(2400), body["time_remaining_seconds"])
+	assert.Equal(t, "2024-01-01T01:00:00Z", body["fully_vested_at"])
+}
+
+// TestFutureStartSchedules checks that schedules starting in the future report
+// nothing vested across the endpoints built on local vesting math
+func TestFutureStartSchedules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := now.Add(10 * 24 * time.Hour)
+	schedules := []models.VestingSchedule{
+		// Starts in 10 days and vests over a year after a 30-day cliff
+		{ID: 1, Beneficiary: beneficiary, Start: start, Cliff: start.Add(30 * 24 * time.Hour), Duration: 365 * 86400, Amount: "3650", Released: "0"},
+		// Unlocks all at once in 10 days, with no cliff recorded
+		{ID: 2, Beneficiary: beneficiary, Start: start, Duration: 0, Amount: "1000", Released: "0"},
+	}
+
+	handler := &Handler{
+		db: &MockDatabase{
+			GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+				schedule := schedules[0]
+				return &schedule, nil
+			},
+			GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+				return schedules, nil
+			},
+			GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+				if offset > 0 {
+					return nil, nil
+				}
+				return schedules, nil
+			},
+		},
+		clock: fixedClock(now),
+	}
+
+	request := func(handle gin.HandlerFunc, target string) map[string]interface{} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+		handle(c)
+
+		require.Equal(t, http.StatusOK, w.Code, target)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("Schedule is pending", func(t *testing.T) {
+		body := request(handler.GetSchedule, "/api/v1/schedules/"+beneficiary)
+		assert.Equal(t, "pending", body["status"])
+		assert.Equal(t, float64(375*86400), body["time_remaining_seconds"])
+	})
+
+	t.Run("Nothing vested yet", func(t *testing.T) {
+		body := request(handler.GetBeneficiaryVested, "/api/v1/beneficiaries/"+beneficiary+"/vested")
+		total := body["total"].(map[string]interface{})
+		assert.Equal(t, "0", total["vested"])
+		assert.Equal(t, "0", total["claimable"])
+	})
+
+	t.Run("Zero progress", func(t *testing.T) {
+		body := request(handler.GetCompletion, "/api/v1/completion")
+		assert.Equal(t, "0.0000", body["completion_percentage"])
+	})
+
+	t.Run("Projection counts vesting after the start", func(t *testing.T) {
+		// The one-off grant unlocks inside the window; the other is still before its cliff
+		body := request(handler.GetVelocity, "/api/v1/velocity?days=30")
+		assert.Equal(t, "1000", body["vesting"])
+
+		body = request(handler.GetVelocity, "/api/v1/velocity?days=5")
+		assert.Equal(t, "0", body["vesting"])
+	})
+}
+
+// mockLogReader returns fixed raw logs
+type mockLogReader struct {
+	logs        []types.Log
+	beneficiary common.Address
+}
+
+func (m *mockLogReader) GetBeneficiaryLogs(ctx context.Context, beneficiary common.Address) ([]types.Log, error) {
+	m.beneficiary = beneficiary
+	return m.logs, nil
+}
+
+func TestGetRawLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	reader := &mockLogReader{logs: []types.Log{{
+		Address:     common.HexToAddress("0x5D6709Ce17C956833b66Ade058832C1890af19b7"),
+		Topics:      []common.Hash{common.HexToHash("0xabc"), common.BytesToHash(beneficiary.Bytes())},
+		Data:        []byte{0x01, 0x02},
+		BlockNumber: 15123456,
+		TxHash:      common.HexToHash("0xdef"),
+		Index:       3,
+	}}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Params = gin.Params{{Key: "address", Value: strings.ToLower(beneficiary.Hex())}}
+
+	handler := &Handler{logs: reader}
+	handler.GetRawLogs(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, beneficiary, reader.beneficiary)
+
+	var response struct {
+		Logs  []types.Log `json:"logs"`
+		Count int         `json:"count"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, 1, response.Count)
+	assert.Equal(t, reader.logs[0].Topics, response.Logs[0].Topics)
+	assert.Equal(t, reader.logs[0].Data, response.Logs[0].Data)
+	assert.Equal(t, uint64(15123456), response.Logs[0].BlockNumber)
+	assert.Equal(t, reader.logs[0].TxHash, response.Logs[0].TxHash)
+	assert.Equal(t, uint(3), response.Logs[0].Index)
+
+	t.Run("Blockchain client not available", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Params = gin.Params{{Key: "address", Value: beneficiary.Hex()}}
+
+		handler := &Handler{}
+		handler.GetRawLogs(c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("Requires an admin key", func(t *testing.T) {
+		reader := &mockLogReader{}
+		router := SetupRouter(&Handler{logs: reader}, &config.Config{AccessLogMode: AccessLogOff, AdminAPIKeys: []string{"admin-key"}})
+		path := adminPathPrefix + "/logs/" + beneficiary.Hex()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, common.Address{}, reader.beneficiary, "the node must not be queried")
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(apiKeyHeader, "admin-key")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, beneficiary, reader.beneficiary)
+	})
+}
+
+// mockReplayer records the requested replay range
+type mockReplayer struct {
+	fromBlock, toBlock uint64
+	replayed           int
+}
+
+func (m *mockReplayer) Replay(ctx context.Context, fromBlock, toBlock uint64) (int, error) {
+	m.fromBlock, m.toBlock = fromBlock, toBlock
+	return m.replayed, nil
+}
+
+func TestReplayEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		maxBlocks    uint64
+		expectedCode int
+	}{
+		{name: "Valid range", query: "?from_block=100&to_block=200", expectedCode: http.StatusOK},
+		{name: "Missing to_block", query: "?from_block=100", expectedCode: http.StatusBadRequest},
+		{name: "Missing range", query: "", expectedCode: http.StatusBadRequest},
+		{name: "Inverted range", query: "?from_block=200&to_block=100", expectedCode: http.StatusBadRequest},
+		{name: "Range at the cap", query: "?from_block=100&to_block=200", maxBlocks: 101, expectedCode: http.StatusOK},
+		{name: "Range over the cap", query: "?from_block=100&to_block=200", maxBlocks: 100, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer := &mockReplayer{replayed: 7}
+			handler := &Handler{replayer: replayer, replayMaxBlocks: tt.maxBlocks}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/replay"+tt.query, nil)
+
+			handler.ReplayEvents(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, uint64(100), replayer.fromBlock)
+				assert.Equal(t, uint64(200), replayer.toBlock)
+
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, float64(7), response["replayed"])
+			} else {
+				assert.Zero(t, replayer.toBlock, "nothing may be replayed")
+			}
+		})
+	}
+
+	t.Run("Requires an admin key", func(t *testing.T) {
+		replayer := &mockReplayer{}
+		router := SetupRouter(&Handler{replayer: replayer}, &config.Config{AccessLogMode: AccessLogOff, AdminAPIKeys: []string{"admin-key"}})
+		path := adminPathPrefix + "/replay?from_block=100&to_block=200"
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Zero(t, replayer.toBlock, "nothing may be replayed")
+
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set(apiKeyHeader, "admin-key")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, uint64(200), replayer.toBlock)
+	})
+}
+
+func TestGetAllSchedules_SparseFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return []models.VestingSchedule{
+				{ID: 1, Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", Amount: "1000", Released: "250", Duration: 3600},
+				{ID: 2, Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Amount: "2000", Released: "0", Duration: 7200},
+			}, nil
+		},
+	}
+
+	t.Run("Only requested fields are returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?fields=beneficiary,amount,released", nil)
+
+		handler := &Handler{db: db}
+		handler.GetAllSchedules(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Schedules []map[string]interface{} `json:"schedules"`
+			Count     int                      `json:"count"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Schedules, 2)
+		assert.Equal(t, map[string]interface{}{
+			"beneficiary": "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+			"amount":      "1000",
+			"released":    "250",
+		}, response.Schedules[0])
+		assert.Equal(t, 2, response.Count)
+	})
+
+	t.Run("Unknown fields are rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?fields=beneficiary,version,secret", nil)
+
+		handler := &Handler{db: db}
+		handler.GetAllSchedules(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unknown fields: version, secret")
+	})
+}
+
+func TestGetAllSchedules_StatusFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return []models.VestingSchedule{
+				{ID: 1, Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", Start: now.Add(-time.Hour), Cliff: now.Add(-time.Hour), Duration: 7200, Amount: "1000"},
+				{ID: 2, Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Start: now.Add(-time.Hour), Cliff: now.Add(-time.Hour), Duration: 3600, Amount: "1000"},
+				{ID: 3, Beneficiary: "0x0000000000000000000000000000000000000001", Start: now.Add(-time.Hour), Cliff: now.Add(-time.Hour), Duration: 3600, Amount: "1000", Revoked: true},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		query    string
+		expected []uint
+	}{
+		{query: "status=vesting", expected: []uint{1}},
+		{query: "status=Completed", expected: []uint{2}}, // Ends exactly at the handler clock
+		{query: "status=revoked", expected: []uint{3}},
+		{query: "status=active", expected: []uint{1, 2}},
+		{query: "status=cliff", expected: []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?"+tt.query, nil)
+
+			handler := &Handler{db: db, clock: fixedClock(now)}
+			handler.GetAllSchedules(c)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var response struct {
+				Schedules []models.VestingSchedule `json:"schedules"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			ids := []uint{}
+			for _, schedule := range response.Schedules {
+				ids = append(ids, schedule.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("Unknown status is rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?status=paused", nil)
+
+		handler := &Handler{db: db}
+		handler.GetAllSchedules(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "status must be one of active, cliff, vesting, completed, revoked")
+	})
+}
+
+func TestGetSchedule_SparseFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary+"?fields=amount,status", nil)
+	c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+
+	handler := &Handler{db: &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{
+				Beneficiary: beneficiary,
+				Amount:      "1000",
+				Released:    "0",
+				Start:       time.Now().Add(-time.Hour),
+				Cliff:       time.Now().Add(-time.Hour),
+				Duration:    7200,
+			}, nil
+		},
+	}}
+	handler.GetSchedule(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]interface{}{"amount": "1000", "status": "vesting"}, response)
+}
+
+func TestGetSchedule_SchemaV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	handler := &Handler{db: &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{
+				ID:          42,
+				Beneficiary: beneficiary,
+				Start:       start,
+				Cliff:       start,
+				Duration:    3600,
+				Amount:      "1000",
+				Released:    "250",
+				Revocable:   true,
+				CreationTx:  "0xabc",
+				CreatedAt:   start,
+				UpdatedAt:   start,
+			}, nil
+		},
+	}}
+
+	request := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary+query, nil)
+		c.Request.Header.Set("Accept", scheduleV1MediaType)
+		c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+		handler.GetSchedule(c)
+		return w
+	}
+
+	w := request("")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, scheduleV1MediaType+"; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]interface{}{
+		"beneficiary":      beneficiary,
+		"token":            "",
+		"start":            "2025-01-01T00:00:00Z",
+		"cliff":            "2025-01-01T00:00:00Z",
+		"duration_seconds": float64(3600),
+		"amount":           "1000",
+		"released":         "250",
+		"revocable":        true,
+		"revoked":          false,
+		"status":           "vested",
+		"labels":           []interface{}{},
+	}, response)
+
+	// Options the v1 schema has no representation for are rejected
+	w = request("?fields=amount")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Plain JSON clients keep the model shape
+	w = httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary, nil)
+	c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+	handler.GetSchedule(c)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"id"`)
+}
+
+func TestGetBeneficiaryShare(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	schedules := map[string][]models.VestingSchedule{
+		alice: {
+			{ID: 1, Beneficiary: alice, Amount: "1000"},
+			{ID: 2, Beneficiary: alice, Amount: "500"},
+			{ID: 3, Beneficiary: alice, Amount: "9000", Revoked: true},
+		},
+		"0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea": {
+			{ID: 4, Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Amount: "2500"},
+		},
+		"0x0000000000000000000000000000000000000001": {
+			{ID: 5, Beneficiary: "0x0000000000000000000000000000000000000001", Amount: "700"},
+		},
+	}
+	db := &MockDatabase{
+		GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+			return schedules[address], nil
+		},
+		GetTotalAllocatedFunc: func() (*big.Int, error) {
+			// Active schedules: 1000 + 500 + 2500 + 700
+			return big.NewInt(4700), nil
+		},
+	}
+
+	tests := []struct {
+		name               string
+		address            string
+		expectedCode       int
+		expectedAllocation string
+		expectedPercentage string
+	}{
+		{name: "Multiple schedules", address: alice, expectedCode: http.StatusOK, expectedAllocation: "1500", expectedPercentage: "31.9149"},
+		{name: "Single schedule", address: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", expectedCode: http.StatusOK, expectedAllocation: "2500", expectedPercentage: "53.1915"},
+		{name: "Near-zero address", address: "0x0000000000000000000000000000000000000001", expectedCode: http.StatusOK, expectedAllocation: "700", expectedPercentage: "14.8936"},
+		{name: "No schedules", address: "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0", expectedCode: http.StatusNotFound},
+		{name: "Invalid address", address: "invalid", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: tt.address}}
+
+			handler := &Handler{db: db}
+			handler.GetBeneficiaryShare(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			var response map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedAllocation, response["allocation"])
+			assert.Equal(t, "4700", response["total_allocated"])
+			assert.Equal(t, tt.expectedPercentage, response["percentage"])
+		})
+	}
+}
+
+func TestGetContractInfo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	contract := "0x5d6709ce17C956833B66aDe058832c1890aF19b7"
+
+	t.Run("Includes persisted deployment block", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		handler := &Handler{db: &MockDatabase{DeploymentBlocks: map[string]uint64{contract: 15123456}}}
+		handler.SetContractInfo(ContractInfo{Address: strings.ToLower(contract), ChainID: 84532})
+		handler.GetContractInfo(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, contract, response["address"])
+		assert.Equal(t, float64(84532), response["chain_id"])
+		assert.Equal(t, float64(15123456), response["deployment_block"])
+	})
+
+	t.Run("Deployment block unknown", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		handler := &Handler{db: &MockDatabase{}}
+		handler.SetContractInfo(ContractInfo{Address: contract})
+		handler.GetContractInfo(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"deployment_block":null`)
+	})
+}
+
+// mockReadiness reports a fixed initial sync status
+type mockReadiness struct {
+	status blockchain.InitialSyncStatus
+}
+
+func (m *mockReadiness) InitialSync() blockchain.InitialSyncStatus {
+	return m.status
+}
+
+func TestReadinessCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		status       blockchain.InitialSyncStatus
+		expectedCode int
+	}{
+		{name: "Sync complete", status: blockchain.InitialSyncStatus{State: blockchain.InitialSyncComplete, Attempts: 2}, expectedCode: http.StatusOK},
+		{name: "Sync running", status: blockchain.InitialSyncStatus{State: blockchain.InitialSyncRunning, Attempts: 1}, expectedCode: http.StatusServiceUnavailable},
+		{name: "Sync failed", status: blockchain.InitialSyncStatus{State: blockchain.InitialSyncFailed, Attempts: 4, Error: "rpc timeout"}, expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/ready", nil)
+
+			handler := &Handler{}
+			handler.SetSyncReadiness(&mockReadiness{status: tt.status})
+			handler.ReadinessCheck(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response struct {
+				Status      string                       `json:"status"`
+				InitialSync blockchain.InitialSyncStatus `json:"initial_sync"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.status, response.InitialSync)
+		})
+	}
+}
+
+func TestReadinessCheck_Dependencies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type response struct {
+		Status string                      `json:"status"`
+		Checks map[string]DependencyStatus `json:"checks"`
+	}
+	ready := func(t *testing.T, handler *Handler) (int, response) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/ready", nil)
+
+		handler.ReadinessCheck(c)
+
+		var result response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return w.Code, result
+	}
+
+	t.Run("All dependencies healthy", func(t *testing.T) {
+		handler := &Handler{
+			db: &MockDatabase{},
+			blockchain: &MockBlockchain{GetLatestBlockNumberFunc: func(ctx context.Context) (uint64, error) {
+				return 19000000, nil
+			}},
+		}
+
+		code, result := ready(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", result.Status)
+		assert.Equal(t, map[string]DependencyStatus{
+			"database":   {Status: "ok"},
+			"blockchain": {Status: "ok"},
+		}, result.Checks)
+	})
+
+	t.Run("Database down", func(t *testing.T) {
+		handler := &Handler{
+			db:         &MockDatabase{PingErr: errors.New("dial tcp 10.0.0.5:5432: connect: connection refused")},
+			blockchain: &MockBlockchain{},
+		}
+
+		code, result := ready(t, handler)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "not_ready", result.Status)
+		assert.Equal(t, DependencyStatus{Status: "down", Error: "unreachable"}, result.Checks["database"])
+		assert.Equal(t, DependencyStatus{Status: "ok"}, result.Checks["blockchain"])
+	})
+
+	t.Run("Blockchain node hangs", func(t *testing.T) {
+		handler := &Handler{
+			db: &MockDatabase{},
+			blockchain: &MockBlockchain{GetLatestBlockNumberFunc: func(ctx context.Context) (uint64, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}},
+		}
+
+		code, result := ready(t, handler)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, DependencyStatus{Status: "down", Error: "timed out"}, result.Checks["blockchain"])
+	})
+}
+
+func TestGetSyncEventTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	marks := []database.EventTypeHighWater{
+		{EventType: "TokensReleased", LatestBlock: 420, Count: 3},
+		{EventType: "VestingScheduleCreated", LatestBlock: 250, Count: 2},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	handler := &Handler{db: &MockDatabase{EventTypeHighWaterMarks: marks}}
+	handler.GetSyncEventTypes(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		EventTypes []database.EventTypeHighWater `json:"event_types"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, marks, response.EventTypes)
+}
+
+func TestPagination_MaxOffset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	tests := []struct {
+		name         string
+		handle       func(h *Handler, c *gin.Context)
+		offset       string
+		expectedCode int
+	}{
+		{name: "Schedules at cap", handle: (*Handler).GetAllSchedules, offset: "500", expectedCode: http.StatusOK},
+		{name: "Schedules beyond cap", handle: (*Handler).GetAllSchedules, offset: "501", expectedCode: http.StatusBadRequest},
+		{name: "Events at cap", handle: (*Handler).GetEvents, offset: "500", expectedCode: http.StatusOK},
+		{name: "Events beyond cap", handle: (*Handler).GetEvents, offset: "100000", expectedCode: http.StatusBadRequest},
+		{name: "Multi-beneficiary events beyond cap", handle: (*Handler).GetEventsForBeneficiaries, offset: "501", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+			c.Request = httptest.NewRequest(http.MethodGet, "/?limit=1000&beneficiaries="+beneficiary+"&offset="+tt.offset, nil)
+
+			handler := &Handler{db: &MockDatabase{}}
+			handler.SetMaxOffset(500)
+			tt.handle(handler, c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusBadRequest {
+				assert.Contains(t, w.Body.String(), "offset must not exceed 500")
+			}
+		})
+	}
+}
+
+func TestPagination_InvalidParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	handlers := map[string]func(h *Handler, c *gin.Context){
+		"Schedules":                (*Handler).GetAllSchedules,
+		"Events":                   (*Handler).GetEvents,
+		"Multi-beneficiary events": (*Handler).GetEventsForBeneficiaries,
+	}
+	tests := []struct {
+		name    string
+		query   string
+		problem string
+	}{
+		{name: "Negative limit", query: "limit=-1", problem: "limit must be a positive integer"},
+		{name: "Zero limit", query: "limit=0", problem: "limit must be a positive integer"},
+		{name: "Non-numeric limit", query: "limit=all", problem: "limit must be a positive integer"},
+		{name: "Negative offset", query: "offset=-5", problem: "offset must be a non-negative integer"},
+		{name: "Non-numeric offset", query: "offset=ten", problem: "offset must be a non-negative integer"},
+	}
+
+	for route, handle := range handlers {
+		for _, tt := range tests {
+			t.Run(route+"/"+tt.name, func(t *testing.T) {
+				called := false
+				db := &MockDatabase{
+					GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+						called = true
+						return nil, nil
+					},
+				}
+
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+				c.Request = httptest.NewRequest(http.MethodGet, "/?beneficiaries="+beneficiary+"&"+tt.query, nil)
+
+				handle(&Handler{db: db}, c)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), tt.problem)
+				assert.False(t, called, "the database must not be queried")
+			})
+		}
+	}
+}
+
+func TestPagination_LinkHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockDB := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return make([]models.VestingSchedule, limit), nil
+		},
+		CountSchedulesFunc: func(filter database.ScheduleFilter) (int64, error) {
+			return 20, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		maxCap   int
+		expected string
+	}{
+		{
+			name:     "First page",
+			query:    "limit=2&token=0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+			expected: `</api/v1/schedules?limit=2&offset=2&token=0xF25DA65784D566fFCC60A1f113650afB688A14ED>; rel="next"`,
+		},
+		{
+			name:  "Middle page",
+			query: "limit=2&offset=3",
+			expected: `</api/v1/schedules?limit=2&offset=5>; rel="next", ` +
+				`</api/v1/schedules?limit=2&offset=1>; rel="prev"`,
+		},
+		{
+			name:     "Next page past offset cap",
+			query:    "limit=2&offset=4",
+			maxCap:   5,
+			expected: `</api/v1/schedules?limit=2&offset=2>; rel="prev"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?"+tt.query, nil)
+
+			handler := &Handler{db: mockDB}
+			handler.SetMaxOffset(tt.maxCap)
+			handler.SetPaginationLinks(true)
+			handler.GetAllSchedules(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Link"))
+		})
+	}
+
+	t.Run("Final page has no next link", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "address", Value: "0xF25DA65784D566fFCC60A1f113650afB688A14ED"}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/events/0xF25DA65784D566fFCC60A1f113650afB688A14ED?limit=10", nil)
+
+		handler := &Handler{db: &MockDatabase{Events: []models.VestingEvent{{Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", EventType: "VestingScheduleCreated"}}}}
+		handler.SetPaginationLinks(true)
+		handler.GetEvents(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+
+	t.Run("Cursor page links only forward", func(t *testing.T) {
+		cursorDB := &MockDatabase{
+			GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+				assert.Equal(t, uint(4), filter.AfterID)
+				assert.Equal(t, 0, offset)
+				return []models.VestingSchedule{{ID: 5}, {ID: 6}}, nil
+			},
+			CountSchedulesFunc: func(filter database.ScheduleFilter) (int64, error) {
+				return 6, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?limit=2&offset=6&cursor="+encodeCursor(scheduleCursor{ID: 4}), nil)
+
+		handler := &Handler{db: cursorDB}
+		handler.SetPaginationLinks(true)
+		handler.GetAllSchedules(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		next := encodeCursor(scheduleCursor{ID: 6})
+		assert.Equal(t, `</api/v1/schedules?cursor=`+next+`&limit=2>; rel="next"`, w.Header().Get("Link"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, next, body["next_cursor"])
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules?limit=2&offset=2", nil)
+
+		handler := &Handler{db: mockDB}
+		handler.GetAllSchedules(c)
+
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+}
+
+func TestGetStats_ServesStaleOnSlowAggregate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	calls := 0
+	mockDB := &MockDatabase{
+		CountSchedulesFunc: func(filter database.ScheduleFilter) (int64, error) {
+			calls++
+			if calls <= 2 {
+				return 3, nil
+			}
+			// Later aggregates hang until the test finishes
+			<-release
+			return 5, nil
+		},
+	}
+	handler := &Handler{db: mockDB, statsDeadline: 20 * time.Millisecond}
+
+	getStats := func() map[string]interface{} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		handler.GetStats(c)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	fresh := getStats()
+	assert.Equal(t, false, fresh["stale"])
+	assert.Equal(t, float64(3), fresh["total_schedules"])
+	require.NotEmpty(t, fresh["computed_at"])
+
+	stale := getStats()
+	assert.Equal(t, true, stale["stale"])
+	assert.Equal(t, float64(3), stale["total_schedules"])
+	assert.Equal(t, fresh["computed_at"], stale["computed_at"])
+}
+
+func TestGetBeneficiaryExists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	seeded := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	eventsOnly := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	mockDB := &MockDatabase{
+		GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+			if address == seeded {
+				return []models.VestingSchedule{{Beneficiary: seeded, Revoked: true}}, nil
+			}
+			return []models.VestingSchedule{}, nil
+		},
+		Events: []models.VestingEvent{{EventType: "TokensReleased", Beneficiary: eventsOnly}},
+	}
+
+	tests := []struct {
+		name           string
+		address        string
+		expectedStatus int
+		exists         bool
+		hasSchedules   bool
+		hasEvents      bool
+	}{
+		{"Seeded beneficiary", strings.ToLower(seeded), http.StatusOK, true, true, false},
+		{"Events only", eventsOnly, http.StatusOK, true, false, true},
+		{"Unknown address", "0x0000000000000000000000000000000000000001", http.StatusOK, false, false, false},
+		{"Invalid address", "0x123", http.StatusBadRequest, false, false, false},
+		{"Zero address", "0x0000000000000000000000000000000000000000", http.StatusBadRequest, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: tt.address}}
+
+			handler := &Handler{db: mockDB}
+			handler.GetBeneficiaryExists(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Exists       bool `json:"exists"`
+				HasSchedules bool `json:"has_schedules"`
+				HasEvents    bool `json:"has_events"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.exists, response.Exists)
+			assert.Equal(t, tt.hasSchedules, response.HasSchedules)
+			assert.Equal(t, tt.hasEvents, response.HasEvents)
+		})
+	}
+}
+
+// fixedClock is a Clock stopped at a given time
+type fixedClock time.Time
+
+func (f fixedClock) Now() time.Time {
+	return time.Time(f)
+}
+
+func TestHandler_UsesClockForVestingMath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	start := time.Now().Add(-time.Hour)
+	mockDB := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{
+				Beneficiary: address,
+				Start:       start,
+				Cliff:       start,
+				Duration:    int64((2 * time.Hour).Seconds()),
+				Amount:      "1000",
+				Released:    "0",
+			}, nil
+		},
+	}
+
+	// A clock corrected two hours back puts the schedule before its start
+	handler := &Handler{db: mockDB}
+	handler.SetClock(fixedClock(start.Add(-time.Hour)))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "address", Value: "0xF25DA65784D566fFCC60A1f113650afB688A14ED"}}
+	handler.GetSchedule(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response models.VestingSchedule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.StatusPending, response.Status)
+}
+
+func TestHandler_VestingModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cliff := start.Add(100 * time.Second)
+	schedule := models.VestingSchedule{
+		ID:       1,
+		Start:    start,
+		Cliff:    cliff,
+		Duration: 1000,
+		Amount:   "1000",
+		Released: "0",
+	}
+	mockDB := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			s := schedule
+			s.Beneficiary = address
+			return &s, nil
+		},
+		GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+			s := schedule
+			s.Beneficiary = address
+			return []models.VestingSchedule{s}, nil
+		},
+	}
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+
+	tests := []struct {
+		model    models.VestingModel
+		vesting  models.VestingParameters
+		expected string
+	}{
+		{
+			model:    models.VestingFromStart,
+			vesting:  models.VestingParameters{Model: models.VestingFromStart, EffectiveStart: start, End: start.Add(1000 * time.Second), EffectiveDuration: 1000},
+			expected: "550",
+		},
+		{
+			model:    models.VestingFromCliff,
+			vesting:  models.VestingParameters{Model: models.VestingFromCliff, EffectiveStart: cliff, End: start.Add(1000 * time.Second), EffectiveDuration: 900},
+			expected: "500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.model), func(t *testing.T) {
+			handler := &Handler{db: mockDB}
+			handler.SetClock(fixedClock(start.Add(550 * time.Second)))
+			handler.SetVestingModel(tt.model)
+
+			// The schedule reports its effective vesting parameters
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+			handler.GetSchedule(c)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var response models.VestingSchedule
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.NotNil(t, response.Vesting)
+			assert.Equal(t, tt.vesting, *response.Vesting)
+
+			// Local vesting math follows the model
+			w = httptest.NewRecorder()
+			c, _ = gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "address", Value: beneficiary}}
+			handler.GetBeneficiaryVested(c)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var vested struct {
+				Total vestingBreakdown `json:"total"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &vested))
+			assert.Equal(t, tt.expected, vested.Total.Vested)
+		})
+	}
+}
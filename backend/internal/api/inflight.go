@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rpcRetryAfterSeconds is the Retry-After hint sent when the RPC in-flight cap is reached
+const rpcRetryAfterSeconds = "1"
+
+// rpcConcurrencyLimit caps the number of RPC-backed requests served at once to
+// protect the RPC node. Requests over the cap are rejected immediately with 503
+// rather than queued, so a slow node cannot pile up waiting requests. A
+// non-positive cap disables the limit.
+func rpcConcurrencyLimit(maxInFlight int) gin.HandlerFunc {
+	if maxInFlight <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	slots := make(chan struct{}, maxInFlight)
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			c.Header("Retry-After", rpcRetryAfterSeconds)
+			respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Too many concurrent blockchain requests, try again shortly"})
+			c.Abort()
+			return
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
+
+// onlyWhen applies middleware to requests matching cond, for endpoints that
+// only call the RPC node when asked to (e.g. ?include_vested=true)
+func onlyWhen(cond func(*gin.Context) bool, middleware gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cond(c) {
+			middleware(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+// includesVested reports whether a schedule listing asks for on-chain vested amounts
+func includesVested(c *gin.Context) bool {
+	return c.Query("include_vested") == "true"
+}
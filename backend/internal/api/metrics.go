@@ -0,0 +1,131 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+)
+
+// Metric descriptors. Amounts are in the token's base units.
+var (
+	schedulesDesc = prometheus.NewDesc(
+		"token_vesting_schedules",
+		"Indexed vesting schedules by state.",
+		[]string{"state"}, nil,
+	)
+	allocatedDesc = prometheus.NewDesc(
+		"token_vesting_allocated_amount",
+		"Total allocation of active schedules, in token base units.",
+		nil, nil,
+	)
+	releasedDesc = prometheus.NewDesc(
+		"token_vesting_released_amount",
+		"Total released from active schedules, in token base units.",
+		nil, nil,
+	)
+	beneficiaryVestedDesc = prometheus.NewDesc(
+		"token_vesting_beneficiary_vested_amount",
+		"Vested amount of a watchlisted beneficiary under the local vesting formula, in token base units.",
+		[]string{"beneficiary"}, nil,
+	)
+	beneficiaryReleasedDesc = prometheus.NewDesc(
+		"token_vesting_beneficiary_released_amount",
+		"Released amount of a watchlisted beneficiary, in token base units.",
+		[]string{"beneficiary"}, nil,
+	)
+)
+
+// SetBeneficiaryMetrics exposes per-beneficiary vested and released gauges on
+// /metrics for the given addresses. Each address adds its own series, so the
+// list is kept to the grants alerting needs. An empty list exposes aggregates only.
+func (h *Handler) SetBeneficiaryMetrics(addresses []string) error {
+	watchlist := make([]string, 0, len(addresses))
+	seen := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid beneficiary address %q", address)
+		}
+		normalized := common.HexToAddress(address).Hex()
+		if !seen[normalized] {
+			seen[normalized] = true
+			watchlist = append(watchlist, normalized)
+		}
+	}
+	h.metricsWatchlist = watchlist
+	return nil
+}
+
+// metricsHandler serves Prometheus metrics computed from the database at
+// scrape time
+// GET /metrics
+func (h *Handler) metricsHandler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metricsCollector{h: h})
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// metricsCollector reads vesting metrics from the database on each scrape
+type metricsCollector struct {
+	h *Handler
+}
+
+// Describe implements prometheus.Collector
+func (m metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- schedulesDesc
+	ch <- allocatedDesc
+	ch <- releasedDesc
+	ch <- beneficiaryVestedDesc
+	ch <- beneficiaryReleasedDesc
+}
+
+// Collect implements prometheus.Collector. A failed query is reported as an
+// invalid metric; the remaining metrics are still served.
+func (m metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	total, err := m.h.db.CountSchedules(database.ScheduleFilter{IncludeRevoked: true})
+	if err == nil {
+		var active int64
+		if active, err = m.h.db.CountSchedules(database.ScheduleFilter{}); err == nil {
+			ch <- prometheus.MustNewConstMetric(schedulesDesc, prometheus.GaugeValue, float64(active), "active")
+			ch <- prometheus.MustNewConstMetric(schedulesDesc, prometheus.GaugeValue, float64(total-active), "revoked")
+		}
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to count schedules for metrics: %v", err)
+		ch <- prometheus.NewInvalidMetric(schedulesDesc, err)
+	}
+
+	if sums, err := m.h.db.SumAmounts(); err != nil {
+		log.Printf("⚠️  Failed to sum amounts for metrics: %v", err)
+		ch <- prometheus.NewInvalidMetric(allocatedDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(allocatedDesc, prometheus.GaugeValue, amountValue(sums.Allocated))
+		ch <- prometheus.MustNewConstMetric(releasedDesc, prometheus.GaugeValue, amountValue(sums.Released))
+	}
+
+	now := m.h.now()
+	for _, address := range m.h.metricsWatchlist {
+		schedules, err := m.h.db.GetSchedulesByBeneficiary(address)
+		if err != nil {
+			log.Printf("⚠️  Failed to read schedules of %s for metrics: %v", address, err)
+			ch <- prometheus.NewInvalidMetric(beneficiaryVestedDesc, err)
+			continue
+		}
+		position := localPosition(address, schedules, m.h.vestingModel, now)
+		ch <- prometheus.MustNewConstMetric(beneficiaryVestedDesc, prometheus.GaugeValue, amountValue(position.vested), address)
+		ch <- prometheus.MustNewConstMetric(beneficiaryReleasedDesc, prometheus.GaugeValue, amountValue(position.released), address)
+	}
+}
+
+// amountValue converts a base-unit amount to a gauge value. Very large
+// amounts lose precision, as every Prometheus sample is a float64.
+func amountValue(amount *big.Int) float64 {
+	value, _ := new(big.Float).SetInt(amount).Float64()
+	return value
+}
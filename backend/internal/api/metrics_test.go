@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestMetrics_BeneficiaryGauges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := now.Add(-50 * time.Second)
+
+	schedules := map[string][]models.VestingSchedule{
+		alice: {{ID: 1, Beneficiary: alice, Amount: "1000", Released: "100", Start: start, Cliff: start, Duration: 100}},
+		bob:   {{ID: 2, Beneficiary: bob, Amount: "400", Released: "0", Start: start, Cliff: start, Duration: 100}},
+	}
+	db := &MockDatabase{
+		GetAllSchedulesFunc: func(filter database.ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+			return append(append([]models.VestingSchedule{}, schedules[alice]...), schedules[bob]...), nil
+		},
+		GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+			return schedules[address], nil
+		},
+	}
+
+	scrape := func(handler *Handler) string {
+		router := SetupRouter(handler, &config.Config{AccessLogMode: AccessLogOff})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	t.Run("Aggregates only by default", func(t *testing.T) {
+		body := scrape(&Handler{db: db, clock: fixedClock(now)})
+		assert.Contains(t, body, `token_vesting_schedules{state="active"} 2`)
+		assert.Contains(t, body, "token_vesting_allocated_amount 1400")
+		assert.Contains(t, body, "token_vesting_released_amount 100")
+		assert.NotContains(t, body, "token_vesting_beneficiary_")
+	})
+
+	t.Run("Watchlisted beneficiaries only", func(t *testing.T) {
+		handler := &Handler{db: db, clock: fixedClock(now)}
+		require.NoError(t, handler.SetBeneficiaryMetrics([]string{"0xf25da65784d566ffcc60a1f113650afb688a14ed"}))
+
+		body := scrape(handler)
+		// Half of alice's 1000 has vested
+		assert.Contains(t, body, `token_vesting_beneficiary_vested_amount{beneficiary="`+alice+`"} 500`)
+		assert.Contains(t, body, `token_vesting_beneficiary_released_amount{beneficiary="`+alice+`"} 100`)
+		assert.NotContains(t, body, bob)
+	})
+
+	t.Run("Invalid watchlist address", func(t *testing.T) {
+		assert.Error(t, (&Handler{}).SetBeneficiaryMetrics([]string{"0x1234"}))
+	})
+}
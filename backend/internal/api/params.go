@@ -0,0 +1,228 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+)
+
+// isZeroAddress reports whether a valid hex address is the zero address
+func isZeroAddress(address string) bool {
+	return common.HexToAddress(address) == (common.Address{})
+}
+
+// parseAddressParam reads the :address path parameter, responding 400 to
+// invalid hex and to the zero address, which can never hold a schedule.
+// Reports false once it has responded.
+func parseAddressParam(c *gin.Context) (common.Address, bool) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": ERR_INVALID_ETH_ADDRESS})
+		return common.Address{}, false
+	}
+	if isZeroAddress(address) {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": ERR_ZERO_ADDRESS})
+		return common.Address{}, false
+	}
+	return common.HexToAddress(address), true
+}
+
+// parseScheduleFilter parses the token and label query parameters for schedule listings
+func parseScheduleFilter(c *gin.Context) (database.ScheduleFilter, error) {
+	var filter database.ScheduleFilter
+	if token := c.Query("token"); token != "" {
+		if !common.IsHexAddress(token) {
+			return filter, errors.New("Invalid token address")
+		}
+		filter.Token = common.HexToAddress(token).Hex()
+	}
+	filter.Label = strings.TrimSpace(c.Query("label"))
+	return filter, nil
+}
+
+// Page sizes for paginated listings
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000 // Larger limits are lowered to this
+)
+
+// parsePagination parses the limit and offset query parameters of paginated
+// listings. A limit must be positive and an offset non-negative; a non-positive
+// limit would otherwise reach the database as no LIMIT at all.
+func parsePagination(c *gin.Context) (limit, offset int, err error) {
+	limit, err = strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit < 1 {
+		return 0, 0, errors.New("limit must be a positive integer")
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	offset, err = strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		return 0, 0, errors.New("offset must be a non-negative integer")
+	}
+	return limit, offset, nil
+}
+
+// parseScheduleStatus parses the optional status query parameter of schedule
+// listings. Returns an empty status when the parameter is absent.
+func parseScheduleStatus(c *gin.Context) (string, error) {
+	status := strings.ToLower(strings.TrimSpace(c.Query("status")))
+	if status == "" || slices.Contains(database.StatusFilters, status) {
+		return status, nil
+	}
+	return "", fmt.Errorf("status must be one of %s", strings.Join(database.StatusFilters, ", "))
+}
+
+// parseBlockParam parses an optional block-number query parameter.
+// Returns nil when the parameter is absent. Negative, non-numeric and
+// overflowing values are rejected rather than wrapped.
+func parseBlockParam(c *gin.Context, name string) (*uint64, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(raw, "-") {
+		return nil, fmt.Errorf("%s must not be negative", name)
+	}
+
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, fmt.Errorf("%s is out of range", name)
+		}
+		return nil, fmt.Errorf("%s must be a block number", name)
+	}
+
+	// Block numbers are converted to int64 for RPC and SQL queries
+	if value > math.MaxInt64 {
+		return nil, fmt.Errorf("%s is out of range", name)
+	}
+
+	return &value, nil
+}
+
+// parseEventFilter parses the block range, minimum amount and ordering query parameters for event queries
+func parseEventFilter(c *gin.Context) (database.EventFilter, error) {
+	filter, err := parseBlockRange(c)
+	if err != nil {
+		return filter, err
+	}
+
+	if raw := strings.TrimSpace(c.Query("min_amount")); raw != "" {
+		minAmount, ok := new(big.Int).SetString(raw, 10)
+		if !ok || minAmount.Sign() < 0 {
+			return filter, errors.New("min_amount must be a non-negative integer amount in token base units")
+		}
+		filter.MinAmount = minAmount
+	}
+
+	switch strings.ToLower(c.DefaultQuery("order", "desc")) {
+	case "asc":
+		filter.Ascending = true
+	case "desc":
+	default:
+		return filter, errors.New("order must be asc or desc")
+	}
+
+	return filter, nil
+}
+
+// parseBlockRange parses the from_block and to_block query parameters, and
+// before_block as an exclusive alternative to to_block
+func parseBlockRange(c *gin.Context) (database.EventFilter, error) {
+	var filter database.EventFilter
+
+	fromBlock, err := parseBlockParam(c, "from_block")
+	if err != nil {
+		return filter, err
+	}
+	toBlock, err := parseBlockParam(c, "to_block")
+	if err != nil {
+		return filter, err
+	}
+	beforeBlock, err := parseBlockParam(c, "before_block")
+	if err != nil {
+		return filter, err
+	}
+
+	if beforeBlock != nil {
+		if toBlock != nil {
+			return filter, errors.New("to_block and before_block cannot be combined")
+		}
+		if *beforeBlock == 0 {
+			return filter, errors.New("before_block must be greater than 0")
+		}
+		if fromBlock != nil && *fromBlock >= *beforeBlock {
+			return filter, errors.New("from_block must be less than before_block")
+		}
+		last := *beforeBlock - 1
+		toBlock = &last
+	}
+
+	if fromBlock != nil && toBlock != nil && *fromBlock > *toBlock {
+		return filter, errors.New("from_block must not be greater than to_block")
+	}
+
+	filter.FromBlock = fromBlock
+	filter.ToBlock = toBlock
+	return filter, nil
+}
+
+// Alternatives to deep offsets suggested when pagination goes past the offset cap
+const (
+	eventsDeepPagingHint    = "from_block/to_block to walk the block range instead"
+	schedulesDeepPagingHint = "the /api/v1/schedules/export stream to read every schedule instead"
+)
+
+// checkOffset rejects offsets beyond the configured cap. Deep offsets make the
+// database scan and discard every preceding row, so clients walking a whole
+// table should use a range filter or export instead.
+func (h *Handler) checkOffset(offset int, alternative string) error {
+	if h.maxOffset > 0 && offset > h.maxOffset {
+		return fmt.Errorf("offset must not exceed %d; use %s", h.maxOffset, alternative)
+	}
+	return nil
+}
+
+// setPaginationLinks adds an RFC 8288 Link header pointing at the neighbouring
+// pages of an offset listing. A full page is assumed to have a next page, and
+// the next link is omitted when it would go past the offset cap.
+func (h *Handler) setPaginationLinks(c *gin.Context, limit, offset, count int) {
+	if !h.paginationLinks || c.Request == nil || limit <= 0 {
+		return
+	}
+
+	var links []string
+	if next := offset + limit; count >= limit && (h.maxOffset <= 0 || next <= h.maxOffset) {
+		links = append(links, paginationLink(c.Request, limit, next, "next"))
+	}
+	if offset > 0 {
+		links = append(links, paginationLink(c.Request, limit, max(offset-limit, 0), "prev"))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+// paginationLink formats a Link value for the request URL moved to another page
+func paginationLink(r *http.Request, limit, offset int, rel string) string {
+	target := *r.URL
+	query := target.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	target.RawQuery = query.Encode()
+	return fmt.Sprintf("<%s>; rel=%q", target.RequestURI(), rel)
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// portfolioRequest is the body of a portfolio query
+type portfolioRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+// portfolioTotals are amounts summed over schedules
+type portfolioTotals struct {
+	Allocated string `json:"allocated"`
+	Vested    string `json:"vested"`
+	Released  string `json:"released"`
+	Claimable string `json:"claimable"`
+}
+
+// portfolioPosition is one address's share of a portfolio
+type portfolioPosition struct {
+	Beneficiary       string `json:"beneficiary"`
+	Schedules         int    `json:"schedules"`
+	VestedSource      string `json:"vested_source,omitempty"`      // onchain or local; empty without schedules
+	VestedError       string `json:"vested_error,omitempty"`       // Why the on-chain read failed, when it fell back to local
+	VestedDiscrepancy bool   `json:"vested_discrepancy,omitempty"` // The contract reported zero vested against a positive local amount
+	portfolioTotals
+
+	allocated, vested, released, claimable *big.Int
+	settled                                *big.Int // Released by revoked schedules, all they will ever vest
+	active                                 bool     // Holds a schedule that is not revoked
+}
+
+// GetPortfolio combines the vesting positions of several addresses, such as
+// the wallets of one investor, into totals plus a per-address breakdown.
+// Vested amounts are read from chain when available, falling back to the
+// local vesting formula per address if the read fails.
+// POST /api/v1/portfolio
+func (h *Handler) GetPortfolio(c *gin.Context) {
+	var req portfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Addresses) == 0 {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Request body must be {\"addresses\": [...]}"})
+		return
+	}
+	if len(req.Addresses) > maxBeneficiariesPerQuery {
+		respondJSON(c, http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Too many addresses (max %d)", maxBeneficiariesPerQuery),
+		})
+		return
+	}
+
+	// Validate and normalize each address, dropping duplicates
+	seen := make(map[string]bool, len(req.Addresses))
+	addresses := make([]string, 0, len(req.Addresses))
+	for _, address := range req.Addresses {
+		if !common.IsHexAddress(address) {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": ERR_INVALID_ETH_ADDRESS, "address": address})
+			return
+		}
+		if isZeroAddress(address) {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": ERR_ZERO_ADDRESS, "address": address})
+			return
+		}
+		normalized := common.HexToAddress(address).Hex()
+		if !seen[normalized] {
+			seen[normalized] = true
+			addresses = append(addresses, normalized)
+		}
+	}
+
+	positions := make([]portfolioPosition, len(addresses))
+	for i, address := range addresses {
+		schedules, err := h.store(c).GetSchedulesByBeneficiary(address)
+		if err != nil {
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+			return
+		}
+		positions[i] = localPosition(address, schedules, h.vestingModel, h.now())
+	}
+	h.attachOnChainVested(c.Request.Context(), positions)
+
+	total := portfolioPosition{allocated: new(big.Int), vested: new(big.Int), released: new(big.Int), claimable: new(big.Int)}
+	for i := range positions {
+		position := &positions[i]
+		total.allocated.Add(total.allocated, position.allocated)
+		total.vested.Add(total.vested, position.vested)
+		total.released.Add(total.released, position.released)
+		total.claimable.Add(total.claimable, position.claimable)
+		position.portfolioTotals = position.totals()
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"addresses": positions,
+		"total":     total.totals(),
+		"as_of":     h.now().UTC(),
+	})
+}
+
+// localPosition sums an address's schedules with the local vesting formula.
+// Revoked schedules were settled at revocation, so what they released is all
+// that vested.
+func localPosition(address string, schedules []models.VestingSchedule, model models.VestingModel, now time.Time) portfolioPosition {
+	position := portfolioPosition{
+		Beneficiary: address,
+		Schedules:   len(schedules),
+		allocated:   new(big.Int),
+		vested:      new(big.Int),
+		released:    new(big.Int),
+		claimable:   new(big.Int),
+		settled:     new(big.Int),
+	}
+	if len(schedules) > 0 {
+		position.VestedSource = "local"
+	}
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		released := parseAmount(schedule.Released)
+		vested := released
+		if schedule.Revoked {
+			position.settled.Add(position.settled, released)
+		} else {
+			vested = schedule.VestedAmount(model, now)
+			position.active = true
+		}
+
+		position.allocated.Add(position.allocated, parseAmount(schedule.Amount))
+		position.vested.Add(position.vested, vested)
+		position.released.Add(position.released, released)
+		position.claimable.Add(position.claimable, schedule.ReleasableAmount(model, now))
+	}
+	return position
+}
+
+// attachOnChainVested replaces local vested and claimable amounts with the
+// contract's, for addresses with active schedules. Addresses whose read fails
+// or times out keep their local amounts, with the reason in vested_error.
+func (h *Handler) attachOnChainVested(ctx context.Context, positions []portfolioPosition) {
+	if h.blockchain == nil {
+		return
+	}
+
+	// Revoked schedules were settled on chain, so only active ones are read
+	var active []*portfolioPosition
+	var addresses []common.Address
+	for i := range positions {
+		if positions[i].active {
+			active = append(active, &positions[i])
+			addresses = append(addresses, common.HexToAddress(positions[i].Beneficiary))
+		}
+	}
+
+	for i, lookup := range h.fetchVestedAmounts(ctx, addresses) {
+		position := active[i]
+		if lookup.err != nil {
+			log.Printf("⚠️  Failed to get vested amount for %s, using local formula: %v", position.Beneficiary, lookup.err)
+			position.VestedError = vestedErrorMessage(lookup.err)
+			continue
+		}
+
+		// The contract reports the active schedule; revoked ones stay as settled
+		local := new(big.Int).Sub(position.vested, position.settled)
+		onchain, discrepancy, usedLocal := h.reconcileVested(position.Beneficiary, lookup.amount, local)
+		position.VestedDiscrepancy = discrepancy
+		if usedLocal {
+			continue
+		}
+		position.vested = new(big.Int).Add(onchain, position.settled)
+		position.claimable = new(big.Int).Sub(position.vested, position.released)
+		if position.claimable.Sign() < 0 {
+			position.claimable.SetInt64(0)
+		}
+		position.VestedSource = "onchain"
+	}
+}
+
+// totals renders the position's amounts as strings
+func (p *portfolioPosition) totals() portfolioTotals {
+	return portfolioTotals{
+		Allocated: p.allocated.String(),
+		Vested:    p.vested.String(),
+		Released:  p.released.String(),
+		Claimable: p.claimable.String(),
+	}
+}
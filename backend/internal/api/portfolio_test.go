@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestGetPortfolio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := now.Add(-50 * time.Second)
+
+	schedules := map[string][]models.VestingSchedule{
+		alice: {
+			{ID: 1, Beneficiary: alice, Amount: "1000", Released: "100", Start: start, Cliff: start, Duration: 100},
+			// Settled at revocation with 300 released
+			{ID: 2, Beneficiary: alice, Amount: "2000", Released: "300", Start: start, Cliff: start, Duration: 100, Revoked: true},
+		},
+		bob: {
+			{ID: 3, Beneficiary: bob, Amount: "400", Released: "0", Start: start, Cliff: start, Duration: 100},
+		},
+	}
+	handler := &Handler{
+		db: &MockDatabase{
+			GetSchedulesByBeneficiaryFunc: func(address string) ([]models.VestingSchedule, error) {
+				return schedules[address], nil
+			},
+		},
+		blockchain: &MockBlockchain{
+			GetVestedAmountFunc: func(beneficiary common.Address) (*big.Int, error) {
+				if beneficiary.Hex() == alice {
+					return big.NewInt(520), nil // Active schedule only
+				}
+				return nil, errors.New("rpc unavailable")
+			},
+		},
+		clock: fixedClock(now),
+	}
+	router := SetupRouter(handler, &config.Config{AccessLogMode: AccessLogOff})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/portfolio", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"addresses": ["` + strings.ToLower(alice) + `", "` + bob + `", "` + alice + `"]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Addresses []map[string]interface{} `json:"addresses"`
+		Total     map[string]string        `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	// Duplicates collapse after normalization
+	require.Len(t, response.Addresses, 2)
+	assert.Equal(t, map[string]interface{}{
+		"beneficiary":   alice,
+		"schedules":     float64(2),
+		"vested_source": "onchain",
+		"allocated":     "3000",
+		"vested":        "820",
+		"released":      "400",
+		"claimable":     "420",
+	}, response.Addresses[0])
+	// The failed on-chain read falls back to the local formula: half of 400 vested
+	assert.Equal(t, map[string]interface{}{
+		"beneficiary":   bob,
+		"schedules":     float64(1),
+		"vested_source": "local",
+		"vested_error":  "Failed to get vested amount",
+		"allocated":     "400",
+		"vested":        "200",
+		"released":      "0",
+		"claimable":     "200",
+	}, response.Addresses[1])
+	assert.Equal(t, map[string]string{
+		"allocated": "3400",
+		"vested":    "1020",
+		"released":  "400",
+		"claimable": "620",
+	}, response.Total)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"addresses": []}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"addresses": ["0x1234"]}`).Code)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitSweepInterval is how often idle clients are dropped from the limiter
+const rateLimitSweepInterval = time.Minute
+
+// rateLimiter keeps an in-memory token bucket per client
+type rateLimiter struct {
+	limit rate.Limit
+	burst int
+	idle  time.Duration // Clients unseen this long have a full bucket and are dropped
+	now   func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*rateLimitedClient
+	lastSweep time.Time
+}
+
+// rateLimitedClient is one client's bucket
+type rateLimitedClient struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// newRateLimiter creates a limiter allowing each client requestsPerSecond on
+// average, with bursts of up to burst requests
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	burst = max(burst, 1)
+
+	// A bucket left alone long enough to refill is the same as a new one, so
+	// dropping it never grants extra requests
+	idle := time.Duration(float64(burst) / requestsPerSecond * float64(time.Second))
+	idle = max(idle, rateLimitSweepInterval)
+
+	return &rateLimiter{
+		limit:   rate.Limit(requestsPerSecond),
+		burst:   burst,
+		idle:    idle,
+		now:     time.Now,
+		clients: make(map[string]*rateLimitedClient),
+	}
+}
+
+// allow takes a token from a client's bucket. When the bucket is empty it
+// reports false and how long until a token is available.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	client, ok := l.clients[key]
+	if !ok {
+		client = &rateLimitedClient{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[key] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep drops idle clients, at most once per sweep interval. Callers must hold mu.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, client := range l.clients {
+		if now.Sub(client.lastSeen) >= l.idle {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// size returns the number of clients being tracked
+func (l *rateLimiter) size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.clients)
+}
+
+// rateLimit rejects clients exceeding requestsPerSecond (after a burst) with
+// 429 and a Retry-After header. Clients are identified by API key when keys
+// are enforced, and by IP address otherwise, since unchecked keys could be
+// varied to dodge the limit. A non-positive rate disables limiting.
+func rateLimit(requestsPerSecond float64, burst int, byAPIKey bool) gin.HandlerFunc {
+	if requestsPerSecond <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	limiter := newRateLimiter(requestsPerSecond, burst)
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if byAPIKey {
+			if apiKey := requestAPIKey(c); apiKey != "" {
+				key = "key:" + apiKey
+			}
+		}
+
+		if ok, wait := limiter.allow(key); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			respondJSON(c, http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded, try again later"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
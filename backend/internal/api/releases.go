@@ -0,0 +1,243 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// releasePageSize is the number of release events read from the database per page
+const releasePageSize = 500
+
+// maxFilledReleaseDays bounds the series length when empty days are filled in
+const maxFilledReleaseDays = 3660
+
+// releaseDateLayout is the format of daily bucket dates and the from/to parameters
+const releaseDateLayout = "2006-01-02"
+
+// DailyRelease is the total released to a beneficiary on one day
+type DailyRelease struct {
+	Date   string `json:"date"`
+	Amount string `json:"amount"`
+	Count  int    `json:"count"`
+}
+
+// GetDailyReleases returns the amount released to a beneficiary per day,
+// bucketed in UTC or the ?tz= zone. Days without releases are omitted unless
+// fill_empty=true, which returns every day in the range with zero amounts.
+// GET /api/v1/schedules/:address/releases/daily?from=2025-01-01&to=2025-01-31&fill_empty=true
+func (h *Handler) GetDailyReleases(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+	location := responseLocation(c)
+
+	from, to, err := parseDateRange(c, location)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fillEmpty := false
+	if raw := c.Query("fill_empty"); raw != "" {
+		if fillEmpty, err = strconv.ParseBool(raw); err != nil {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "fill_empty must be true or false"})
+			return
+		}
+	}
+
+	events, err := h.releaseEvents(c, normalizedAddress, nil)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		return
+	}
+
+	days, total := bucketDailyReleases(events, location, from, to)
+	if fillEmpty {
+		if days, err = fillReleaseDays(days, location, from, to); err != nil {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary": normalizedAddress,
+		"timezone":    location.String(),
+		"days":        days,
+		"total":       total.String(),
+	})
+}
+
+// GetReleasedAt returns the cumulative amount released to a beneficiary as of
+// a block, summing TokensReleased events up to and including that block
+// GET /api/v1/schedules/:address/released-at?block=N
+func (h *Handler) GetReleasedAt(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	block, err := parseBlockParam(c, "block")
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if block == nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "block is required"})
+		return
+	}
+
+	events, err := h.releaseEvents(c, normalizedAddress, block)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		return
+	}
+
+	released := new(big.Int)
+	for _, event := range events {
+		if amount, ok := new(big.Int).SetString(event.Amount, 10); ok {
+			released.Add(released, amount)
+		}
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"beneficiary": normalizedAddress,
+		"block":       *block,
+		"released":    released.String(),
+		"releases":    len(events),
+	})
+}
+
+// releaseEvents reads every TokensReleased event for a beneficiary, oldest
+// first, optionally stopping at toBlock (inclusive)
+func (h *Handler) releaseEvents(c *gin.Context, beneficiary string, toBlock *uint64) ([]models.VestingEvent, error) {
+	filter := database.EventFilter{EventType: "TokensReleased", ToBlock: toBlock, Ascending: true}
+
+	var events []models.VestingEvent
+	for {
+		page, err := h.store(c).GetEventsByBeneficiary(beneficiary, filter, releasePageSize, len(events))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+		if len(page) < releasePageSize {
+			return events, nil
+		}
+	}
+}
+
+// parseDateRange parses the optional from and to dates (YYYY-MM-DD, both
+// inclusive) as the start of each day in location
+func parseDateRange(c *gin.Context, location *time.Location) (from, to *time.Time, err error) {
+	parse := func(name string) (*time.Time, error) {
+		raw := strings.TrimSpace(c.Query(name))
+		if raw == "" {
+			return nil, nil
+		}
+		day, err := time.ParseInLocation(releaseDateLayout, raw, location)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+		}
+		return &day, nil
+	}
+
+	if from, err = parse("from"); err != nil {
+		return nil, nil, err
+	}
+	if to, err = parse("to"); err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
+
+// bucketDailyReleases sums release amounts per calendar day in location,
+// skipping events outside the optional date range. Days are returned in order.
+func bucketDailyReleases(events []models.VestingEvent, location *time.Location, from, to *time.Time) ([]DailyRelease, *big.Int) {
+	days := []DailyRelease{}
+	sums := []*big.Int{}
+	total := new(big.Int)
+
+	for _, event := range events {
+		day := startOfDay(event.Timestamp, location)
+		if (from != nil && day.Before(*from)) || (to != nil && day.After(*to)) {
+			continue
+		}
+
+		amount, ok := new(big.Int).SetString(event.Amount, 10)
+		if !ok {
+			continue
+		}
+
+		date := day.Format(releaseDateLayout)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, DailyRelease{Date: date})
+			sums = append(sums, new(big.Int))
+		}
+		sums[len(sums)-1].Add(sums[len(sums)-1], amount)
+		days[len(days)-1].Count++
+		total.Add(total, amount)
+	}
+
+	for i := range days {
+		days[i].Amount = sums[i].String()
+	}
+	return days, total
+}
+
+// fillReleaseDays inserts zero buckets for days without releases, spanning the
+// requested range or, where a bound is open, the first or last release day
+func fillReleaseDays(days []DailyRelease, location *time.Location, from, to *time.Time) ([]DailyRelease, error) {
+	first, last := from, to
+	if len(days) > 0 {
+		if first == nil {
+			day, _ := time.ParseInLocation(releaseDateLayout, days[0].Date, location)
+			first = &day
+		}
+		if last == nil {
+			day, _ := time.ParseInLocation(releaseDateLayout, days[len(days)-1].Date, location)
+			last = &day
+		}
+	}
+	if first == nil || last == nil {
+		return days, nil
+	}
+
+	filled := []DailyRelease{}
+	next := 0
+	for day := *first; !day.After(*last); day = day.AddDate(0, 0, 1) {
+		if len(filled) == maxFilledReleaseDays {
+			return nil, fmt.Errorf("fill_empty spans more than %d days; narrow the range with from and to", maxFilledReleaseDays)
+		}
+		date := day.Format(releaseDateLayout)
+		if next < len(days) && days[next].Date == date {
+			filled = append(filled, days[next])
+			next++
+			continue
+		}
+		filled = append(filled, DailyRelease{Date: date, Amount: "0"})
+	}
+	return filled, nil
+}
+
+// startOfDay returns midnight of t's calendar day in location
+func startOfDay(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestGetDailyReleases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	release := func(hash, amount string, at time.Time) models.VestingEvent {
+		return models.VestingEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: amount, TransactionHash: hash, Timestamp: at}
+	}
+	mockDB := &MockDatabase{Events: []models.VestingEvent{
+		{EventType: "VestingScheduleCreated", Beneficiary: beneficiary, Amount: "5000", TransactionHash: "0x00", Timestamp: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)},
+		release("0x01", "100", time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)),
+		release("0x02", "50", time.Date(2025, 3, 1, 23, 30, 0, 0, time.UTC)),
+		release("0x03", "200", time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC)),
+		release("0x04", "25", time.Date(2025, 3, 5, 2, 0, 0, 0, time.UTC)),
+	}}
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedDays []DailyRelease
+		total        string
+	}{
+		{
+			name:         "Releases summed per day",
+			expectedCode: http.StatusOK,
+			expectedDays: []DailyRelease{
+				{Date: "2025-03-01", Amount: "150", Count: 2},
+				{Date: "2025-03-04", Amount: "200", Count: 1},
+				{Date: "2025-03-05", Amount: "25", Count: 1},
+			},
+			total: "375",
+		},
+		{
+			name:         "Empty days filled with zero",
+			query:        "?fill_empty=true",
+			expectedCode: http.StatusOK,
+			expectedDays: []DailyRelease{
+				{Date: "2025-03-01", Amount: "150", Count: 2},
+				{Date: "2025-03-02", Amount: "0"},
+				{Date: "2025-03-03", Amount: "0"},
+				{Date: "2025-03-04", Amount: "200", Count: 1},
+				{Date: "2025-03-05", Amount: "25", Count: 1},
+			},
+			total: "375",
+		},
+		{
+			name:         "Date range",
+			query:        "?from=2025-03-02&to=2025-03-04&fill_empty=true",
+			expectedCode: http.StatusOK,
+			expectedDays: []DailyRelease{
+				{Date: "2025-03-02", Amount: "0"},
+				{Date: "2025-03-03", Amount: "0"},
+				{Date: "2025-03-04", Amount: "200", Count: 1},
+			},
+			total: "200",
+		},
+		{
+			name:         "Days bucketed in requested zone",
+			query:        "?tz=America/New_York",
+			expectedCode: http.StatusOK,
+			expectedDays: []DailyRelease{
+				{Date: "2025-03-01", Amount: "150", Count: 2},
+				{Date: "2025-03-04", Amount: "225", Count: 2},
+			},
+			total: "375",
+		},
+		{name: "Invalid date", query: "?from=03/01/2025", expectedCode: http.StatusBadRequest},
+		{name: "Inverted range", query: "?from=2025-03-05&to=2025-03-01", expectedCode: http.StatusBadRequest},
+		{name: "Invalid fill_empty", query: "?fill_empty=maybe", expectedCode: http.StatusBadRequest},
+		{name: "Fill range too long", query: "?from=2000-01-01&to=2025-01-01&fill_empty=true", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := SetupRouter(&Handler{db: mockDB}, &config.Config{AccessLogMode: AccessLogOff})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary+"/releases/daily"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Days  []DailyRelease `json:"days"`
+				Total string         `json:"total"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedDays, response.Days)
+			assert.Equal(t, tt.total, response.Total)
+		})
+	}
+}
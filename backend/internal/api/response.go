@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	_ "time/tzdata" // Resolve ?tz= zones on hosts without a zoneinfo database
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// prettyJSONKey is the context key holding the server-wide pretty-print default
+const prettyJSONKey = "pretty_json"
+
+// responseLocationKey is the context key holding the zone response timestamps are rendered in
+const responseLocationKey = "response_location"
+
+// prettyJSONDefault stores the server-wide pretty-print default on each request
+func prettyJSONDefault(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(prettyJSONKey, enabled)
+		c.Next()
+	}
+}
+
+// responseTimezone resolves the ?tz= query parameter to the zone response
+// timestamps are rendered in, rejecting unknown zones. UTC is the default.
+func responseTimezone(c *gin.Context) {
+	raw := c.Query("tz")
+	if raw == "" {
+		c.Next()
+		return
+	}
+
+	location, err := time.LoadLocation(raw)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "tz must be an IANA timezone such as Europe/Berlin"})
+		c.Abort()
+		return
+	}
+	c.Set(responseLocationKey, location)
+	c.Next()
+}
+
+// responseLocation returns the zone response timestamps are rendered in
+func responseLocation(c *gin.Context) *time.Location {
+	if value, ok := c.Get(responseLocationKey); ok {
+		if location, ok := value.(*time.Location); ok {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+// timeType is the type localizeTimes converts
+var timeType = reflect.TypeOf(time.Time{})
+
+// localizeTimes returns a copy of v with every time.Time it holds moved into
+// location, so responses are consistent regardless of the zone values were
+// read in. Only typed times are converted; strings that merely look like
+// timestamps, such as labels, are left alone. Values holding no times are
+// returned as is.
+func localizeTimes(v any, location *time.Location) any {
+	if v == nil {
+		return nil
+	}
+	return localizeValue(reflect.ValueOf(v), location).Interface()
+}
+
+// localizeValue copies v with its times moved into location. Unexported
+// fields are copied unchanged, since encoding/json skips them too.
+func localizeValue(v reflect.Value, location *time.Location) reflect.Value {
+	t := v.Type()
+	if !holdsTime(t) {
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t == timeType {
+			return reflect.ValueOf(v.Interface().(time.Time).In(location))
+		}
+		out := reflect.New(t).Elem()
+		out.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				out.Field(i).Set(localizeValue(v.Field(i), location))
+			}
+		}
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(t.Elem())
+		out.Elem().Set(localizeValue(v.Elem(), location))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(t).Elem()
+		out.Set(localizeValue(v.Elem(), location))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(localizeValue(v.Index(i), location))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(localizeValue(v.Index(i), location))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), localizeValue(iter.Value(), location))
+		}
+		return out
+	}
+	return v
+}
+
+// timeHolders caches holdsTime by type
+var timeHolders sync.Map // reflect.Type -> bool
+
+// holdsTime reports whether values of t may contain a time.Time that
+// encoding/json would encode. Interfaces may hold anything.
+func holdsTime(t reflect.Type) bool {
+	if cached, ok := timeHolders.Load(t); ok {
+		return cached.(bool)
+	}
+	// Recursive types see themselves as holding no time while being checked
+	timeHolders.Store(t, false)
+
+	holds := false
+	switch t.Kind() {
+	case reflect.Struct:
+		holds = t == timeType
+		for i := 0; !holds && i < t.NumField(); i++ {
+			holds = t.Field(i).IsExported() && holdsTime(t.Field(i).Type)
+		}
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		holds = holdsTime(t.Elem())
+	case reflect.Interface:
+		holds = true
+	}
+
+	timeHolders.Store(t, holds)
+	return holds
+}
+
+// respondJSON writes obj as JSON with timestamps in UTC, or in the ?tz= zone
+// when given. Output is indented when ?pretty=true is passed or the server
+// default is enabled; an explicit ?pretty=false overrides the default.
+func respondJSON(c *gin.Context, status int, obj any) {
+	respondJSONAs(c, status, "application/json", obj)
+}
+
+// respondJSONAs writes obj like respondJSON under the given JSON media type
+func respondJSONAs(c *gin.Context, status int, mediaType string, obj any) {
+	pretty := c.GetBool(prettyJSONKey)
+	if raw, ok := c.GetQuery("pretty"); ok {
+		if value, err := strconv.ParseBool(raw); err == nil {
+			pretty = value
+		}
+	}
+
+	encoded, err := json.Marshal(localizeTimes(obj, responseLocation(c)))
+	if err != nil {
+		log.Printf("❌ Failed to encode response for %s: %v", c.FullPath(), err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	// Flag degraded data on successful responses so UIs can show a banner
+	if status < http.StatusBadRequest {
+		encoded = withWarnings(c, encoded)
+	}
+
+	if pretty {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, encoded, "", "    "); err == nil {
+			encoded = indented.Bytes()
+		}
+	}
+
+	c.Data(status, mediaType+"; charset=utf-8", encoded)
+}
+
+// respondLookupError reports a failed single-record lookup. A missing record is
+// a 404 with notFound as the message; any other error is a database failure and
+// is reported as a 500 rather than masked as a missing record.
+func respondLookupError(c *gin.Context, err error, notFound string) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": notFound})
+		return
+	}
+	log.Printf("❌ Database lookup failed for %s: %v", c.FullPath(), err)
+	respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Database error"})
+}
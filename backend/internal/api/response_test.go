@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestRespondJSON_Pretty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		defaultPretty bool
+		query         string
+		indented      bool
+	}{
+		{"Compact by default", false, "", false},
+		{"Pretty via query", false, "?pretty=true", true},
+		{"Pretty via server default", true, "", true},
+		{"Query overrides server default", true, "?pretty=false", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := SetupRouter(&Handler{}, &config.Config{AccessLogMode: AccessLogOff, PrettyJSON: tt.defaultPretty})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health"+tt.query, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			if tt.indented {
+				assert.Contains(t, w.Body.String(), "{\n    \"service\"")
+			} else {
+				assert.NotContains(t, w.Body.String(), "\n")
+			}
+		})
+	}
+}
+
+func TestRespondJSON_Timezone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	berlin := time.FixedZone("CEST", 2*60*60)
+	mockDB := &MockDatabase{
+		GetScheduleFunc: func(beneficiary string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{
+				Beneficiary: beneficiary,
+				Start:       time.Date(2025, 7, 1, 14, 0, 0, 0, berlin),
+				Cliff:       time.Date(2025, 7, 1, 14, 0, 0, 0, berlin),
+				Amount:      "1000",
+				Released:    "0",
+			}, nil
+		},
+	}
+	address := "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedStart  string
+	}{
+		{"UTC by default", "", http.StatusOK, "2025-07-01T12:00:00Z"},
+		{"Converted to requested zone", "?tz=America/New_York", http.StatusOK, "2025-07-01T08:00:00-04:00"},
+		{"Explicit UTC", "?tz=UTC", http.StatusOK, "2025-07-01T12:00:00Z"},
+		{"Unknown zone", "?tz=Mars/Olympus_Mons", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := SetupRouter(&Handler{db: mockDB}, &config.Config{AccessLogMode: AccessLogOff})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+address+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Start string `json:"start"`
+				Cliff string `json:"cliff"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStart, response.Start)
+			assert.Equal(t, tt.expectedStart, response.Cliff)
+		})
+	}
+}
+
+func TestRespondJSON_TimezoneLeavesStringsAlone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	label := "migrated:2025-07-01T14:00:00+02:00"
+	timestampLabel := "2025-07-01T14:00:00+02:00"
+	mockDB := &MockDatabase{
+		GetScheduleFunc: func(beneficiary string) (*models.VestingSchedule, error) {
+			return &models.VestingSchedule{
+				Beneficiary: beneficiary,
+				Start:       time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC),
+				Cliff:       time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC),
+				Amount:      "1000",
+				Released:    "0",
+				Labels:      []string{label, timestampLabel},
+			}, nil
+		},
+	}
+	router := SetupRouter(&Handler{db: mockDB}, &config.Config{AccessLogMode: AccessLogOff})
+
+	for _, path := range []string{
+		"/api/v1/schedules/0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0?tz=America/New_York",
+		"/api/v1/schedules/0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0?tz=America/New_York&fields=start,labels",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Start  string   `json:"start"`
+			Labels []string `json:"labels"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "2025-07-01T08:00:00-04:00", response.Start, path)
+		// Labels are text, even when they look like timestamps
+		assert.Equal(t, []string{label, timestampLabel}, response.Labels, path)
+	}
+}
+
+func TestLocalizeTimes(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	localized := localizeTimes(gin.H{
+		"at":      at,
+		"pointer": &at,
+		"nested":  []any{map[string]time.Time{"at": at}},
+		"text":    at.Format(time.RFC3339),
+		"nil":     (*time.Time)(nil),
+	}, newYork).(gin.H)
+
+	assert.Equal(t, newYork, localized["at"].(time.Time).Location())
+	assert.Equal(t, newYork, localized["pointer"].(*time.Time).Location())
+	assert.Equal(t, newYork, localized["nested"].([]any)[0].(map[string]time.Time)["at"].Location())
+	assert.Equal(t, "2025-07-01T12:00:00Z", localized["text"])
+	assert.Nil(t, localized["nil"])
+	assert.True(t, localized["at"].(time.Time).Equal(at))
+
+	// The original value is not modified
+	assert.Equal(t, time.UTC, at.Location())
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// revocationPageSize is the number of revocation events read from the database per page
+const revocationPageSize = 500
+
+// Revocation is one revoked schedule, as recorded by its VestingRevoked event
+type Revocation struct {
+	Beneficiary     string    `json:"beneficiary"`
+	Refunded        string    `json:"refunded"` // Unvested amount returned to the owner, in token base units
+	BlockNumber     uint64    `json:"block_number"`
+	LogIndex        uint      `json:"log_index"`
+	TransactionHash string    `json:"transaction_hash"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// GetRevocations lists every revocation in an inclusive block window, oldest
+// first, with the refunded amounts and their total, for compliance reviews.
+// Either bound may be omitted to leave that side of the window open.
+// GET /api/v1/revocations?from_block=0&to_block=0
+func (h *Handler) GetRevocations(c *gin.Context) {
+	filter, err := parseBlockRange(c)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.EventType = "VestingRevoked"
+
+	events, err := h.eventsInRange(c, filter)
+	if err != nil {
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve revocations"})
+		return
+	}
+
+	revocations := make([]Revocation, len(events))
+	totalRefunded := new(big.Int)
+	for i, event := range events {
+		revocations[i] = Revocation{
+			Beneficiary:     event.Beneficiary,
+			Refunded:        event.Amount,
+			BlockNumber:     event.BlockNumber,
+			LogIndex:        event.LogIndex,
+			TransactionHash: event.TransactionHash,
+			Timestamp:       event.Timestamp,
+		}
+		totalRefunded.Add(totalRefunded, parseAmount(event.Amount))
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"from_block":     filter.FromBlock,
+		"to_block":       filter.ToBlock,
+		"revocations":    revocations,
+		"count":          len(revocations),
+		"total_refunded": totalRefunded.String(),
+	})
+}
+
+// eventsInRange reads every event matching filter across all beneficiaries, in chain order
+func (h *Handler) eventsInRange(c *gin.Context, filter database.EventFilter) ([]models.VestingEvent, error) {
+	var events []models.VestingEvent
+	for {
+		page, err := h.store(c).GetEventsInRange(filter, revocationPageSize, len(events))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+		if len(page) < revocationPageSize {
+			return events, nil
+		}
+	}
+}
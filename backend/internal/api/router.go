@@ -1,41 +1,185 @@
 package api
 
 import (
+	"log"
+	"net/http"
+	"strings"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
 )
 
-func SetupRouter(handler *Handler) *gin.Engine {
-	router := gin.Default()
+// adminPathPrefix is the route group for admin operations
+const adminPathPrefix = "/api/v1/admin"
 
-	// CORS middleware
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:8080"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-	}))
+// Access log modes
+const (
+	AccessLogOff    = "off"
+	AccessLogErrors = "errors"
+	AccessLogAll    = "all"
+)
+
+func SetupRouter(handler *Handler, cfg *config.Config) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// Access logging
+	if logger := accessLogger(cfg.AccessLogMode, cfg.AccessLogSkipHealth); logger != nil {
+		router.Use(logger)
+	}
+
+	// Compact JSON unless pretty-printing is enabled server-wide or per request
+	router.Use(prettyJSONDefault(cfg.PrettyJSON))
+
+	// Render response timestamps in UTC unless another zone is requested via ?tz=
+	router.Use(responseTimezone)
+
+	// Serve reads from the replica unless X-Read-Consistency: strong is sent
+	router.Use(readConsistency)
+
+	// CORS middleware: admin routes get their own, stricter origin list
+	router.Use(corsByRouteGroup(newCORS(cfg.CORSAllowedOrigins), newCORS(cfg.AdminCORSAllowedOrigins)))
 
 	// Health check
 	router.GET("/health", handler.HealthCheck)
+	router.GET("/ready", handler.ReadinessCheck)
+
+	// Prometheus metrics, scraped like the health checks without an API key
+	router.GET("/metrics", gin.WrapH(handler.metricsHandler()))
+
+	// Shared cap on concurrent requests that call the RPC node
+	rpcLimit := rpcConcurrencyLimit(cfg.RPCMaxInFlight)
+
+	// API key authentication, when enabled, covers the API routes but not health checks
+	authenticate := func(c *gin.Context) { c.Next() }
+	if cfg.APIKeyAuth {
+		authenticate = requireAPIKey(cfg.APIKeys)
+	}
+
+	// Per-client request rate limit on the API routes, applied after authentication
+	// so rejected keys do not spend a bucket
+	limit := rateLimit(cfg.RateLimitRPS, cfg.RateLimitBurst, cfg.APIKeyAuth)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	v1.Use(authenticate, limit, handler.flagDegradedData)
 	{
 		// Vesting schedules
-		v1.GET("/schedules", handler.GetAllSchedules)
+		v1.GET("/schedules", onlyWhen(includesVested, rpcLimit), handler.GetAllSchedules)
+		v1.GET("/schedules/by-status", handler.GetSchedulesByStatus)
+		v1.GET("/schedules/export", handler.ExportSchedules)
 		v1.GET("/schedules/:address", handler.GetSchedule)
+		v1.GET("/schedules/:address/releases/daily", handler.GetDailyReleases)
+		v1.GET("/schedules/:address/released-at", handler.GetReleasedAt)
+		v1.GET("/schedules/:address/curve", handler.GetVestingCurve)
+		v1.GET("/schedules/:address/summary.pdf", handler.GetScheduleSummaryPDF)
 
 		// Vested amounts
-		v1.GET("/vested/:address", handler.GetVestedAmount)
+		v1.GET("/vested/:address", rpcLimit, handler.GetVestedAmount)
+		v1.GET("/releasable-now", handler.GetReleasableNow)
+		v1.GET("/velocity", handler.GetVelocity)
+		v1.GET("/completion", handler.GetCompletion)
+
+		// Beneficiaries
+		v1.GET("/beneficiaries/:address/claimable", rpcLimit, handler.GetClaimable)
+		v1.GET("/beneficiaries/:address/vested", handler.GetBeneficiaryVested)
+		v1.GET("/beneficiaries/:address/share", handler.GetBeneficiaryShare)
+		v1.GET("/beneficiaries/:address/exists", handler.GetBeneficiaryExists)
+
+		// Portfolios
+		v1.POST("/portfolio", rpcLimit, handler.GetPortfolio)
 
 		// Events
+		v1.GET("/events", handler.GetEventsForBeneficiaries)
 		v1.GET("/events/:address", handler.GetEvents)
+		v1.GET("/events/:address/stream", handler.StreamEvents)
+		v1.GET("/ws", handler.StreamWebSocket)
+
+		// Revocation audit
+		v1.GET("/revocations", handler.GetRevocations)
+
+		// Merkle allocations
+		v1.GET("/allocations/:address/proof", handler.GetAllocationProof)
 
 		// Statistics
 		v1.GET("/stats", handler.GetStats)
+		v1.GET("/stats/:address", rpcLimit, handler.GetBeneficiaryStats)
+
+		// Contract
+		v1.GET("/contract/info", handler.GetContractInfo)
+
+		// Sync status
+		v1.GET("/sync/backlog", handler.GetSyncBacklog)
+		v1.GET("/sync/throughput", handler.GetSyncThroughput)
+		v1.GET("/sync/event-types", handler.GetSyncEventTypes)
+		v1.GET("/sync/coverage", handler.GetSyncCoverage)
+	}
+
+	// Admin routes take their own keys, whether or not API key auth is on
+	admin := router.Group(adminPathPrefix)
+	admin.Use(requireAdmin(cfg.AdminAPIKeys), limit)
+	{
+		admin.POST("/schedules/:address/simulate-release", rpcLimit, handler.SimulateRelease)
+		admin.PUT("/schedules/:address/labels", handler.SetScheduleLabels)
+		admin.GET("/schedules/export", handler.AdminExportSchedules)
+		admin.GET("/logs/:address", rpcLimit, handler.GetRawLogs)
+		admin.POST("/replay", handler.ReplayEvents)
 	}
 
 	return router
 }
+
+// newCORS builds CORS middleware allowing the given origins. An empty list
+// rejects all cross-origin requests.
+func newCORS(origins []string) gin.HandlerFunc {
+	corsConfig := cors.Config{
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", apiKeyHeader, readConsistencyHeader},
+		ExposeHeaders:    []string{"Content-Length"},
+		AllowCredentials: true,
+	}
+	if len(origins) == 0 {
+		corsConfig.AllowOriginFunc = func(string) bool { return false }
+	} else {
+		corsConfig.AllowOrigins = origins
+	}
+	return cors.New(corsConfig)
+}
+
+// corsByRouteGroup applies the admin CORS policy to admin routes and the public
+// policy to everything else. Dispatching on the path rather than attaching the
+// middleware to each group lets preflight requests, which have no matching
+// OPTIONS route, see the right policy.
+func corsByRouteGroup(public, admin gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, adminPathPrefix+"/") {
+			admin(c)
+			return
+		}
+		public(c)
+	}
+}
+
+// accessLogger returns gin's request logger filtered by mode, or nil when
+// access logging is off
+func accessLogger(mode string, skipHealth bool) gin.HandlerFunc {
+	switch mode {
+	case AccessLogOff:
+		return nil
+	case AccessLogErrors, AccessLogAll:
+	default:
+		log.Printf("⚠️  Unknown access log mode %q, logging all requests", mode)
+		mode = AccessLogAll
+	}
+
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Skip: func(c *gin.Context) bool {
+			if skipHealth && (c.Request.URL.Path == "/health" || c.Request.URL.Path == "/ready") {
+				return true
+			}
+			return mode == AccessLogErrors && c.Writer.Status() < http.StatusBadRequest
+		},
+	})
+}
@@ -0,0 +1,448 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// captureAccessLog builds a router with the given config and returns the
+// access log output produced by requesting path
+func captureAccessLog(t *testing.T, cfg *config.Config, path string) string {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	original := gin.DefaultWriter
+	gin.DefaultWriter = &buf
+	defer func() { gin.DefaultWriter = original }()
+
+	router := SetupRouter(&Handler{db: &MockDatabase{}}, cfg)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return buf.String()
+}
+
+func TestAccessLog_SkipsHealthCheck(t *testing.T) {
+	cfg := &config.Config{AccessLogMode: AccessLogAll, AccessLogSkipHealth: true}
+
+	assert.Empty(t, captureAccessLog(t, cfg, "/health"))
+	assert.Contains(t, captureAccessLog(t, cfg, "/api/v1/stats"), "/api/v1/stats")
+}
+
+func TestAccessLog_HealthCheckLoggedWhenNotSkipped(t *testing.T) {
+	cfg := &config.Config{AccessLogMode: AccessLogAll, AccessLogSkipHealth: false}
+
+	assert.Contains(t, captureAccessLog(t, cfg, "/health"), "/health")
+}
+
+func TestAccessLog_ErrorsOnly(t *testing.T) {
+	cfg := &config.Config{AccessLogMode: AccessLogErrors}
+
+	assert.Empty(t, captureAccessLog(t, cfg, "/api/v1/stats"))
+	assert.Contains(t, captureAccessLog(t, cfg, "/api/v1/schedules/invalid"), "/api/v1/schedules/invalid")
+}
+
+func TestAccessLog_Off(t *testing.T) {
+	cfg := &config.Config{AccessLogMode: AccessLogOff}
+
+	assert.Empty(t, captureAccessLog(t, cfg, "/api/v1/schedules/invalid"))
+}
+
+// preflight sends a CORS preflight request for path from origin
+func preflight(router *gin.Engine, path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_PerRouteGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	publicOrigin := "http://localhost:3000"
+	adminOrigin := "https://admin.example.com"
+
+	router := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{
+		AccessLogMode:           AccessLogOff,
+		CORSAllowedOrigins:      []string{publicOrigin},
+		AdminCORSAllowedOrigins: []string{adminOrigin},
+	})
+
+	// Public origin is allowed on public routes
+	w := preflight(router, "/api/v1/schedules", publicOrigin)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, publicOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+
+	// ...but rejected on admin routes
+	w = preflight(router, adminPathPrefix+"/schedules/0xF25DA65784D566fFCC60A1f113650afB688A14ED/simulate-release", publicOrigin)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	// Admin origin is allowed on admin routes only
+	w = preflight(router, adminPathPrefix+"/schedules/0xF25DA65784D566fFCC60A1f113650afB688A14ED/simulate-release", adminOrigin)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, adminOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = preflight(router, "/api/v1/schedules", adminOrigin)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCORS_AdminDeniedByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{
+		AccessLogMode:      AccessLogOff,
+		CORSAllowedOrigins: []string{"http://localhost:3000"},
+	})
+
+	w := preflight(router, adminPathPrefix+"/replay", "http://localhost:3000")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRPCConcurrencyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const maxInFlight = 2
+	const requests = 5
+
+	// Hold RPC calls open until released so requests overlap
+	entered := make(chan struct{}, requests)
+	release := make(chan struct{})
+	handler := &Handler{
+		db: &MockDatabase{},
+		blockchain: &MockBlockchain{
+			GetVestedAmountFunc: func(beneficiary common.Address) (*big.Int, error) {
+				entered <- struct{}{}
+				<-release
+				return big.NewInt(0), nil
+			},
+		},
+	}
+	router := SetupRouter(handler, &config.Config{AccessLogMode: AccessLogOff, RPCMaxInFlight: maxInFlight})
+
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/vested/0xF25DA65784D566fFCC60A1f113650afB688A14ED", nil))
+			codes <- w.Code
+			if w.Code == http.StatusServiceUnavailable {
+				assert.Equal(t, rpcRetryAfterSeconds, w.Header().Get("Retry-After"))
+			}
+		}()
+	}
+
+	// Wait until the cap is saturated and every excess request was rejected
+	for i := 0; i < maxInFlight; i++ {
+		select {
+		case <-entered:
+		case <-time.After(5 * time.Second):
+			t.Fatal("RPC-backed requests did not reach the blockchain client")
+		}
+	}
+	rejected := 0
+	for i := 0; i < requests-maxInFlight; i++ {
+		select {
+		case code := <-codes:
+			require.Equal(t, http.StatusServiceUnavailable, code)
+			rejected++
+		case <-time.After(5 * time.Second):
+			t.Fatal("Requests over the cap were not rejected")
+		}
+	}
+	assert.Equal(t, requests-maxInFlight, rejected)
+
+	// Database-only endpoints are unaffected while the cap is saturated
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/schedules", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		assert.NotEqual(t, http.StatusServiceUnavailable, code)
+	}
+}
+
+func TestDegradedMode_Warnings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// decode reads the _warnings of a response, failing on invalid JSON
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) []string {
+		var body struct {
+			Warnings []string `json:"_warnings"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Warnings
+	}
+
+	progress := &mockSyncProgress{progress: blockchain.SyncProgress{Healthy: true, Head: 1500, Processed: 1000, Lag: 500}}
+	handler := &Handler{db: &MockDatabase{}}
+	handler.SetDegradedMode(progress, 100)
+	router := SetupRouter(handler, &config.Config{AccessLogMode: AccessLogOff})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("High sync lag flags list responses", func(t *testing.T) {
+		w := serve("/api/v1/schedules")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Indexer is 500 blocks behind the chain head; recent events may be missing"}, decode(t, w))
+		assert.Contains(t, w.Body.String(), `"schedules":[]`)
+	})
+
+	t.Run("Pretty output stays valid", func(t *testing.T) {
+		w := serve("/api/v1/schedules?pretty=true")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, decode(t, w), 1)
+		assert.Contains(t, w.Body.String(), "\n    \"_warnings\": [")
+	})
+
+	t.Run("Errors are not flagged", func(t *testing.T) {
+		w := serve("/api/v1/schedules/not-an-address")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, decode(t, w))
+	})
+
+	t.Run("Health checks are not flagged", func(t *testing.T) {
+		w := serve("/health")
+		assert.Empty(t, decode(t, w))
+	})
+
+	t.Run("Disconnected client", func(t *testing.T) {
+		progress.progress = blockchain.SyncProgress{Healthy: true, RPCError: "dial tcp: connection refused"}
+		w := serve("/api/v1/schedules")
+		require.Equal(t, http.StatusOK, w.Code)
+		warnings := decode(t, w)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "disconnected")
+	})
+
+	t.Run("Lag within threshold", func(t *testing.T) {
+		progress.progress = blockchain.SyncProgress{Healthy: true, Head: 1050, Processed: 1000, Lag: 50}
+		w := serve("/api/v1/schedules")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "_warnings")
+	})
+}
+
+func TestReadConsistency_RoutesStrongReadsToPrimary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The replica has not caught up with a schedule already on the primary
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	replica := &MockDatabase{GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+		return nil, gorm.ErrRecordNotFound
+	}}
+	primary := &MockDatabase{GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+		return &models.VestingSchedule{Beneficiary: address, Amount: "1000", Released: "0"}, nil
+	}}
+
+	handler := &Handler{db: replica}
+	handler.SetPrimaryDatabase(primary)
+	router := SetupRouter(handler, &config.Config{AccessLogMode: AccessLogOff})
+
+	tests := []struct {
+		name         string
+		consistency  string
+		expectedCode int
+	}{
+		{name: "Default is eventual", expectedCode: http.StatusNotFound},
+		{name: "Eventual reads the replica", consistency: "eventual", expectedCode: http.StatusNotFound},
+		{name: "Strong reads the primary", consistency: "Strong", expectedCode: http.StatusOK},
+		{name: "Unknown level is rejected", consistency: "linearizable", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+beneficiary, nil)
+			if tt.consistency != "" {
+				req.Header.Set(readConsistencyHeader, tt.consistency)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	request := func(router *gin.Engine, path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	router := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{
+		AccessLogMode: AccessLogOff,
+		APIKeyAuth:    true,
+		APIKeys:       []string{"key-one", "key-two"},
+		AdminAPIKeys:  []string{"admin-key"},
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		header   []string
+		expected int
+	}{
+		{name: "Missing key", path: "/api/v1/stats", expected: http.StatusUnauthorized},
+		{name: "Invalid key", path: "/api/v1/stats", header: []string{"X-API-Key", "key-three"}, expected: http.StatusUnauthorized},
+		{name: "Prefix of a key", path: "/api/v1/stats", header: []string{"X-API-Key", "key-on"}, expected: http.StatusUnauthorized},
+		{name: "Valid key", path: "/api/v1/stats", header: []string{"X-API-Key", "key-one"}, expected: http.StatusOK},
+		{name: "Second key", path: "/api/v1/stats", header: []string{"X-API-Key", "key-two"}, expected: http.StatusOK},
+		{name: "Bearer token", path: "/api/v1/stats", header: []string{"Authorization", "Bearer key-two"}, expected: http.StatusOK},
+		{name: "Other authorization scheme", path: "/api/v1/stats", header: []string{"Authorization", "Basic key-two"}, expected: http.StatusUnauthorized},
+		{name: "Admin routes", path: adminPathPrefix + "/logs/0xF25DA65784D566fFCC60A1f113650afB688A14ED", expected: http.StatusUnauthorized},
+		{name: "Admin routes reject API keys", path: adminPathPrefix + "/logs/0xF25DA65784D566fFCC60A1f113650afB688A14ED", header: []string{"X-API-Key", "key-one"}, expected: http.StatusUnauthorized},
+		{name: "Admin key is not an API key", path: "/api/v1/stats", header: []string{"X-API-Key", "admin-key"}, expected: http.StatusUnauthorized},
+		{name: "Health stays public", path: "/health", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(router, tt.path, tt.header...)
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusUnauthorized {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.NotEmpty(t, body["error"])
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+
+	// Authentication is off unless enabled
+	open := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{AccessLogMode: AccessLogOff, APIKeys: []string{"key-one"}})
+	assert.Equal(t, http.StatusOK, request(open, "/api/v1/stats").Code)
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const burst = 3
+	const requests = 10
+
+	get := func(router *gin.Engine, path, remoteAddr, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("By client IP", func(t *testing.T) {
+		router := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{
+			AccessLogMode:  AccessLogOff,
+			RateLimitRPS:   0.5,
+			RateLimitBurst: burst,
+		})
+
+		codes := map[int]int{}
+		for i := 0; i < requests; i++ {
+			w := get(router, "/api/v1/stats", "10.0.0.1:1234", "")
+			codes[w.Code]++
+			if w.Code == http.StatusTooManyRequests {
+				assert.Equal(t, "2", w.Header().Get("Retry-After"))
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.NotEmpty(t, body["error"])
+			}
+		}
+		assert.Equal(t, map[int]int{http.StatusOK: burst, http.StatusTooManyRequests: requests - burst}, codes)
+
+		// Other clients and health checks are unaffected
+		assert.Equal(t, http.StatusOK, get(router, "/api/v1/stats", "10.0.0.2:1234", "").Code)
+		assert.Equal(t, http.StatusOK, get(router, "/health", "10.0.0.1:1234", "").Code)
+	})
+
+	t.Run("By API key when keys are enforced", func(t *testing.T) {
+		router := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{
+			AccessLogMode:  AccessLogOff,
+			APIKeyAuth:     true,
+			APIKeys:        []string{"key-one", "key-two"},
+			RateLimitRPS:   0.5,
+			RateLimitBurst: burst,
+		})
+
+		for i := 0; i < burst; i++ {
+			assert.Equal(t, http.StatusOK, get(router, "/api/v1/stats", "10.0.0.1:1234", "key-one").Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, get(router, "/api/v1/stats", "10.0.0.1:1234", "key-one").Code)
+
+		// Another key from the same address has its own bucket
+		assert.Equal(t, http.StatusOK, get(router, "/api/v1/stats", "10.0.0.1:1234", "key-two").Code)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		router := SetupRouter(&Handler{db: &MockDatabase{}}, &config.Config{AccessLogMode: AccessLogOff})
+		for i := 0; i < requests; i++ {
+			assert.Equal(t, http.StatusOK, get(router, "/api/v1/stats", "10.0.0.1:1234", "").Code)
+		}
+	})
+}
+
+func TestRateLimiter_RefillsAndSweepsIdleClients(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+
+	ok, _ := limiter.allow("a")
+	assert.True(t, ok)
+	ok, _ = limiter.allow("a")
+	assert.True(t, ok)
+	ok, wait := limiter.allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	// One token refills per second
+	now = now.Add(time.Second)
+	ok, _ = limiter.allow("a")
+	assert.True(t, ok)
+
+	limiter.allow("b")
+	assert.Equal(t, 2, limiter.size())
+
+	// Clients idle for the sweep interval are dropped at the next sweep
+	now = now.Add(rateLimitSweepInterval)
+	limiter.allow("c")
+	assert.Equal(t, 1, limiter.size())
+}
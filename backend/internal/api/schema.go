@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// scheduleV1MediaType selects the versioned schedule schema via the Accept header
+const scheduleV1MediaType = "application/vnd.token-vesting.schedule.v1+json"
+
+// errScheduleV1Options rejects query options whose output the v1 schema does not define
+var errScheduleV1Options = errors.New("fields, include_vested and include_latest_event are not supported with the v1 schedule schema")
+
+// ScheduleV1 is the stable, versioned representation of a vesting schedule for
+// strongly-typed clients. Unlike the storage model it never exposes database
+// concerns such as row ids or bookkeeping timestamps, and every field is
+// always present. Amounts are base-unit integer strings and timestamps are
+// RFC3339.
+type ScheduleV1 struct {
+	Beneficiary     string    `json:"beneficiary"`      // Checksummed address
+	Token           string    `json:"token"`            // Checksummed address; empty when unknown
+	Start           time.Time `json:"start"`            // Vesting start
+	Cliff           time.Time `json:"cliff"`            // Nothing vests before the cliff
+	DurationSeconds int64     `json:"duration_seconds"` // Vesting period from start
+	Amount          string    `json:"amount"`           // Total allocation
+	Released        string    `json:"released"`         // Released so far
+	Revocable       bool      `json:"revocable"`
+	Revoked         bool      `json:"revoked"`
+	Status          string    `json:"status"` // pending, cliff, vesting, vested or revoked
+	Labels          []string  `json:"labels"` // Never null
+}
+
+// newScheduleV1 converts a schedule, with its status already computed, to the v1 schema
+func newScheduleV1(schedule *models.VestingSchedule) ScheduleV1 {
+	labels := schedule.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return ScheduleV1{
+		Beneficiary:     schedule.Beneficiary,
+		Token:           schedule.Token,
+		Start:           schedule.Start,
+		Cliff:           schedule.Cliff,
+		DurationSeconds: schedule.Duration,
+		Amount:          schedule.Amount,
+		Released:        schedule.Released,
+		Revocable:       schedule.Revocable,
+		Revoked:         schedule.Revoked,
+		Status:          string(schedule.Status),
+		Labels:          labels,
+	}
+}
+
+// wantsScheduleV1 reports whether the client asked for the v1 schedule schema.
+// Plain JSON remains the default for clients without a matching Accept header.
+func wantsScheduleV1(c *gin.Context) bool {
+	if c.Request == nil {
+		return false
+	}
+	return c.NegotiateFormat(gin.MIMEJSON, scheduleV1MediaType) == scheduleV1MediaType
+}
+
+// checkScheduleV1Options rejects options the v1 schema has no representation for
+func checkScheduleV1Options(c *gin.Context, fields []string) error {
+	if fields != nil || c.Query("include_vested") == "true" || c.Query("include_latest_event") == "true" {
+		return errScheduleV1Options
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// serverReadHeaderTimeout bounds how long a client may take to send request headers
+const serverReadHeaderTimeout = 10 * time.Second
+
+// NewServer wraps the router in an HTTP server. Live event streams are ended
+// when the server starts shutting down, so they do not hold up the drain.
+func NewServer(router http.Handler, handler *Handler) *http.Server {
+	srv := &http.Server{
+		Handler:           router,
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+	srv.RegisterOnShutdown(handler.CloseStreams)
+	return srv
+}
+
+// Serve accepts connections on ln until ctx is cancelled, then shuts the
+// server down: it stops accepting connections and waits up to timeout for
+// in-flight requests to complete. Connections still open after the timeout
+// are closed. Returns nil once every request has finished.
+func Serve(ctx context.Context, srv *http.Server, ln net.Listener, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️  Requests still running after %s, closing their connections", timeout)
+		_ = srv.Close()
+		return fmt.Errorf("graceful shutdown incomplete: %w", err)
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
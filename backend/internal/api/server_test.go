@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+)
+
+// startServer serves router on a free local port until the returned context
+// is cancelled. Serve's result is delivered on the returned channel.
+func startServer(t *testing.T, router http.Handler, handler *Handler, timeout time.Duration) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() { done <- Serve(ctx, NewServer(router, handler), ln, timeout) }()
+	return "http://" + ln.Addr().String(), cancel, done
+}
+
+func TestServe_DrainsInFlightRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	started := make(chan struct{})
+	router := gin.New()
+	router.GET("/slow", func(c *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+	url, shutdown, served := startServer(t, router, &Handler{}, 5*time.Second)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		response <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	// Shut down while the request is being handled
+	<-started
+	shutdown()
+
+	got := <-response
+	require.NoError(t, got.err)
+	assert.Equal(t, http.StatusOK, got.status)
+	assert.Equal(t, "done", got.body)
+
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not finish shutting down")
+	}
+
+	// The listener is closed once shutdown completes
+	_, err := http.Get(url + "/slow")
+	assert.Error(t, err)
+}
+
+func TestServe_EndsEventStreams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &Handler{db: &MockDatabase{}}
+	handler.SetEventStream(blockchain.NewEventHub())
+	router := SetupRouter(handler, &config.Config{AccessLogMode: AccessLogOff})
+	url, shutdown, served := startServer(t, router, handler, 5*time.Second)
+
+	resp, err := http.Get(url + "/api/v1/events/0xF25DA65784D566fFCC60A1f113650afB688A14ED/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	started := time.Now()
+	shutdown()
+
+	// The stream ends instead of holding the shutdown until its timeout
+	_, err = io.Copy(io.Discard, resp.Body)
+	assert.NoError(t, err)
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not finish shutting down")
+	}
+	assert.Less(t, time.Since(started), 2*time.Second)
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statsSnapshot is a computed stats body and when it was computed
+type statsSnapshot struct {
+	body       gin.H
+	computedAt time.Time
+}
+
+// statsFlight is an in-progress stats computation shared by concurrent requests
+type statsFlight struct {
+	done     chan struct{}
+	snapshot statsSnapshot
+	err      error
+}
+
+// statsCache remembers the last successful stats computation so slow
+// aggregates can be answered from it instead of failing. The zero value is
+// ready to use.
+type statsCache struct {
+	mu      sync.Mutex
+	last    *statsSnapshot
+	pending *statsFlight
+}
+
+// get returns freshly computed stats, or the last known-good snapshot with
+// stale set when the computation takes longer than deadline. A computation
+// that overruns keeps running and refreshes the cache when it finishes.
+// Without a snapshot to fall back on, get waits for the computation.
+func (s *statsCache) get(deadline time.Duration, compute func() (gin.H, error)) (snapshot statsSnapshot, stale bool, err error) {
+	flight := s.start(compute)
+
+	if deadline > 0 {
+		timer := time.NewTimer(deadline)
+		defer timer.Stop()
+
+		select {
+		case <-flight.done:
+		case <-timer.C:
+			s.mu.Lock()
+			last := s.last
+			s.mu.Unlock()
+			if last != nil {
+				return *last, true, nil
+			}
+			<-flight.done
+		}
+	} else {
+		<-flight.done
+	}
+
+	return flight.snapshot, false, flight.err
+}
+
+// start joins the in-progress computation or begins a new one
+func (s *statsCache) start(compute func() (gin.H, error)) *statsFlight {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending != nil {
+		return s.pending
+	}
+
+	flight := &statsFlight{done: make(chan struct{})}
+	s.pending = flight
+
+	go func() {
+		body, err := compute()
+
+		s.mu.Lock()
+		flight.snapshot = statsSnapshot{body: body, computedAt: time.Now()}
+		flight.err = err
+		if err == nil {
+			s.last = &flight.snapshot
+		}
+		s.pending = nil
+		s.mu.Unlock()
+
+		close(flight.done)
+	}()
+
+	return flight
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// streamHeartbeat is how often an idle event stream sends a comment so proxies
+// keep the connection open
+const streamHeartbeat = 15 * time.Second
+
+// EventStream delivers newly recorded events for a beneficiary as they happen
+type EventStream interface {
+	Subscribe(beneficiary string) (<-chan models.VestingEvent, func())
+}
+
+// SetEventStream enables live event streaming over Server-Sent Events
+func (h *Handler) SetEventStream(stream EventStream) {
+	h.stream = stream
+}
+
+// CloseStreams ends every live event stream, over Server-Sent Events or
+// WebSocket, and makes new ones end at once. Called when the server shuts down,
+// as streams would otherwise stay open until the shutdown timeout.
+func (h *Handler) CloseStreams() {
+	done := h.streamsDone()
+	h.streamsClose.Do(func() { close(done) })
+}
+
+// streamsDone returns a channel closed once live streams should end
+func (h *Handler) streamsDone() chan struct{} {
+	h.streamsInit.Do(func() { h.streamsClosed = make(chan struct{}) })
+	return h.streamsClosed
+}
+
+// StreamEvents pushes a beneficiary's new events to the client as Server-Sent
+// Events until the client disconnects. Each frame carries the event ID, the
+// event type as the SSE event name, and the event as JSON. Earlier events are
+// not replayed; read them from GET /api/v1/events/:address.
+// GET /api/v1/events/:address/stream
+func (h *Handler) StreamEvents(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	if h.stream == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Event streaming not available"})
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	events, unsubscribe := h.stream.Subscribe(normalizedAddress)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Stop nginx from buffering the stream
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	location := responseLocation(c)
+	shutdown := h.streamsDone()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-shutdown:
+			// The client reconnects once the server is back
+			return
+		case event, ok := <-events:
+			if !ok {
+				// Fell too far behind; the client reconnects and catches up from the events listing
+				return
+			}
+			data, err := json.Marshal(localizeTimes(event, location))
+			if err != nil {
+				log.Printf("❌ Failed to encode streamed event %d: %v", event.ID, err)
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, data)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
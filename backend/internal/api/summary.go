@@ -0,0 +1,106 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-pdf/fpdf"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// summaryTimeLayout is how dates are printed in vesting summaries
+const summaryTimeLayout = "2006-01-02 15:04 MST"
+
+// GetScheduleSummaryPDF renders a beneficiary's schedule terms and current
+// vested and released amounts as a shareable PDF. Dates use UTC or the ?tz= zone.
+// GET /api/v1/schedules/:address/summary.pdf
+func (h *Handler) GetScheduleSummaryPDF(c *gin.Context) {
+	address, ok := parseAddressParam(c)
+	if !ok {
+		return
+	}
+
+	normalizedAddress := address.Hex()
+
+	schedule, err := h.store(c).GetScheduleByBeneficiary(normalizedAddress)
+	if err != nil {
+		respondLookupError(c, err, "Schedule not found")
+		return
+	}
+
+	now := h.now()
+	doc := h.renderScheduleSummary(schedule, now, responseLocation(c))
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		log.Printf("❌ Failed to render summary PDF for %s: %v", normalizedAddress, err)
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to render summary"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="vesting-summary-%s.pdf"`, normalizedAddress))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// renderScheduleSummary lays out a one-page summary of a schedule as of now.
+// Amounts are shown in token units when the token's decimals are known.
+func (h *Handler) renderScheduleSummary(schedule *models.VestingSchedule, now time.Time, location *time.Location) *fpdf.Fpdf {
+	amount := func(value string) string {
+		if h.decimals == nil {
+			return value + " (base units)"
+		}
+		return models.FormatUnitsRounded(value, h.decimals.Decimals(schedule.Token), h.displayPlaces())
+	}
+	date := func(t time.Time) string {
+		return t.In(location).Format(summaryTimeLayout)
+	}
+
+	revocable := "No"
+	if schedule.Revocable {
+		revocable = "Yes"
+	}
+	token := schedule.Token
+	if token == "" {
+		token = "Unknown"
+	}
+
+	rows := [][2]string{
+		{"Beneficiary", schedule.Beneficiary},
+		{"Token", token},
+		{"Total amount", amount(schedule.Amount)},
+		{"Start", date(schedule.Start)},
+		{"Cliff", date(schedule.Cliff)},
+		{"Duration", (time.Duration(schedule.Duration) * time.Second).String()},
+		{"Revocable", revocable},
+		{"Status", string(schedule.ComputeStatus(now))},
+		{"Vested", amount(schedule.VestedAmount(h.vestingModel, now).String())},
+		{"Released", amount(schedule.Released)},
+	}
+
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetCreationDate(now)
+	doc.SetTitle("Vesting Schedule Summary", false)
+	doc.AddPage()
+
+	doc.SetFont("Helvetica", "B", 16)
+	doc.Cell(0, 10, "Vesting Schedule Summary")
+	doc.Ln(12)
+
+	doc.SetFont("Helvetica", "", 9)
+	doc.Cell(0, 6, "As of "+date(now))
+	doc.Ln(10)
+
+	for _, row := range rows {
+		doc.SetFont("Helvetica", "B", 11)
+		doc.CellFormat(40, 8, row[0], "B", 0, "L", false, 0, "")
+		doc.SetFont("Helvetica", "", 11)
+		doc.CellFormat(0, 8, row[1], "B", 1, "L", false, 0, "")
+	}
+
+	return doc
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestGetScheduleSummaryPDF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	mockDB := &MockDatabase{
+		GetScheduleFunc: func(address string) (*models.VestingSchedule, error) {
+			if address != beneficiary {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &models.VestingSchedule{
+				Beneficiary: beneficiary,
+				Start:       time.Now().Add(-time.Hour),
+				Cliff:       time.Now().Add(-time.Hour),
+				Duration:    7200,
+				Amount:      "1000",
+				Released:    "250",
+				Revocable:   true,
+			}, nil
+		},
+	}
+	router := SetupRouter(&Handler{db: mockDB}, &config.Config{AccessLogMode: AccessLogOff})
+
+	request := func(address string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/schedules/"+address+"/summary.pdf", nil))
+		return w
+	}
+
+	w := request(beneficiary)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="vesting-summary-`+beneficiary+`.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.NotZero(t, w.Body.Len())
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")), "missing PDF header")
+	assert.Contains(t, string(bytes.TrimSpace(w.Body.Bytes())), "%%EOF")
+
+	w = request("0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = request("0x1234")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// defaultVestedFetchConcurrency bounds concurrent on-chain vested amount
+// lookups per request when none is configured
+const defaultVestedFetchConcurrency = 8
+
+// vestedLookup is the outcome of one address's on-chain vested amount read
+type vestedLookup struct {
+	amount *big.Int
+	err    error
+}
+
+// fetchVestedAmounts reads the on-chain vested amount of each address using a
+// bounded worker pool. Each read has its own timeout, so a slow or failing
+// address only fails its own lookup and the rest of the batch still returns.
+// Results are in the order of addresses.
+func (h *Handler) fetchVestedAmounts(ctx context.Context, addresses []common.Address) []vestedLookup {
+	concurrency := h.vestedConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultVestedFetchConcurrency
+	}
+
+	results := make([]vestedLookup, len(addresses))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range addresses {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			callCtx := ctx
+			if h.vestedTimeout > 0 {
+				var cancel context.CancelFunc
+				callCtx, cancel = context.WithTimeout(ctx, h.vestedTimeout)
+				defer cancel()
+			}
+			amount, err := h.blockchain.GetVestedAmount(callCtx, addresses[i])
+			results[i] = vestedLookup{amount: amount, err: err}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// vestedErrorMessage describes a failed vested lookup for API responses
+// without exposing RPC internals
+func vestedErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "Timed out fetching vested amount"
+	case errors.Is(err, context.Canceled):
+		return "Vested amount lookup cancelled"
+	default:
+		return "Failed to get vested amount"
+	}
+}
+
+// SetZeroVestedFallback makes endpoints answer with the local vesting math
+// when the contract reports nothing vested for a schedule the local math says
+// has vested, flagging the response. Such a zero usually means a misconfigured
+// contract address. The discrepancy is logged either way.
+func (h *Handler) SetZeroVestedFallback(enabled bool) {
+	h.zeroVestedFallback = enabled
+}
+
+// reconcileVested checks an on-chain vested amount against the local vesting
+// math. A zero on chain where the local amount is positive is a discrepancy:
+// it is logged and, with the fallback enabled, the local amount is used
+// instead. Returns the amount to report, whether there was a discrepancy and
+// whether the local amount replaced the on-chain one.
+func (h *Handler) reconcileVested(beneficiary string, onchain, local *big.Int) (vested *big.Int, discrepancy, usedLocal bool) {
+	if onchain.Sign() != 0 || local == nil || local.Sign() <= 0 {
+		return onchain, false, false
+	}
+
+	if h.zeroVestedFallback {
+		log.Printf("⚠️  On-chain vested amount for %s is 0 but local vesting math gives %s, using the local amount; check VESTING_CONTRACT_ADDRESS", beneficiary, local)
+		return local, true, true
+	}
+	log.Printf("⚠️  On-chain vested amount for %s is 0 but local vesting math gives %s; check VESTING_CONTRACT_ADDRESS", beneficiary, local)
+	return onchain, true, false
+}
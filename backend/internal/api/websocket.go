@@ -0,0 +1,311 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// WebSocket connection timing. The server pings every wsPingPeriod and drops
+// a connection that has not answered within wsPongWait.
+const (
+	wsWriteWait    = 10 * time.Second
+	wsPongWait     = 60 * time.Second
+	wsPingPeriod   = wsPongWait * 9 / 10
+	wsMaxMessage   = 4096 // Largest control message accepted from a client
+	wsSendBuffered = 16   // Outgoing frames queued per connection
+)
+
+// WebSocket control actions sent by clients
+const (
+	wsActionSubscribe   = "subscribe"
+	wsActionUnsubscribe = "unsubscribe"
+)
+
+// WebSocket frame types sent to clients
+const (
+	wsFrameEvent        = "event"
+	wsFrameSubscribed   = "subscribed"
+	wsFrameUnsubscribed = "unsubscribed"
+	wsFrameError        = "error"
+)
+
+// websocketUpgrader upgrades dashboard connections. Cross-origin requests have
+// already been vetted by the CORS middleware, which rejects disallowed origins
+// before the handler runs, so the upgrader does not check origins again.
+var websocketUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// wsControl is a control message from a client
+type wsControl struct {
+	Action    string   `json:"action"`
+	Addresses []string `json:"addresses"`
+}
+
+// wsFrame is a message to a client
+type wsFrame struct {
+	Type      string          `json:"type"`
+	Addresses []string        `json:"addresses,omitempty"`
+	Event     json.RawMessage `json:"event,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// wsSubscription is one beneficiary watched by a connection
+type wsSubscription struct {
+	events      <-chan models.VestingEvent
+	unsubscribe func()
+}
+
+// wsConnection is the state of one dashboard WebSocket connection
+type wsConnection struct {
+	conn     *websocket.Conn
+	stream   EventStream
+	location *time.Location
+	shutdown <-chan struct{} // Closed when the server shuts down
+
+	send      chan wsFrame
+	done      chan struct{} // Closed once the connection is shutting down
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+
+	mu            sync.Mutex
+	subscriptions map[string]wsSubscription
+}
+
+// StreamWebSocket pushes events to a dashboard over a WebSocket. After
+// connecting, the client sends {"action": "subscribe", "addresses": [...]} and
+// {"action": "unsubscribe", "addresses": [...]} messages to choose the
+// beneficiaries it watches, and receives {"type": "event", "event": {...}}
+// frames for them. Earlier events are not replayed.
+// GET /api/v1/ws
+func (h *Handler) StreamWebSocket(c *gin.Context) {
+	if h.stream == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Event streaming not available"})
+		return
+	}
+
+	conn, err := websocketUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written an error response
+		log.Printf("⚠️  WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	ws := &wsConnection{
+		conn:          conn,
+		stream:        h.stream,
+		location:      responseLocation(c),
+		shutdown:      h.streamsDone(),
+		send:          make(chan wsFrame, wsSendBuffered),
+		done:          make(chan struct{}),
+		closeCode:     websocket.CloseNormalClosure,
+		subscriptions: make(map[string]wsSubscription),
+	}
+	ws.run()
+}
+
+// run serves the connection until either side closes it
+func (ws *wsConnection) run() {
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		ws.writeLoop()
+	}()
+
+	go func() {
+		select {
+		case <-ws.shutdown:
+			ws.close(websocket.CloseGoingAway, "server shutting down")
+		case <-ws.done:
+		}
+	}()
+
+	ws.readLoop()
+
+	ws.close(websocket.CloseNormalClosure, "")
+	ws.mu.Lock()
+	for _, subscription := range ws.subscriptions {
+		subscription.unsubscribe()
+	}
+	ws.subscriptions = nil
+	ws.mu.Unlock()
+	<-writerDone
+}
+
+// readLoop handles control messages until the client goes away or the
+// connection is closed
+func (ws *wsConnection) readLoop() {
+	ws.conn.SetReadLimit(wsMaxMessage)
+	_ = ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	ws.conn.SetPongHandler(func(string) error {
+		return ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, message, err := ws.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				select {
+				case <-ws.done:
+					// Closed by the server; the read fails once the socket is gone
+				default:
+					log.Printf("⚠️  WebSocket closed unexpectedly: %v", err)
+				}
+			}
+			return
+		}
+
+		var control wsControl
+		if err := json.Unmarshal(message, &control); err != nil {
+			ws.queue(wsFrame{Type: wsFrameError, Error: "Invalid control message: expected JSON with action and addresses"})
+			continue
+		}
+		ws.queue(ws.control(control))
+	}
+}
+
+// control applies a subscribe or unsubscribe message, returning the reply frame
+func (ws *wsConnection) control(control wsControl) wsFrame {
+	if len(control.Addresses) == 0 {
+		return wsFrame{Type: wsFrameError, Error: "addresses is required"}
+	}
+
+	addresses := make([]string, 0, len(control.Addresses))
+	for _, address := range control.Addresses {
+		address = strings.TrimSpace(address)
+		if !common.IsHexAddress(address) {
+			return wsFrame{Type: wsFrameError, Error: fmt.Sprintf("%s: %s", ERR_INVALID_ETH_ADDRESS, address)}
+		}
+		if isZeroAddress(address) {
+			return wsFrame{Type: wsFrameError, Error: ERR_ZERO_ADDRESS}
+		}
+		addresses = append(addresses, common.HexToAddress(address).Hex())
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	switch control.Action {
+	case wsActionSubscribe:
+		added := 0
+		for _, address := range addresses {
+			if _, ok := ws.subscriptions[address]; !ok {
+				added++
+			}
+		}
+		if len(ws.subscriptions)+added > maxBeneficiariesPerQuery {
+			return wsFrame{Type: wsFrameError, Error: fmt.Sprintf("Too many subscriptions (max %d per connection)", maxBeneficiariesPerQuery)}
+		}
+		for _, address := range addresses {
+			if _, ok := ws.subscriptions[address]; ok {
+				continue
+			}
+			events, unsubscribe := ws.stream.Subscribe(address)
+			ws.subscriptions[address] = wsSubscription{events: events, unsubscribe: unsubscribe}
+			go ws.forward(address, events)
+		}
+		return wsFrame{Type: wsFrameSubscribed, Addresses: addresses}
+
+	case wsActionUnsubscribe:
+		for _, address := range addresses {
+			if subscription, ok := ws.subscriptions[address]; ok {
+				delete(ws.subscriptions, address)
+				subscription.unsubscribe()
+			}
+		}
+		return wsFrame{Type: wsFrameUnsubscribed, Addresses: addresses}
+
+	default:
+		return wsFrame{Type: wsFrameError, Error: "action must be subscribe or unsubscribe"}
+	}
+}
+
+// forward relays one subscription's events to the client until the
+// subscription ends
+func (ws *wsConnection) forward(address string, events <-chan models.VestingEvent) {
+	for event := range events {
+		data, err := json.Marshal(localizeTimes(event, ws.location))
+		if err != nil {
+			log.Printf("❌ Failed to encode streamed event %d: %v", event.ID, err)
+			continue
+		}
+		if !ws.queue(wsFrame{Type: wsFrameEvent, Event: data}) {
+			return
+		}
+	}
+
+	// The channel also closes on unsubscribe; only a subscription still on
+	// record was dropped by the stream for falling behind
+	ws.mu.Lock()
+	subscription, ok := ws.subscriptions[address]
+	dropped := ok && subscription.events == events
+	ws.mu.Unlock()
+	if dropped {
+		// The client reconnects and catches up from the events listing
+		ws.close(websocket.CloseTryAgainLater, "fell behind the event stream")
+	}
+}
+
+// queue hands a frame to the writer. Reports false once the connection is closing.
+func (ws *wsConnection) queue(frame wsFrame) bool {
+	select {
+	case ws.send <- frame:
+		return true
+	case <-ws.done:
+		return false
+	}
+}
+
+// writeLoop writes queued frames and keep-alive pings until the connection
+// closes, then sends a close frame and releases the socket
+func (ws *wsConnection) writeLoop() {
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+	defer ws.conn.Close()
+
+	for {
+		select {
+		case frame := <-ws.send:
+			_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := ws.conn.WriteJSON(frame); err != nil {
+				ws.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ping.C:
+			if err := ws.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				ws.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ws.done:
+			if ws.closeCode != websocket.CloseAbnormalClosure {
+				message := websocket.FormatCloseMessage(ws.closeCode, ws.closeText)
+				_ = ws.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait))
+			}
+			return
+		}
+	}
+}
+
+// close starts shutting the connection down with a close code. Only the first
+// call has an effect.
+func (ws *wsConnection) close(code int, text string) {
+	ws.closeOnce.Do(func() {
+		ws.closeCode = code
+		ws.closeText = text
+		close(ws.done)
+	})
+}
@@ -0,0 +1,97 @@
+package blockchain
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// defaultHistoricalBatchSize is the historical sync's block range per log query
+// when none is configured
+const defaultHistoricalBatchSize = 10000
+
+// limitExceededCode is the JSON-RPC error code providers such as Infura return
+// when a log query spans too many blocks or matches too many logs
+const limitExceededCode = -32005
+
+// rangeTooLargeMessages are fragments of the errors RPC providers return when
+// an eth_getLogs range exceeds their limits
+var rangeTooLargeMessages = []string{
+	"query returned more than",
+	"block range",
+	"range is too large",
+	"range too large",
+	"too many blocks",
+	"exceed maximum block range",
+	"query exceeds max",
+	"response size exceeded",
+	"log response size",
+	"limit exceeded",
+}
+
+// isRangeTooLargeError reports whether a failed log query was rejected for
+// covering too many blocks or logs, so a smaller range may succeed
+func isRangeTooLargeError(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == limitExceededCode {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range rangeTooLargeMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// adaptiveBatch sizes historical log queries to what the RPC node accepts. A
+// rejected range halves the size; each accepted range grows it by an eighth,
+// up to the configured maximum but never back to a size the node rejected.
+type adaptiveBatch struct {
+	size    uint64 // Blocks in the next query
+	max     uint64 // Upper bound set by configuration
+	ceiling uint64 // One below the smallest rejected size; 0 until a rejection
+}
+
+// newAdaptiveBatch starts at initial blocks per query, growing to at most max.
+// Zero values fall back to the default size; max is raised to initial if lower.
+func newAdaptiveBatch(initial, max uint64) *adaptiveBatch {
+	if initial == 0 {
+		initial = defaultHistoricalBatchSize
+	}
+	if max < initial {
+		max = initial
+	}
+	return &adaptiveBatch{size: initial, max: max}
+}
+
+// shrink halves the size after the node rejected it. It returns false when the
+// size is already a single block and cannot shrink further.
+func (b *adaptiveBatch) shrink() bool {
+	if b.size <= 1 {
+		return false
+	}
+	b.ceiling = b.size - 1
+	b.size /= 2
+	return true
+}
+
+// grow enlarges the size after an accepted query
+func (b *adaptiveBatch) grow() {
+	limit := b.max
+	if b.ceiling > 0 && b.ceiling < limit {
+		limit = b.ceiling
+	}
+
+	step := b.size / 8
+	if step == 0 {
+		step = 1
+	}
+	b.size += step
+	if b.size > limit {
+		b.size = limit
+	}
+}
@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// codedError is a JSON-RPC error carrying an error code
+type codedError struct {
+	code int
+}
+
+func (e codedError) Error() string  { return "limit reached" }
+func (e codedError) ErrorCode() int { return e.code }
+
+func TestIsRangeTooLargeError(t *testing.T) {
+	tooLarge := []error{
+		errors.New("query returned more than 10000 results"),
+		errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range"),
+		errors.New("block range is too wide"),
+		errors.New("exceed maximum block range: 5000"),
+		fmt.Errorf("failed to filter logs: %w", codedError{code: limitExceededCode}),
+	}
+	for _, err := range tooLarge {
+		assert.True(t, isRangeTooLargeError(err), err.Error())
+	}
+
+	for _, err := range []error{errors.New("rpc timeout"), errors.New("execution reverted"), codedError{code: -32000}} {
+		assert.False(t, isRangeTooLargeError(err), err.Error())
+	}
+}
+
+func TestAdaptiveBatch(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		batch := newAdaptiveBatch(0, 0)
+		assert.Equal(t, uint64(defaultHistoricalBatchSize), batch.size)
+		assert.Equal(t, uint64(defaultHistoricalBatchSize), batch.max)
+	})
+
+	t.Run("Grows slowly up to the maximum", func(t *testing.T) {
+		batch := newAdaptiveBatch(800, 1000)
+		batch.grow()
+		assert.Equal(t, uint64(900), batch.size)
+		batch.grow()
+		batch.grow()
+		assert.Equal(t, uint64(1000), batch.size)
+	})
+
+	t.Run("Never grows back to a rejected size", func(t *testing.T) {
+		batch := newAdaptiveBatch(4000, 10000)
+		assert.True(t, batch.shrink())
+		assert.Equal(t, uint64(2000), batch.size)
+		for i := 0; i < 20; i++ {
+			batch.grow()
+		}
+		assert.Equal(t, uint64(3999), batch.size)
+	})
+
+	t.Run("Stops shrinking at one block", func(t *testing.T) {
+		batch := newAdaptiveBatch(2, 2)
+		assert.True(t, batch.shrink())
+		assert.Equal(t, uint64(1), batch.size)
+		assert.False(t, batch.shrink())
+	})
+}
@@ -0,0 +1,46 @@
+package blockchain
+
+import (
+	"sync"
+	"time"
+)
+
+// blockTimeCacheSize bounds the number of block timestamps kept in memory
+const blockTimeCacheSize = 4096
+
+// blockTimeCache remembers block timestamps so blocks holding several events
+// are only looked up once. When full, the oldest entries are evicted first.
+type blockTimeCache struct {
+	mu     sync.Mutex
+	times  map[uint64]time.Time
+	order  []uint64 // Insertion order, for eviction
+	maxLen int
+}
+
+func newBlockTimeCache(maxLen int) *blockTimeCache {
+	return &blockTimeCache{times: make(map[uint64]time.Time), maxLen: maxLen}
+}
+
+// get returns a cached block timestamp
+func (b *blockTimeCache) get(block uint64) (time.Time, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.times[block]
+	return t, ok
+}
+
+// put caches a block timestamp, evicting the oldest entry when full
+func (b *blockTimeCache) put(block uint64, t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.times[block]; ok {
+		return
+	}
+	if len(b.order) >= b.maxLen {
+		delete(b.times, b.order[0])
+		b.order = b.order[1:]
+	}
+	b.times[block] = t
+	b.order = append(b.order, block)
+}
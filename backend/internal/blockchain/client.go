@@ -2,16 +2,22 @@ package blockchain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/kaldun-tech/token-vesting-backend/internal/config"
 	"github.com/kaldun-tech/token-vesting-backend/pkg/contracts"
@@ -19,63 +25,179 @@ import (
 
 type Client struct {
 	ethClient       *ethclient.Client
+	node            *retryingBackend // ethClient with transient failures retried
+	subscriber      logSubscriber    // Opens live log subscriptions; ethClient outside tests
 	vestingContract *contracts.TokenVesting
 	config          *config.Config
 	contractAddress common.Address
+
+	contractAbi abi.ABI
+	// eventTopics maps event signature topics to internal event names
+	eventTopics map[common.Hash]string
+
+	blockTimes *blockTimeCache
 }
 
 // NewClient creates a new blockchain client
 func NewClient(cfg *config.Config) (*Client, error) {
+	// Bound the dial and connection check so an unreachable RPC fails startup
+	// instead of hanging it
+	ctx := context.Background()
+	if cfg.RPCDialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.RPCDialTimeout)
+		defer cancel()
+	}
+
 	// Connect to Ethereum node
-	client, err := ethclient.Dial(cfg.EthereumRPC)
+	client, err := ethclient.DialContext(ctx, cfg.EthereumRPC)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
 	}
 
-	// Verify connection
-	chainID, err := client.ChainID(context.Background())
+	// Verify connection. HTTP endpoints are only contacted on the first call.
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
 	log.Printf("✅ Connected to Ethereum network (Chain ID: %s)", chainID.String())
 
+	node := &retryingBackend{rpcBackend: client, policy: RetryPolicy{
+		MaxAttempts: cfg.RPCRetryMaxAttempts,
+		BaseDelay:   cfg.RPCRetryBaseDelay,
+		MaxDelay:    cfg.RPCRetryMaxDelay,
+	}}
+
 	// Load contract
 	contractAddress := common.HexToAddress(cfg.TokenVestingAddress)
-	vestingContract, err := contracts.NewTokenVesting(contractAddress, client)
+	vestingContract, err := contracts.NewTokenVesting(contractAddress, node, cfg.MethodNameMap)
 	if err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to load vesting contract: %w", err)
 	}
+	for deployed, internal := range cfg.MethodNameMap {
+		log.Printf("🔀 Calling %s for %s", deployed, internal)
+	}
 
 	log.Printf("✅ Vesting contract loaded at %s", contractAddress.Hex())
 
+	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
+	}
+
+	eventTopics, err := buildEventTopics(contractAbi, cfg.EventNameMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event name map: %w", err)
+	}
+
 	return &Client{
 		ethClient:       client,
+		node:            node,
+		subscriber:      client,
 		vestingContract: vestingContract,
 		config:          cfg,
 		contractAddress: contractAddress,
+		contractAbi:     contractAbi,
+		eventTopics:     eventTopics,
+		blockTimes:      newBlockTimeCache(blockTimeCacheSize),
 	}, nil
 }
 
-// GetVestingSchedule retrieves a vesting schedule from the blockchain
-func (c *Client) GetVestingSchedule(beneficiary common.Address) (*contracts.VestingSchedule, error) {
-	schedule, err := c.vestingContract.VestingSchedules(nil, beneficiary)
+// buildEventTopics maps each ABI event's topic to its name, plus a topic for
+// every alias so differently-named but equivalent events route to the same handler.
+// aliases maps the deployed contract's event name to the internal event name.
+func buildEventTopics(contractAbi abi.ABI, aliases map[string]string) (map[common.Hash]string, error) {
+	topics := make(map[common.Hash]string, len(contractAbi.Events)+len(aliases))
+	for name, event := range contractAbi.Events {
+		topics[event.ID] = name
+	}
+
+	for actual, internal := range aliases {
+		base, ok := contractAbi.Events[internal]
+		if !ok {
+			return nil, fmt.Errorf("unknown internal event %q for alias %q", internal, actual)
+		}
+		// The signature hash depends on the name, so derive it with the same inputs
+		aliased := abi.NewEvent(actual, actual, base.Anonymous, base.Inputs)
+		topics[aliased.ID] = internal
+		log.Printf("🔀 Routing %s events to %s handler", actual, internal)
+	}
+
+	return topics, nil
+}
+
+// GetVestingSchedule retrieves a vesting schedule from the blockchain. The call
+// is abandoned when ctx is cancelled.
+func (c *Client) GetVestingSchedule(ctx context.Context, beneficiary common.Address) (*contracts.VestingSchedule, error) {
+	schedule, err := c.vestingContract.VestingSchedules(&bind.CallOpts{Context: ctx}, beneficiary)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vesting schedule: %w", err)
 	}
 	return &schedule, nil
 }
 
-// GetVestedAmount gets the vested amount for a beneficiary
-func (c *Client) GetVestedAmount(beneficiary common.Address) (*big.Int, error) {
-	amount, err := c.vestingContract.VestedAmount(nil, beneficiary)
+// GetTokenDecimals reads an ERC20 token's decimals
+func (c *Client) GetTokenDecimals(ctx context.Context, token common.Address) (uint8, error) {
+	erc20, err := contracts.NewERC20(token, c.node)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load token contract: %w", err)
+	}
+
+	decimals, err := erc20.Decimals(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get decimals for %s: %w", token.Hex(), err)
+	}
+	return decimals, nil
+}
+
+// GetVestedAmount gets the vested amount for a beneficiary. The call is
+// abandoned when ctx is cancelled.
+func (c *Client) GetVestedAmount(ctx context.Context, beneficiary common.Address) (*big.Int, error) {
+	amount, err := c.vestingContract.VestedAmount(&bind.CallOpts{Context: ctx}, beneficiary)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vested amount: %w", err)
 	}
 	return amount, nil
 }
 
-// WatchEvents watches for contract events starting from a specific block
+// defaultLogPollInterval is how often logs are polled when no interval is configured
+const defaultLogPollInterval = 5 * time.Second
+
+// logPollInterval returns how often to poll for logs or re-check the head
+func (c *Client) logPollInterval() time.Duration {
+	if c.config != nil && c.config.LogPollInterval > 0 {
+		return c.config.LogPollInterval
+	}
+	return defaultLogPollInterval
+}
+
+// confirmationBlocks returns how many blocks must follow an event's block
+// before the event is emitted
+func (c *Client) confirmationBlocks() uint64 {
+	if c.config == nil {
+		return 0
+	}
+	return c.config.ConfirmationBlocks
+}
+
+// logSubscriber opens a subscription delivering new logs matching a query
+type logSubscriber interface {
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+}
+
+// WatchEvents watches for contract events starting from a specific block.
+// Events are emitted once their block has the configured number of
+// confirmations. Endpoints without subscription support, such as plain HTTP
+// RPC, are polled for new logs instead.
+//
+// A subscription only delivers logs from blocks mined after it opens, so the
+// blocks from startBlock up to the current head are fetched once the
+// subscription is up and fed through the same confirmation buffer. A log
+// mined while the backfill runs may arrive twice; the listener skips events
+// it has already recorded.
 func (c *Client) WatchEvents(ctx context.Context, startBlock uint64, eventChan chan<- *ContractEvent) error {
 	query := ethereum.FilterQuery{
 		Addresses: []common.Address{c.contractAddress},
@@ -83,37 +205,172 @@ func (c *Client) WatchEvents(ctx context.Context, startBlock uint64, eventChan c
 	}
 
 	logs := make(chan types.Log)
-	sub, err := c.ethClient.SubscribeFilterLogs(ctx, query, logs)
+	sub, err := c.subscriber.SubscribeFilterLogs(ctx, query, logs)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		interval := c.logPollInterval()
+		log.Printf("⚠️  RPC endpoint does not support subscriptions, polling for logs every %s", interval)
+		go c.pollLogs(ctx, startBlock, interval, eventChan)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to logs: %w", err)
 	}
 
+	backfill, err := c.backfillLogs(ctx, startBlock)
+	if err != nil {
+		// The poller covers the same blocks, so fall back to it rather than
+		// leave a gap before the subscription's first log
+		sub.Unsubscribe()
+		interval := c.logPollInterval()
+		log.Printf("⚠️  Could not backfill logs from block %d, polling for logs every %s instead: %v", startBlock, interval, err)
+		go c.pollLogs(ctx, startBlock, interval, eventChan)
+		return nil
+	}
+
 	log.Printf("🔍 Watching for events from block %d", startBlock)
 
 	go func() {
 		defer sub.Unsubscribe()
+
+		// Unconfirmed logs wait for later blocks; the head is re-checked as
+		// logs arrive and on every tick
+		buffer := &confirmationBuffer{confirmations: c.confirmationBlocks()}
+		for _, vLog := range backfill {
+			buffer.add(vLog)
+		}
+		ticker := time.NewTicker(c.logPollInterval())
+		defer ticker.Stop()
+
+		if !c.emitConfirmed(ctx, buffer, eventChan) {
+			return
+		}
+
 		for {
 			select {
 			case err := <-sub.Err():
 				log.Printf("❌ Event subscription error: %v", err)
 				return
 			case vLog := <-logs:
-				event, err := c.parseEvent(vLog)
-				if err != nil {
-					log.Printf("⚠️  Failed to parse event: %v", err)
-					continue
-				}
-				eventChan <- event
+				buffer.add(vLog)
+			case <-ticker.C:
 			case <-ctx.Done():
 				log.Println("🛑 Stopping event watcher")
 				return
 			}
+
+			if !c.emitConfirmed(ctx, buffer, eventChan) {
+				return
+			}
 		}
 	}()
 
 	return nil
 }
 
+// backfillLogs fetches the contract's logs from startBlock up to the current
+// head, which a new subscription does not deliver
+func (c *Client) backfillLogs(ctx context.Context, startBlock uint64) ([]types.Log, error) {
+	head, err := c.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head < startBlock {
+		return nil, nil
+	}
+
+	logs, err := c.node.FilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{c.contractAddress},
+		FromBlock: new(big.Int).SetUint64(startBlock),
+		ToBlock:   new(big.Int).SetUint64(head),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs for blocks %d to %d: %w", startBlock, head, err)
+	}
+	return logs, nil
+}
+
+// emitConfirmed sends the buffered logs confirmed at the current head. It
+// returns false if ctx ended while sending.
+func (c *Client) emitConfirmed(ctx context.Context, buffer *confirmationBuffer, eventChan chan<- *ContractEvent) bool {
+	if len(buffer.pending) == 0 {
+		return true
+	}
+
+	head := uint64(math.MaxUint64)
+	if buffer.confirmations > 0 {
+		var err error
+		if head, err = c.GetLatestBlockNumber(ctx); err != nil {
+			log.Printf("⚠️  Could not check confirmations of %d pending events: %v", len(buffer.pending), err)
+			return true
+		}
+	}
+
+	for _, vLog := range buffer.release(head) {
+		event, err := c.parseEvent(vLog)
+		if err != nil {
+			log.Printf("⚠️  Failed to parse event: %v", err)
+			continue
+		}
+		select {
+		case eventChan <- event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// pollLogs delivers contract events by querying logs from the next
+// undelivered block up to the newest block with the configured number of
+// confirmations, immediately and then every interval, until ctx is done. Each
+// block is delivered once; failed polls are retried on the next tick from the
+// same block.
+func (c *Client) pollLogs(ctx context.Context, next uint64, interval time.Duration, eventChan chan<- *ContractEvent) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("🔍 Polling for events from block %d", next)
+
+	confirmations := c.confirmationBlocks()
+
+	for {
+		if head, err := c.GetLatestBlockNumber(ctx); err != nil {
+			log.Printf("⚠️  Log poll failed: %v", err)
+		} else if confirmedAt(next, head, confirmations) {
+			head -= confirmations
+			logs, err := c.node.FilterLogs(ctx, ethereum.FilterQuery{
+				Addresses: []common.Address{c.contractAddress},
+				FromBlock: new(big.Int).SetUint64(next),
+				ToBlock:   new(big.Int).SetUint64(head),
+			})
+			if err != nil {
+				log.Printf("⚠️  Log poll for blocks %d to %d failed: %v", next, head, err)
+			} else {
+				for _, vLog := range logs {
+					event, err := c.parseEvent(vLog)
+					if err != nil {
+						log.Printf("⚠️  Failed to parse event: %v", err)
+						continue
+					}
+					select {
+					case eventChan <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+				next = head + 1
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Println("🛑 Stopping event poller")
+			return
+		}
+	}
+}
+
 // FetchHistoricalEvents fetches past events in batches
 func (c *Client) FetchHistoricalEvents(ctx context.Context, fromBlock, toBlock uint64) ([]*ContractEvent, error) {
 	query := ethereum.FilterQuery{
@@ -122,7 +379,7 @@ func (c *Client) FetchHistoricalEvents(ctx context.Context, fromBlock, toBlock u
 		ToBlock:   big.NewInt(int64(toBlock)),
 	}
 
-	logs, err := c.ethClient.FilterLogs(ctx, query)
+	logs, err := c.node.FilterLogs(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to filter logs: %w", err)
 	}
@@ -140,60 +397,198 @@ func (c *Client) FetchHistoricalEvents(ctx context.Context, fromBlock, toBlock u
 	return events, nil
 }
 
+// GetBeneficiaryLogs fetches the contract's raw logs for a beneficiary, matching
+// the indexed beneficiary topic shared by all vesting events
+func (c *Client) GetBeneficiaryLogs(ctx context.Context, beneficiary common.Address) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{c.contractAddress},
+		FromBlock: new(big.Int).SetUint64(c.config.StartBlock),
+		Topics:    [][]common.Hash{nil, {common.BytesToHash(beneficiary.Bytes())}},
+	}
+
+	logs, err := c.node.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs: %w", err)
+	}
+	return logs, nil
+}
+
 // GetLatestBlockNumber gets the latest block number
 func (c *Client) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
-	header, err := c.ethClient.HeaderByNumber(ctx, nil)
+	header, err := c.node.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get latest block: %w", err)
 	}
 	return header.Number.Uint64(), nil
 }
 
+// timestampBatchSize bounds the header requests sent in one JSON-RPC batch,
+// staying under the batch limits common RPC providers enforce
+const timestampBatchSize = 100
+
+// GetBlockTimestamp reads a block's timestamp from its header, reusing
+// timestamps already fetched for the block
+func (c *Client) GetBlockTimestamp(ctx context.Context, block uint64) (time.Time, error) {
+	if t, ok := c.blockTimes.get(block); ok {
+		return t, nil
+	}
+
+	header, err := c.node.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get header for block %d: %w", block, err)
+	}
+
+	t := time.Unix(int64(header.Time), 0).UTC()
+	c.blockTimes.put(block, t)
+	return t, nil
+}
+
+// GetBlockTimestamps reads the timestamps of the given blocks, fetching the
+// headers not already cached in JSON-RPC batches rather than one request per block
+func (c *Client) GetBlockTimestamps(ctx context.Context, blocks []uint64) (map[uint64]time.Time, error) {
+	timestamps := make(map[uint64]time.Time, len(blocks))
+
+	missing := make([]uint64, 0, len(blocks))
+	for _, block := range blocks {
+		if t, ok := c.blockTimes.get(block); ok {
+			timestamps[block] = t
+		} else {
+			missing = append(missing, block)
+		}
+	}
+	blocks = missing
+
+	for start := 0; start < len(blocks); start += timestampBatchSize {
+		end := start + timestampBatchSize
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+
+		headers := make([]*types.Header, end-start)
+		batch := make([]rpc.BatchElem, end-start)
+		for i, block := range blocks[start:end] {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []interface{}{hexutil.EncodeUint64(block), false},
+				Result: &headers[i],
+			}
+		}
+
+		if err := c.ethClient.Client().BatchCallContext(ctx, batch); err != nil {
+			return nil, fmt.Errorf("failed to fetch block headers: %w", err)
+		}
+		for i, elem := range batch {
+			if elem.Error != nil {
+				return nil, fmt.Errorf("failed to fetch header for block %d: %w", blocks[start+i], elem.Error)
+			}
+			if headers[i] == nil {
+				return nil, fmt.Errorf("block %d not found", blocks[start+i])
+			}
+			t := time.Unix(int64(headers[i].Time), 0).UTC()
+			timestamps[blocks[start+i]] = t
+			c.blockTimes.put(blocks[start+i], t)
+		}
+	}
+
+	return timestamps, nil
+}
+
+// GetLatestBlockTime gets the timestamp of the latest block
+func (c *Client) GetLatestBlockTime(ctx context.Context) (time.Time, error) {
+	header, err := c.node.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	return time.Unix(int64(header.Time), 0).UTC(), nil
+}
+
+// FindDeploymentBlock binary-searches for the first block at which the vesting
+// contract has code. Requires historical state, so pruned nodes may fail.
+func (c *Client) FindDeploymentBlock(ctx context.Context) (uint64, error) {
+	head, err := c.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	code, err := c.node.CodeAt(ctx, c.contractAddress, new(big.Int).SetUint64(head))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get contract code: %w", err)
+	}
+	if len(code) == 0 {
+		return 0, fmt.Errorf("no contract code at %s", c.contractAddress.Hex())
+	}
+
+	low, high := uint64(0), head
+	for low < high {
+		mid := low + (high-low)/2
+		code, err := c.node.CodeAt(ctx, c.contractAddress, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, fmt.Errorf("failed to get contract code at block %d: %w", mid, err)
+		}
+		if len(code) > 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// GetGasUsed reads the gas used by a transaction from its receipt
+func (c *Client) GetGasUsed(ctx context.Context, txHash string) (uint64, error) {
+	receipt, err := c.node.TransactionReceipt(ctx, common.HexToHash(txHash))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get receipt for %s: %w", txHash, err)
+	}
+	return receipt.GasUsed, nil
+}
+
 // parseEvent parses a log event into our ContractEvent struct
 func (c *Client) parseEvent(vLog types.Log) (*ContractEvent, error) {
-	// Parse based on topic (event signature)
-	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
-	if err != nil {
-		return nil, err
+	if len(vLog.Topics) < 2 {
+		return nil, fmt.Errorf("unexpected topic count %d", len(vLog.Topics))
 	}
 
 	event := &ContractEvent{
 		BlockNumber:     vLog.BlockNumber,
 		TransactionHash: vLog.TxHash.Hex(),
+		LogIndex:        vLog.Index,
 	}
 
-	// Determine event type by topic
-	switch vLog.Topics[0].Hex() {
-	case contractAbi.Events["VestingScheduleCreated"].ID.Hex():
+	// Determine event type by topic (event signature), honoring aliases
+	contractAbi := c.contractAbi
+	switch c.eventTopics[vLog.Topics[0]] {
+	case "VestingScheduleCreated":
 		var scheduleCreated contracts.TokenVestingVestingScheduleCreated
 		err := contractAbi.UnpackIntoInterface(&scheduleCreated, "VestingScheduleCreated", vLog.Data)
 		if err != nil {
-			return nil, err
+			return c.partialEvent(event, "VestingScheduleCreated", vLog, err)
 		}
 		event.EventType = "VestingScheduleCreated"
 		event.Beneficiary = common.HexToAddress(vLog.Topics[1].Hex()).Hex()
 		event.Amount = scheduleCreated.Amount.String()
+		event.Token = c.scheduleToken(contractAbi.Events["VestingScheduleCreated"], vLog)
 		event.Data = map[string]interface{}{
 			"start":    scheduleCreated.Start.String(),
 			"cliff":    scheduleCreated.Cliff.String(),
 			"duration": scheduleCreated.Duration.String(),
 		}
 
-	case contractAbi.Events["TokensReleased"].ID.Hex():
+	case "TokensReleased":
 		var tokensReleased contracts.TokenVestingTokensReleased
 		err := contractAbi.UnpackIntoInterface(&tokensReleased, "TokensReleased", vLog.Data)
 		if err != nil {
-			return nil, err
+			return c.partialEvent(event, "TokensReleased", vLog, err)
 		}
 		event.EventType = "TokensReleased"
 		event.Beneficiary = common.HexToAddress(vLog.Topics[1].Hex()).Hex()
 		event.Amount = tokensReleased.Amount.String()
 
-	case contractAbi.Events["VestingRevoked"].ID.Hex():
+	case "VestingRevoked":
 		var vestingRevoked contracts.TokenVestingVestingRevoked
 		err := contractAbi.UnpackIntoInterface(&vestingRevoked, "VestingRevoked", vLog.Data)
 		if err != nil {
-			return nil, err
+			return c.partialEvent(event, "VestingRevoked", vLog, err)
 		}
 		event.EventType = "VestingRevoked"
 		event.Beneficiary = common.HexToAddress(vLog.Topics[1].Hex()).Hex()
@@ -206,14 +601,47 @@ func (c *Client) parseEvent(vLog types.Log) (*ContractEvent, error) {
 	return event, nil
 }
 
+// scheduleToken resolves the vested token for a schedule creation log. Multi-token
+// deployments emit the token as a "token" event argument; single-token contracts
+// fall back to the configured TOKEN_ADDRESS.
+func (c *Client) scheduleToken(event abi.Event, vLog types.Log) string {
+	topic := 1 // Topic 0 is the event signature
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			if input.Name == "token" && input.Type.T == abi.AddressTy && topic < len(vLog.Topics) {
+				return common.BytesToAddress(vLog.Topics[topic].Bytes()).Hex()
+			}
+			topic++
+			continue
+		}
+		if input.Name == "token" && input.Type.T == abi.AddressTy {
+			values := make(map[string]interface{})
+			if err := event.Inputs.UnpackIntoMap(values, vLog.Data); err == nil {
+				if token, ok := values["token"].(common.Address); ok {
+					return token.Hex()
+				}
+			}
+		}
+	}
+
+	if c.config != nil && common.IsHexAddress(c.config.TokenAddress) {
+		return common.HexToAddress(c.config.TokenAddress).Hex()
+	}
+	return ""
+}
+
 // ContractEvent represents a parsed contract event
 type ContractEvent struct {
 	EventType       string
 	Beneficiary     string
+	Token           string // Vested token, set for schedule creation events
 	Amount          string
 	BlockNumber     uint64
 	TransactionHash string
+	LogIndex        uint      // Position of the log within its block
+	Timestamp       time.Time // Block timestamp; zero when not fetched
 	Data            map[string]interface{}
+	DecodeError     string // Set when the log data only partially decoded; the failing field and error
 }
 
 // Close closes the Ethereum client connection
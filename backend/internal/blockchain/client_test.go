@@ -0,0 +1,643 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/pkg/contracts"
+)
+
+// newTestClient builds a Client with parsed ABI and event routing but no RPC connection
+func newTestClient(t *testing.T, aliases map[string]string) *Client {
+	t.Helper()
+
+	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
+	require.NoError(t, err)
+
+	eventTopics, err := buildEventTopics(contractAbi, aliases)
+	require.NoError(t, err)
+
+	return &Client{contractAbi: contractAbi, eventTopics: eventTopics}
+}
+
+func TestParseEvent_AliasedEventName(t *testing.T) {
+	client := newTestClient(t, map[string]string{"TokensClaimed": "TokensReleased"})
+
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	base := client.contractAbi.Events["TokensReleased"]
+	aliased := abi.NewEvent("TokensClaimed", "TokensClaimed", base.Anonymous, base.Inputs)
+
+	data, err := base.Inputs.NonIndexed().Pack(big.NewInt(750))
+	require.NoError(t, err)
+
+	event, err := client.parseEvent(types.Log{
+		Topics:      []common.Hash{aliased.ID, common.BytesToHash(beneficiary.Bytes())},
+		Data:        data,
+		BlockNumber: 42,
+		Index:       7,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TokensReleased", event.EventType)
+	assert.Equal(t, beneficiary.Hex(), event.Beneficiary)
+	assert.Equal(t, "750", event.Amount)
+	assert.Equal(t, uint64(42), event.BlockNumber)
+	assert.Equal(t, uint(7), event.LogIndex)
+}
+
+func TestParseEvent_UnknownEvent(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	unknown := abi.NewEvent("Unrelated", "Unrelated", false, client.contractAbi.Events["TokensReleased"].Inputs)
+	_, err := client.parseEvent(types.Log{
+		Topics: []common.Hash{unknown.ID, {}},
+	})
+	assert.Error(t, err)
+}
+
+func TestParseEvent_PartialDecode(t *testing.T) {
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+
+	// Only amount and start are present; cliff and duration are cut off
+	var data []byte
+	data = append(data, common.BigToHash(big.NewInt(1000)).Bytes()...)
+	data = append(data, common.BigToHash(big.NewInt(1700000000)).Bytes()...)
+
+	logEntry := func(client *Client) types.Log {
+		return types.Log{
+			Topics:      []common.Hash{client.contractAbi.Events["VestingScheduleCreated"].ID, common.BytesToHash(beneficiary.Bytes())},
+			Data:        data,
+			BlockNumber: 42,
+			TxHash:      common.HexToHash("0xabc"),
+			Index:       3,
+		}
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		client := newTestClient(t, nil)
+		client.config = &config.Config{DecodePartialEvents: true}
+
+		event, err := client.parseEvent(logEntry(client))
+		require.NoError(t, err)
+
+		assert.Equal(t, "VestingScheduleCreated", event.EventType)
+		assert.Equal(t, beneficiary.Hex(), event.Beneficiary)
+		assert.Equal(t, "1000", event.Amount)
+		assert.Equal(t, map[string]interface{}{"start": "1700000000"}, event.Data)
+		assert.Equal(t, uint(3), event.LogIndex)
+		assert.True(t, strings.HasPrefix(event.DecodeError, "cliff: "), event.DecodeError)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		client := newTestClient(t, nil)
+		client.config = &config.Config{DecodePartialEvents: false}
+
+		event, err := client.parseEvent(logEntry(client))
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+}
+
+func TestBuildEventTopics_UnknownInternalEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
+	require.NoError(t, err)
+
+	_, err = buildEventTopics(contractAbi, map[string]string{"TokensClaimed": "TokensWithdrawn"})
+	assert.Error(t, err)
+}
+
+// recordingCaller answers contract calls by selector and records which selectors were called
+type recordingCaller struct {
+	outputs map[[4]byte][]byte
+	called  [][4]byte
+	blocks  []*big.Int // Block number passed with each call; nil means latest
+}
+
+func (r *recordingCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (r *recordingCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var selector [4]byte
+	copy(selector[:], call.Data)
+	r.called = append(r.called, selector)
+	r.blocks = append(r.blocks, blockNumber)
+
+	output, ok := r.outputs[selector]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return output, nil
+}
+
+func TestGetVestingSchedule_DecodesAllFields(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
+	require.NoError(t, err)
+
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	method := contractAbi.Methods["vestingSchedules"]
+	// Distinct values per field, with the trailing bools differing so a swap is caught
+	output, err := method.Outputs.Pack(beneficiary, big.NewInt(1700000000), big.NewInt(1731536000), big.NewInt(126144000), big.NewInt(5000), big.NewInt(1250), false, true)
+	require.NoError(t, err)
+
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	caller := &recordingCaller{outputs: map[[4]byte][]byte{selector: output}}
+
+	vesting, err := contracts.NewTokenVesting(common.HexToAddress("0x5d6709ce17C956833B66aDe058832c1890aF19b7"), caller, nil)
+	require.NoError(t, err)
+	client := &Client{vestingContract: vesting}
+
+	schedule, err := client.GetVestingSchedule(context.Background(), beneficiary)
+	require.NoError(t, err)
+
+	assert.Equal(t, contracts.VestingSchedule{
+		Beneficiary: beneficiary,
+		Start:       big.NewInt(1700000000),
+		Cliff:       big.NewInt(1731536000),
+		Duration:    big.NewInt(126144000),
+		Amount:      big.NewInt(5000),
+		Released:    big.NewInt(1250),
+		Revocable:   false,
+		Revoked:     true,
+	}, *schedule)
+	assert.Equal(t, [][4]byte{selector}, caller.called)
+}
+
+func TestVestedAmount_ContractCall(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
+	require.NoError(t, err)
+
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	method := contractAbi.Methods["vestedAmount"]
+	vested, _ := new(big.Int).SetString("123456789000000000000", 10)
+	output, err := method.Outputs.Pack(vested)
+	require.NoError(t, err)
+
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	contractAddress := common.HexToAddress("0x5d6709ce17C956833B66aDe058832c1890aF19b7")
+
+	t.Run("Decodes amount at latest block", func(t *testing.T) {
+		caller := &recordingCaller{outputs: map[[4]byte][]byte{selector: output}}
+		vesting, err := contracts.NewTokenVesting(contractAddress, caller, nil)
+		require.NoError(t, err)
+
+		amount, err := vesting.VestedAmount(nil, beneficiary)
+		require.NoError(t, err)
+		assert.Equal(t, vested, amount)
+		assert.Equal(t, [][4]byte{selector}, caller.called)
+		assert.Equal(t, []*big.Int{nil}, caller.blocks)
+	})
+
+	t.Run("Calls at requested block", func(t *testing.T) {
+		caller := &recordingCaller{outputs: map[[4]byte][]byte{selector: output}}
+		vesting, err := contracts.NewTokenVesting(contractAddress, caller, nil)
+		require.NoError(t, err)
+
+		_, err = vesting.VestedAmount(&bind.CallOpts{BlockNumber: big.NewInt(4200)}, beneficiary)
+		require.NoError(t, err)
+		assert.Equal(t, []*big.Int{big.NewInt(4200)}, caller.blocks)
+	})
+
+	t.Run("Revert is an error, not zero", func(t *testing.T) {
+		vesting, err := contracts.NewTokenVesting(contractAddress, &recordingCaller{}, nil)
+		require.NoError(t, err)
+
+		amount, err := vesting.VestedAmount(nil, beneficiary)
+		assert.Error(t, err)
+		assert.Nil(t, amount)
+
+		_, err = (&Client{vestingContract: vesting}).GetVestedAmount(context.Background(), beneficiary)
+		assert.ErrorContains(t, err, "execution reverted")
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		caller := &recordingCaller{outputs: map[[4]byte][]byte{selector: output}}
+		vesting, err := contracts.NewTokenVesting(contractAddress, caller, nil)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = vesting.VestedAmount(&bind.CallOpts{Context: ctx}, beneficiary)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, caller.called)
+	})
+}
+
+func TestClient_MethodNameOverrides(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(contracts.TokenVestingMetaData.ABI))
+	require.NoError(t, err)
+
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	scheduleMethod := contractAbi.Methods["vestingSchedules"]
+	vestedMethod := contractAbi.Methods["vestedAmount"]
+	getSchedule := abi.NewMethod("getSchedule", "getSchedule", scheduleMethod.Type, scheduleMethod.StateMutability, scheduleMethod.Constant, scheduleMethod.Payable, scheduleMethod.Inputs, scheduleMethod.Outputs)
+	releasable := abi.NewMethod("releasable", "releasable", vestedMethod.Type, vestedMethod.StateMutability, vestedMethod.Constant, vestedMethod.Payable, vestedMethod.Inputs, vestedMethod.Outputs)
+
+	scheduleOutput, err := scheduleMethod.Outputs.Pack(beneficiary, big.NewInt(100), big.NewInt(200), big.NewInt(300), big.NewInt(1000), big.NewInt(250), true, false)
+	require.NoError(t, err)
+	vestedOutput, err := vestedMethod.Outputs.Pack(big.NewInt(600))
+	require.NoError(t, err)
+
+	var getScheduleID, releasableID [4]byte
+	copy(getScheduleID[:], getSchedule.ID)
+	copy(releasableID[:], releasable.ID)
+	caller := &recordingCaller{outputs: map[[4]byte][]byte{
+		getScheduleID: scheduleOutput,
+		releasableID:  vestedOutput,
+	}}
+
+	vesting, err := contracts.NewTokenVesting(common.HexToAddress("0x5d6709ce17C956833B66aDe058832c1890aF19b7"), caller, map[string]string{
+		"getSchedule": "vestingSchedules",
+		"releasable":  "vestedAmount",
+	})
+	require.NoError(t, err)
+	client := &Client{vestingContract: vesting}
+
+	schedule, err := client.GetVestingSchedule(context.Background(), beneficiary)
+	require.NoError(t, err)
+	assert.Equal(t, beneficiary, schedule.Beneficiary)
+	assert.Equal(t, "1000", schedule.Amount.String())
+	assert.Equal(t, "250", schedule.Released.String())
+	assert.True(t, schedule.Revocable)
+
+	vested, err := client.GetVestedAmount(context.Background(), beneficiary)
+	require.NoError(t, err)
+	assert.Equal(t, "600", vested.String())
+
+	assert.Equal(t, [][4]byte{getScheduleID, releasableID}, caller.called)
+}
+
+func TestNewTokenVesting_InvalidMethodOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+	}{
+		{"Unknown internal method", map[string]string{"getSchedule": "schedules"}},
+		{"Collides with another ABI method", map[string]string{"vestedAmount": "vestingSchedules"}},
+		{"Internal method overridden twice", map[string]string{"getSchedule": "vestingSchedules", "scheduleOf": "vestingSchedules"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := contracts.NewTokenVesting(common.Address{}, &recordingCaller{}, tt.overrides)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewTokenVesting_MethodNameOverrides(t *testing.T) {
+	vesting, err := contracts.NewTokenVesting(common.Address{}, &recordingCaller{}, map[string]string{
+		"getSchedule": "vestingSchedules",
+		"releasable":  "vestedAmount",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "getSchedule", vesting.MethodName("vestingSchedules"))
+	assert.Equal(t, "releasable", vesting.MethodName("vestedAmount"))
+
+	vesting, err = contracts.NewTokenVesting(common.Address{}, &recordingCaller{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vestingSchedules", vesting.MethodName("vestingSchedules"))
+}
+
+func TestScheduleToken(t *testing.T) {
+	addressType, err := abi.NewType("address", "", nil)
+	require.NoError(t, err)
+	uintType, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+
+	t.Run("Indexed token argument", func(t *testing.T) {
+		client := newTestClient(t, nil)
+		event := abi.NewEvent("VestingScheduleCreated", "VestingScheduleCreated", false, abi.Arguments{
+			{Name: "beneficiary", Type: addressType, Indexed: true},
+			{Name: "token", Type: addressType, Indexed: true},
+			{Name: "amount", Type: uintType},
+		})
+
+		got := client.scheduleToken(event, types.Log{
+			Topics: []common.Hash{event.ID, common.BytesToHash(beneficiary.Bytes()), common.BytesToHash(token.Bytes())},
+		})
+		assert.Equal(t, token.Hex(), got)
+	})
+
+	t.Run("Non-indexed token argument", func(t *testing.T) {
+		client := newTestClient(t, nil)
+		event := abi.NewEvent("VestingScheduleCreated", "VestingScheduleCreated", false, abi.Arguments{
+			{Name: "beneficiary", Type: addressType, Indexed: true},
+			{Name: "token", Type: addressType},
+			{Name: "amount", Type: uintType},
+		})
+		data, err := event.Inputs.NonIndexed().Pack(token, big.NewInt(1))
+		require.NoError(t, err)
+
+		got := client.scheduleToken(event, types.Log{
+			Topics: []common.Hash{event.ID, common.BytesToHash(beneficiary.Bytes())},
+			Data:   data,
+		})
+		assert.Equal(t, token.Hex(), got)
+	})
+
+	t.Run("Falls back to configured token", func(t *testing.T) {
+		client := newTestClient(t, nil)
+		client.config = &config.Config{TokenAddress: "0x751f3c0af0ed18d9f70108cd0c4d878aa0de59a8"}
+
+		got := client.scheduleToken(client.contractAbi.Events["VestingScheduleCreated"], types.Log{})
+		assert.Equal(t, "0x751f3c0aF0Ed18d9F70108CD0c4d878Aa0De59A8", got)
+	})
+}
+
+func TestNewClient_DialTimeout(t *testing.T) {
+	// Accept connections but never respond, like a hung RPC node
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	cfg := &config.Config{
+		EthereumRPC:    "http://" + listener.Addr().String(),
+		RPCDialTimeout: 200 * time.Millisecond,
+	}
+
+	start := time.Now()
+	client, err := NewClient(cfg)
+
+	assert.Error(t, err)
+	assert.Nil(t, client)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+// headerRPCServer serves eth_getBlockByNumber, single or batched, with block
+// N timestamped at 1700000000 + 12*N, counting requests and batches
+func headerRPCServer(t *testing.T, requests, batches *int) *Client {
+	t.Helper()
+
+	type rpcRequest struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params []interface{}   `json:"params"`
+	}
+	respond := func(req rpcRequest) map[string]interface{} {
+		*requests++
+		require.Equal(t, "eth_getBlockByNumber", req.Method)
+		number, err := hexutil.DecodeUint64(req.Params[0].(string))
+		require.NoError(t, err)
+
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Time: 1700000000 + number*12, Difficulty: big.NewInt(0)}
+		return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": header}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			var batch []rpcRequest
+			require.NoError(t, json.Unmarshal(body, &batch))
+			*batches++
+
+			responses := make([]map[string]interface{}, len(batch))
+			for i, req := range batch {
+				responses[i] = respond(req)
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(responses))
+			return
+		}
+
+		var req rpcRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		require.NoError(t, json.NewEncoder(w).Encode(respond(req)))
+	}))
+	t.Cleanup(server.Close)
+
+	ethClient, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(ethClient.Close)
+
+	return &Client{ethClient: ethClient, node: &retryingBackend{rpcBackend: ethClient}, blockTimes: newBlockTimeCache(blockTimeCacheSize)}
+}
+
+func TestGetBlockTimestamps_BatchesHeaderRequests(t *testing.T) {
+	var requests, batches int
+	client := headerRPCServer(t, &requests, &batches)
+
+	blocks := make([]uint64, timestampBatchSize+5)
+	for i := range blocks {
+		blocks[i] = uint64(1000 + i)
+	}
+
+	timestamps, err := client.GetBlockTimestamps(context.Background(), blocks)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, batches)
+	assert.Equal(t, len(blocks), requests)
+	require.Len(t, timestamps, len(blocks))
+	assert.Equal(t, time.Unix(1700000000+1000*12, 0).UTC(), timestamps[1000])
+
+	// Cached blocks are not requested again
+	_, err = client.GetBlockTimestamps(context.Background(), blocks[:10])
+	require.NoError(t, err)
+	assert.Equal(t, 2, batches)
+}
+
+func TestGetBlockTimestamp_UsesHeaderTime(t *testing.T) {
+	var requests, batches int
+	client := headerRPCServer(t, &requests, &batches)
+
+	timestamp, err := client.GetBlockTimestamp(context.Background(), 500)
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1700000000+500*12, 0).UTC(), timestamp)
+	assert.Equal(t, 1, requests)
+
+	// A second event in the same block reuses the cached timestamp
+	_, err = client.GetBlockTimestamp(context.Background(), 500)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+// pollingBackend serves a chain whose head advances on each head read,
+// recording the block ranges logs are requested for
+type pollingBackend struct {
+	rpcBackend
+	heads  []uint64 // Head returned by successive reads; the last one repeats
+	logs   []types.Log
+	ranges [][2]uint64
+}
+
+func (p *pollingBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	head := p.heads[0]
+	if len(p.heads) > 1 {
+		p.heads = p.heads[1:]
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(head)}, nil
+}
+
+func (p *pollingBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	p.ranges = append(p.ranges, [2]uint64{from, to})
+
+	var logs []types.Log
+	for _, vLog := range p.logs {
+		if vLog.BlockNumber >= from && vLog.BlockNumber <= to {
+			logs = append(logs, vLog)
+		}
+	}
+	return logs, nil
+}
+
+func TestPollLogs_DeliversNewBlocksOnce(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	released := client.contractAbi.Events["TokensReleased"]
+	data, err := released.Inputs.NonIndexed().Pack(big.NewInt(100))
+	require.NoError(t, err)
+	releaseLog := func(block uint64) types.Log {
+		beneficiary := common.BigToAddress(new(big.Int).SetUint64(block))
+		return types.Log{Topics: []common.Hash{released.ID, common.BytesToHash(beneficiary.Bytes())}, Data: data, BlockNumber: block}
+	}
+
+	backend := &pollingBackend{
+		heads: []uint64{101, 101, 103, 105},
+		logs:  []types.Log{releaseLog(100), releaseLog(101), releaseLog(103), releaseLog(105)},
+	}
+	client.node = &retryingBackend{rpcBackend: backend}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChan := make(chan *ContractEvent)
+	done := make(chan struct{})
+	go func() {
+		client.pollLogs(ctx, 100, time.Millisecond, eventChan)
+		close(done)
+	}()
+
+	var blocks []uint64
+	for len(blocks) < 4 {
+		select {
+		case event := <-eventChan:
+			assert.Equal(t, "TokensReleased", event.EventType)
+			blocks = append(blocks, event.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatalf("polling stalled after blocks %v", blocks)
+		}
+	}
+	cancel()
+	<-done
+
+	assert.Equal(t, []uint64{100, 101, 103, 105}, blocks)
+	// An unchanged head is not queried again, and each block is requested once
+	assert.Equal(t, [][2]uint64{{100, 101}, {102, 103}, {104, 105}}, backend.ranges)
+}
+
+func TestWatchEvents_FallsBackToPollingOverHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	ethClient, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(ethClient.Close)
+	client := &Client{ethClient: ethClient, node: &retryingBackend{rpcBackend: ethClient}, subscriber: ethClient}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// HTTP cannot subscribe; the poller starts instead of WatchEvents failing
+	assert.NoError(t, client.WatchEvents(ctx, 100, make(chan *ContractEvent)))
+}
+
+// liveSubscriber opens subscriptions that, like eth_subscribe, only deliver
+// logs sent on them after they open
+type liveSubscriber struct {
+	logs chan<- types.Log
+}
+
+func (l *liveSubscriber) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	l.logs = ch
+	return &liveSubscription{err: make(chan error)}, nil
+}
+
+type liveSubscription struct {
+	err chan error
+}
+
+func (s *liveSubscription) Unsubscribe()      {}
+func (s *liveSubscription) Err() <-chan error { return s.err }
+
+func TestWatchEvents_BackfillsBlocksBeforeSubscription(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.config = &config.Config{ConfirmationBlocks: 2, LogPollInterval: time.Millisecond}
+
+	released := client.contractAbi.Events["TokensReleased"]
+	data, err := released.Inputs.NonIndexed().Pack(big.NewInt(100))
+	require.NoError(t, err)
+	releaseLog := func(block uint64) types.Log {
+		beneficiary := common.BigToAddress(new(big.Int).SetUint64(block))
+		return types.Log{Topics: []common.Hash{released.ID, common.BytesToHash(beneficiary.Bytes())}, Data: data, BlockNumber: block}
+	}
+
+	// Blocks 101 and 103 were mined before the subscription opened; the
+	// subscription itself never delivers them
+	backend := &pollingBackend{
+		heads: []uint64{103, 103, 105},
+		logs:  []types.Log{releaseLog(101), releaseLog(103)},
+	}
+	client.node = &retryingBackend{rpcBackend: backend}
+	client.subscriber = &liveSubscriber{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChan := make(chan *ContractEvent)
+	require.NoError(t, client.WatchEvents(ctx, 100, eventChan))
+
+	var blocks []uint64
+	for len(blocks) < 2 {
+		select {
+		case event := <-eventChan:
+			blocks = append(blocks, event.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatalf("backfilled events were not delivered, got blocks %v", blocks)
+		}
+	}
+
+	// Backfilled logs still wait for their confirmations
+	assert.Equal(t, []uint64{101, 103}, blocks)
+	assert.Equal(t, [][2]uint64{{100, 103}}, backend.ranges)
+}
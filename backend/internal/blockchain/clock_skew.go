@@ -0,0 +1,123 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// BlockTimeReader reads the timestamp of the chain head
+type BlockTimeReader interface {
+	GetLatestBlockTime(ctx context.Context) (time.Time, error)
+}
+
+// ClockSkewMonitor periodically compares the server clock with the latest
+// block time and warns when they drift apart by more than the tolerance.
+// Vesting math runs on the server clock, so a skewed clock misreports vested
+// amounts. When preferBlockTime is set, Now corrects the server clock by the
+// measured skew whenever it exceeds the tolerance.
+//
+// Block times trail wall time by up to one block interval, so the tolerance
+// should comfortably exceed the chain's block time.
+type ClockSkewMonitor struct {
+	chain           BlockTimeReader
+	maxSkew         time.Duration
+	interval        time.Duration
+	preferBlockTime bool
+
+	mu       sync.Mutex
+	skew     time.Duration // Server clock minus chain head time at the last check
+	measured bool
+}
+
+func NewClockSkewMonitor(chain BlockTimeReader, maxSkew, interval time.Duration, preferBlockTime bool) *ClockSkewMonitor {
+	return &ClockSkewMonitor{
+		chain:           chain,
+		maxSkew:         maxSkew,
+		interval:        interval,
+		preferBlockTime: preferBlockTime,
+	}
+}
+
+// Start checks the clock skew every interval until the context is cancelled
+func (m *ClockSkewMonitor) Start(ctx context.Context) {
+	log.Printf("🩺 Checking server clock against block time every %s (tolerance %s)", m.interval, m.maxSkew)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx, time.Now()); err != nil {
+			log.Printf("⚠️  Clock skew check failed: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Println("🛑 Stopping clock skew monitor")
+			return
+		}
+	}
+}
+
+// Check measures the skew between now and the latest block time, warning when
+// it exceeds the tolerance. A positive skew means the server clock is ahead.
+func (m *ClockSkewMonitor) Check(ctx context.Context, now time.Time) (time.Duration, error) {
+	blockTime, err := m.chain.GetLatestBlockTime(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read latest block time: %w", err)
+	}
+
+	skew := now.Sub(blockTime)
+	if m.exceeds(skew) {
+		direction := "ahead of"
+		if skew < 0 {
+			direction = "behind"
+		}
+		log.Printf("🚨 WARNING: server clock is %s %s the latest block time (tolerance %s); vesting amounts may be wrong", absDuration(skew), direction, m.maxSkew)
+	}
+
+	m.mu.Lock()
+	m.skew = skew
+	m.measured = true
+	m.mu.Unlock()
+
+	return skew, nil
+}
+
+// Skew returns the skew measured by the last successful check
+func (m *ClockSkewMonitor) Skew() (skew time.Duration, measured bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.skew, m.measured
+}
+
+// Now returns the reference time for vesting computations: the server clock,
+// corrected toward block time when preferred and the skew exceeds the tolerance
+func (m *ClockSkewMonitor) Now() time.Time {
+	now := time.Now()
+	if !m.preferBlockTime {
+		return now
+	}
+
+	skew, measured := m.Skew()
+	if measured && m.exceeds(skew) {
+		return now.Add(-skew)
+	}
+	return now
+}
+
+// exceeds reports whether a skew is beyond the tolerance
+func (m *ClockSkewMonitor) exceeds(skew time.Duration) bool {
+	return absDuration(skew) > m.maxSkew
+}
+
+// absDuration returns the magnitude of d
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBlockTime serves a scripted latest block time
+type fakeBlockTime struct {
+	at time.Time
+}
+
+func (f *fakeBlockTime) GetLatestBlockTime(ctx context.Context) (time.Time, error) {
+	return f.at, nil
+}
+
+func TestClockSkewMonitor_WarnsBeyondTolerance(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(original)
+
+	now := time.Unix(1700000000, 0)
+	chain := &fakeBlockTime{at: now.Add(-5 * time.Second)}
+	monitor := NewClockSkewMonitor(chain, 30*time.Second, time.Minute, false)
+
+	skew, err := monitor.Check(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, skew)
+	assert.NotContains(t, buf.String(), "WARNING")
+
+	// Server clock two minutes ahead of the chain
+	chain.at = now.Add(-2 * time.Minute)
+	skew, err = monitor.Check(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, skew)
+	assert.Contains(t, buf.String(), "WARNING: server clock is 2m0s ahead of the latest block time")
+
+	// And behind it
+	buf.Reset()
+	chain.at = now.Add(90 * time.Second)
+	_, err = monitor.Check(context.Background(), now)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "1m30s behind")
+}
+
+func TestClockSkewMonitor_Now(t *testing.T) {
+	chain := &fakeBlockTime{at: time.Now().Add(-time.Hour)}
+
+	t.Run("Server clock by default", func(t *testing.T) {
+		monitor := NewClockSkewMonitor(chain, time.Minute, time.Minute, false)
+		_, err := monitor.Check(context.Background(), time.Now())
+		require.NoError(t, err)
+
+		assert.WithinDuration(t, time.Now(), monitor.Now(), time.Second)
+	})
+
+	t.Run("Block time preferred when skewed", func(t *testing.T) {
+		monitor := NewClockSkewMonitor(chain, time.Minute, time.Minute, true)
+		assert.WithinDuration(t, time.Now(), monitor.Now(), time.Second, "no correction before the first check")
+
+		_, err := monitor.Check(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), monitor.Now(), time.Second)
+	})
+
+	t.Run("Skew within tolerance is not corrected", func(t *testing.T) {
+		monitor := NewClockSkewMonitor(&fakeBlockTime{at: time.Now().Add(-10 * time.Second)}, time.Minute, time.Minute, true)
+		_, err := monitor.Check(context.Background(), time.Now())
+		require.NoError(t, err)
+
+		assert.WithinDuration(t, time.Now(), monitor.Now(), time.Second)
+	})
+}
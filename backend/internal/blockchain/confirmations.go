@@ -0,0 +1,51 @@
+package blockchain
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// confirmationBuffer holds live logs until enough blocks are built on top of
+// them that a reorg is unlikely to drop them
+type confirmationBuffer struct {
+	confirmations uint64
+	pending       []types.Log // In arrival order
+}
+
+// add buffers a log. A removed log, delivered when a reorg drops its block,
+// withdraws the pending copy instead.
+func (b *confirmationBuffer) add(vLog types.Log) {
+	if !vLog.Removed {
+		b.pending = append(b.pending, vLog)
+		return
+	}
+
+	kept := b.pending[:0]
+	for _, pending := range b.pending {
+		if pending.TxHash != vLog.TxHash || pending.Index != vLog.Index {
+			kept = append(kept, pending)
+		}
+	}
+	b.pending = kept
+}
+
+// release removes and returns the logs confirmed at the given head, in
+// arrival order
+func (b *confirmationBuffer) release(head uint64) []types.Log {
+	var confirmed []types.Log
+	kept := b.pending[:0]
+	for _, vLog := range b.pending {
+		if confirmedAt(vLog.BlockNumber, head, b.confirmations) {
+			confirmed = append(confirmed, vLog)
+		} else {
+			kept = append(kept, vLog)
+		}
+	}
+	b.pending = kept
+	return confirmed
+}
+
+// confirmedAt reports whether a block has the given number of confirmations
+// once the chain reaches head
+func confirmedAt(block, head, confirmations uint64) bool {
+	return head >= block && head-block >= confirmations
+}
@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+)
+
+func TestConfirmationBuffer(t *testing.T) {
+	buffer := &confirmationBuffer{confirmations: 3}
+	logAt := func(block uint64, tx byte) types.Log {
+		return types.Log{BlockNumber: block, TxHash: common.Hash{tx}}
+	}
+
+	buffer.add(logAt(100, 1))
+	buffer.add(logAt(101, 2))
+	buffer.add(logAt(102, 3))
+
+	// The chain tip has no confirmations yet
+	assert.Empty(t, buffer.release(100))
+	assert.Empty(t, buffer.release(102))
+	assert.Equal(t, []types.Log{logAt(100, 1)}, buffer.release(103))
+
+	// A reorg drops block 101, withdrawing its log
+	removed := logAt(101, 2)
+	removed.Removed = true
+	buffer.add(removed)
+
+	assert.Equal(t, []types.Log{logAt(102, 3)}, buffer.release(110))
+	assert.Empty(t, buffer.pending)
+}
+
+func TestPollLogs_WithholdsUnconfirmedEvents(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.config = &config.Config{ConfirmationBlocks: 2}
+
+	released := client.contractAbi.Events["TokensReleased"]
+	data, err := released.Inputs.NonIndexed().Pack(big.NewInt(100))
+	require.NoError(t, err)
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+
+	// The event is mined at the tip; two more blocks arrive on later polls
+	backend := &pollingBackend{
+		heads: []uint64{100, 100, 101, 102},
+		logs:  []types.Log{{Topics: []common.Hash{released.ID, common.BytesToHash(beneficiary.Bytes())}, Data: data, BlockNumber: 100}},
+	}
+	client.node = &retryingBackend{rpcBackend: backend}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChan := make(chan *ContractEvent)
+	done := make(chan struct{})
+	go func() {
+		client.pollLogs(ctx, 100, time.Millisecond, eventChan)
+		close(done)
+	}()
+
+	select {
+	case event := <-eventChan:
+		assert.Equal(t, uint64(100), event.BlockNumber)
+	case <-time.After(time.Second):
+		t.Fatal("confirmed event was not delivered")
+	}
+	cancel()
+	<-done
+
+	// Logs were only requested once block 100 had two confirmations
+	assert.Equal(t, [][2]uint64{{100, 100}}, backend.ranges)
+}
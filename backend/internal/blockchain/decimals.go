@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DecimalsReader reads a token's decimals from chain
+type DecimalsReader interface {
+	GetTokenDecimals(ctx context.Context, token common.Address) (uint8, error)
+}
+
+// DecimalsCache resolves decimals per token address, reading each token from
+// chain once. Schedules without a token use the default token, and tokens whose
+// decimals cannot be read fall back to the configured default.
+type DecimalsCache struct {
+	reader       DecimalsReader
+	defaultToken string
+	fallback     uint8
+
+	mu       sync.RWMutex
+	decimals map[common.Address]uint8
+}
+
+func NewDecimalsCache(reader DecimalsReader, defaultToken string, fallback uint8) *DecimalsCache {
+	return &DecimalsCache{
+		reader:       reader,
+		defaultToken: defaultToken,
+		fallback:     fallback,
+		decimals:     make(map[common.Address]uint8),
+	}
+}
+
+// Decimals returns the decimals for a token address
+func (d *DecimalsCache) Decimals(token string) uint8 {
+	if token == "" {
+		token = d.defaultToken
+	}
+	if !common.IsHexAddress(token) {
+		return d.fallback
+	}
+	address := common.HexToAddress(token)
+
+	d.mu.RLock()
+	decimals, ok := d.decimals[address]
+	d.mu.RUnlock()
+	if ok {
+		return decimals
+	}
+
+	// Failures are not cached so a transient RPC error is retried on the next request
+	decimals, err := d.reader.GetTokenDecimals(context.Background(), address)
+	if err != nil {
+		log.Printf("⚠️  Could not read decimals for token %s, using %d: %v", address.Hex(), d.fallback, err)
+		return d.fallback
+	}
+
+	d.mu.Lock()
+	d.decimals[address] = decimals
+	d.mu.Unlock()
+	return decimals
+}
@@ -0,0 +1,53 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockDecimalsReader serves decimals per token and counts reads
+type mockDecimalsReader struct {
+	decimals map[common.Address]uint8
+	reads    int
+}
+
+func (m *mockDecimalsReader) GetTokenDecimals(ctx context.Context, token common.Address) (uint8, error) {
+	m.reads++
+	decimals, ok := m.decimals[token]
+	if !ok {
+		return 0, errors.New("execution reverted")
+	}
+	return decimals, nil
+}
+
+func TestDecimalsCache(t *testing.T) {
+	usdc := "0x1111111111111111111111111111111111111111"
+	vest := "0x2222222222222222222222222222222222222222"
+	reader := &mockDecimalsReader{decimals: map[common.Address]uint8{
+		common.HexToAddress(usdc): 6,
+		common.HexToAddress(vest): 18,
+	}}
+	cache := NewDecimalsCache(reader, vest, 18)
+
+	assert.Equal(t, uint8(6), cache.Decimals(usdc))
+	assert.Equal(t, uint8(18), cache.Decimals(vest))
+	assert.Equal(t, 2, reader.reads)
+
+	// Cached after the first read
+	assert.Equal(t, uint8(6), cache.Decimals("0x1111111111111111111111111111111111111111"))
+	assert.Equal(t, 2, reader.reads)
+
+	// Schedules without a token use the default token
+	assert.Equal(t, uint8(18), cache.Decimals(""))
+	assert.Equal(t, 2, reader.reads)
+
+	// Unreadable tokens fall back and are retried next time
+	unknown := "0x3333333333333333333333333333333333333333"
+	assert.Equal(t, uint8(18), cache.Decimals(unknown))
+	assert.Equal(t, uint8(18), cache.Decimals(unknown))
+	assert.Equal(t, 4, reader.reads)
+}
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// eventHubBuffer is the number of events queued per subscriber. A subscriber
+// that falls this far behind is disconnected rather than silently missing events.
+const eventHubBuffer = 64
+
+// EventHub fans recorded events out to in-process subscribers, keyed by
+// beneficiary. It backs live event streams to API clients.
+type EventHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan models.VestingEvent]struct{}
+}
+
+// NewEventHub creates an event hub with no subscribers
+func NewEventHub() *EventHub {
+	return &EventHub{subscribers: make(map[string]map[chan models.VestingEvent]struct{})}
+}
+
+// Subscribe registers for the events of a beneficiary. The returned channel is
+// closed when unsubscribe is called or the subscriber falls too far behind.
+// Unsubscribe may be called more than once.
+func (h *EventHub) Subscribe(beneficiary string) (<-chan models.VestingEvent, func()) {
+	key := strings.ToLower(beneficiary)
+	events := make(chan models.VestingEvent, eventHubBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[key] == nil {
+		h.subscribers[key] = make(map[chan models.VestingEvent]struct{})
+	}
+	h.subscribers[key][events] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(key, events)
+	}
+	return events, unsubscribe
+}
+
+// Publish delivers an event to every subscriber of its beneficiary without
+// blocking. Subscribers whose queue is full are disconnected.
+func (h *EventHub) Publish(event models.VestingEvent) {
+	key := strings.ToLower(event.Beneficiary)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for events := range h.subscribers[key] {
+		select {
+		case events <- event:
+		default:
+			log.Printf("⚠️  Disconnecting slow event stream subscriber for %s", event.Beneficiary)
+			h.remove(key, events)
+		}
+	}
+}
+
+// Subscribers returns the number of subscribers of a beneficiary
+func (h *EventHub) Subscribers(beneficiary string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[strings.ToLower(beneficiary)])
+}
+
+// remove closes and forgets a subscriber channel. Callers must hold mu.
+func (h *EventHub) remove(key string, events chan models.VestingEvent) {
+	subscribers := h.subscribers[key]
+	if _, ok := subscribers[events]; !ok {
+		return
+	}
+	delete(subscribers, events)
+	close(events)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, key)
+	}
+}
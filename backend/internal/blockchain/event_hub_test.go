@@ -0,0 +1,55 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestEventHub_FansOutByBeneficiary(t *testing.T) {
+	hub := NewEventHub()
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+
+	first, unsubscribeFirst := hub.Subscribe(alice)
+	second, unsubscribeSecond := hub.Subscribe(alice)
+	other, unsubscribeOther := hub.Subscribe(bob)
+	defer unsubscribeSecond()
+	defer unsubscribeOther()
+
+	// Keys match regardless of address case
+	hub.Publish(models.VestingEvent{ID: 1, Beneficiary: "0xf25da65784d566ffcc60a1f113650afb688a14ed"})
+
+	assert.Equal(t, uint(1), (<-first).ID)
+	assert.Equal(t, uint(1), (<-second).ID)
+	assert.Empty(t, other)
+
+	// Unsubscribing closes the channel and is safe to repeat
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, open := <-first
+	assert.False(t, open)
+	assert.Equal(t, 1, hub.Subscribers(alice))
+}
+
+func TestEventHub_DisconnectsSlowSubscribers(t *testing.T) {
+	hub := NewEventHub()
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	events, unsubscribe := hub.Subscribe(beneficiary)
+	defer unsubscribe()
+
+	// Publishing never blocks; the subscriber is dropped once its queue is full
+	for i := 0; i <= eventHubBuffer; i++ {
+		hub.Publish(models.VestingEvent{ID: uint(i + 1), Beneficiary: beneficiary})
+	}
+	assert.Equal(t, 0, hub.Subscribers(beneficiary))
+
+	received := 0
+	for range events {
+		received++
+	}
+	require.Equal(t, eventHubBuffer, received)
+}
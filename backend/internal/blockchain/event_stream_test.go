@@ -0,0 +1,93 @@
+package blockchain_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/api"
+	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestEventStream_PushesHandledEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	db := blockchain.SetupTestDB(t)
+	hub := blockchain.NewEventHub()
+	listener := blockchain.NewEventListener(nil, db, nil)
+	listener.SetEventHub(hub)
+
+	handler := api.NewHandler(db, nil, nil)
+	handler.SetEventStream(hub)
+	server := httptest.NewServer(api.SetupRouter(handler, &config.Config{AccessLogMode: api.AccessLogOff}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/events/"+strings.ToLower(beneficiary)+"/stream", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Eventually(t, func() bool { return hub.Subscribers(beneficiary) == 1 }, time.Second, 5*time.Millisecond)
+
+	// Events of other beneficiaries are not streamed
+	require.NoError(t, listener.HandleEvent(&blockchain.ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea",
+		Amount:          "500",
+		BlockNumber:     9,
+		TransactionHash: "0xother",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+	}))
+	require.NoError(t, listener.HandleEvent(&blockchain.ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xcreate",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+	}))
+
+	// Read one SSE frame: header lines up to the blank separator
+	frame := map[string]string{}
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			break
+		}
+		name, value, _ := strings.Cut(line, ": ")
+		frame[name] = value
+	}
+
+	assert.Equal(t, "VestingScheduleCreated", frame["event"])
+	var event models.VestingEvent
+	require.NoError(t, json.Unmarshal([]byte(frame["data"]), &event))
+	assert.Equal(t, beneficiary, event.Beneficiary)
+	assert.Equal(t, "1000", event.Amount)
+	assert.Equal(t, uint64(10), event.BlockNumber)
+	assert.Equal(t, strconv.FormatUint(uint64(event.ID), 10), frame["id"])
+
+	// Disconnecting the client ends the subscription
+	cancel()
+	assert.Eventually(t, func() bool { return hub.Subscribers(beneficiary) == 0 }, time.Second, 5*time.Millisecond)
+}
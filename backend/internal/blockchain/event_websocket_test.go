@@ -0,0 +1,119 @@
+package blockchain_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/api"
+	"github.com/kaldun-tech/token-vesting-backend/internal/blockchain"
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// wsFrame mirrors the frames the WebSocket endpoint sends
+type wsFrame struct {
+	Type      string               `json:"type"`
+	Addresses []string             `json:"addresses"`
+	Event     *models.VestingEvent `json:"event"`
+	Error     string               `json:"error"`
+}
+
+func TestEventWebSocket_DeliversToSubscribedConnection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	db := blockchain.SetupTestDB(t)
+	hub := blockchain.NewEventHub()
+	listener := blockchain.NewEventListener(nil, db, nil)
+	listener.SetEventHub(hub)
+
+	handler := api.NewHandler(db, nil, nil)
+	handler.SetEventStream(hub)
+	server := httptest.NewServer(api.SetupRouter(handler, &config.Config{AccessLogMode: api.AccessLogOff}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/ws"
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	read := func(conn *websocket.Conn) wsFrame {
+		var frame wsFrame
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		require.NoError(t, conn.ReadJSON(&frame))
+		return frame
+	}
+	created := func(beneficiary, txHash string, block uint64) *blockchain.ContractEvent {
+		return &blockchain.ContractEvent{
+			EventType:       "VestingScheduleCreated",
+			Beneficiary:     beneficiary,
+			Amount:          "1000",
+			BlockNumber:     block,
+			TransactionHash: txHash,
+			Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+		}
+	}
+
+	watcher := dial()
+	bystander := dial()
+
+	// One connection watches both beneficiaries, the other only bob
+	require.NoError(t, watcher.WriteJSON(map[string]interface{}{"action": "subscribe", "addresses": []string{strings.ToLower(alice), bob}}))
+	frame := read(watcher)
+	assert.Equal(t, "subscribed", frame.Type)
+	assert.Equal(t, []string{alice, bob}, frame.Addresses)
+
+	require.NoError(t, bystander.WriteJSON(map[string]interface{}{"action": "subscribe", "addresses": []string{bob}}))
+	assert.Equal(t, "subscribed", read(bystander).Type)
+
+	require.NoError(t, listener.HandleEvent(created(alice, "0xalice", 10)))
+	frame = read(watcher)
+	require.Equal(t, "event", frame.Type)
+	require.NotNil(t, frame.Event)
+	assert.Equal(t, alice, frame.Event.Beneficiary)
+	assert.Equal(t, uint64(10), frame.Event.BlockNumber)
+
+	// The bystander only sees bob's event, not alice's
+	require.NoError(t, listener.HandleEvent(created(bob, "0xbob", 11)))
+	frame = read(bystander)
+	require.Equal(t, "event", frame.Type)
+	assert.Equal(t, bob, frame.Event.Beneficiary)
+	assert.Equal(t, bob, read(watcher).Event.Beneficiary)
+
+	// Unsubscribing stops delivery for that address only
+	require.NoError(t, watcher.WriteJSON(map[string]interface{}{"action": "unsubscribe", "addresses": []string{alice}}))
+	assert.Equal(t, "unsubscribed", read(watcher).Type)
+	assert.Equal(t, 0, hub.Subscribers(alice))
+	assert.Equal(t, 2, hub.Subscribers(bob))
+
+	// Invalid control messages get an error frame and keep the connection open
+	require.NoError(t, watcher.WriteJSON(map[string]interface{}{"action": "subscribe", "addresses": []string{"not-an-address"}}))
+	assert.Equal(t, "error", read(watcher).Type)
+
+	// Pings are answered while the connection is open
+	pong := make(chan struct{}, 1)
+	watcher.SetPongHandler(func(string) error { pong <- struct{}{}; return nil })
+	require.NoError(t, watcher.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)))
+	go func() { _, _, _ = watcher.ReadMessage() }()
+	select {
+	case <-pong:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no pong received")
+	}
+
+	// A graceful close drops the connection's subscriptions
+	require.NoError(t, bystander.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+	_, _, err := bystander.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "expected a normal close, got %v", err)
+	assert.Eventually(t, func() bool { return hub.Subscribers(bob) == 1 }, time.Second, 5*time.Millisecond)
+}
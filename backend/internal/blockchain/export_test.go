@@ -0,0 +1,13 @@
+package blockchain
+
+import "context"
+
+// Exports for the external blockchain_test package
+
+// HandleEvent exposes handleEvent to external tests
+func (el *EventListener) HandleEvent(event *ContractEvent) error {
+	return el.handleEvent(context.Background(), event)
+}
+
+// SetupTestDB exposes setupTestDB to external tests
+var SetupTestDB = setupTestDB
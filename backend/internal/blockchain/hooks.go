@@ -0,0 +1,56 @@
+package blockchain
+
+import (
+	"fmt"
+	"log"
+)
+
+// PreHandleHook runs before an event is persisted. It receives a copy of the
+// event, so it can observe but not alter what is stored.
+type PreHandleHook func(event ContractEvent) error
+
+// PostHandleHook runs after an event has been handled, with the handling
+// error (nil when the event was persisted and applied)
+type PostHandleHook func(event ContractEvent, handleErr error) error
+
+// AddPreHandleHook registers a hook run before each event is handled. Hooks
+// must be registered before Start.
+func (el *EventListener) AddPreHandleHook(hook PreHandleHook) {
+	el.preHandleHooks = append(el.preHandleHooks, hook)
+}
+
+// AddPostHandleHook registers a hook run after each event is handled. Hooks
+// must be registered before Start.
+func (el *EventListener) AddPostHandleHook(hook PostHandleHook) {
+	el.postHandleHooks = append(el.postHandleHooks, hook)
+}
+
+// runPreHandleHooks invokes the pre-handle hooks in registration order
+func (el *EventListener) runPreHandleHooks(event *ContractEvent) {
+	for _, hook := range el.preHandleHooks {
+		runHook("pre-handle", event, func() error { return hook(*event) })
+	}
+}
+
+// runPostHandleHooks invokes the post-handle hooks in registration order
+func (el *EventListener) runPostHandleHooks(event *ContractEvent, handleErr error) {
+	for _, hook := range el.postHandleHooks {
+		runHook("post-handle", event, func() error { return hook(*event, handleErr) })
+	}
+}
+
+// runHook calls a hook, logging its error or panic instead of letting it
+// interfere with event processing
+func runHook(stage string, event *ContractEvent, call func() error) {
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return call()
+	}()
+	if err != nil {
+		log.Printf("⚠️  %s hook failed for %s event in tx %s: %v", stage, event.EventType, event.TransactionHash, err)
+	}
+}
@@ -0,0 +1,95 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+)
+
+func TestHooks_ObserveProcessedEvents(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	created := &ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xcreate",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+	}
+	released := &ContractEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     beneficiary,
+		Amount:          "100",
+		BlockNumber:     11,
+		TransactionHash: "0xrelease",
+	}
+	orphaned := &ContractEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea",
+		Amount:          "100",
+		BlockNumber:     12,
+		TransactionHash: "0xorphan",
+	}
+
+	type outcome struct {
+		txHash string
+		failed bool
+	}
+
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, nil)
+
+	var pre []string
+	var post []outcome
+	el.AddPreHandleHook(func(event ContractEvent) error {
+		pre = append(pre, event.TransactionHash)
+		return nil
+	})
+	el.AddPostHandleHook(func(event ContractEvent, handleErr error) error {
+		post = append(post, outcome{event.TransactionHash, handleErr != nil})
+		return nil
+	})
+
+	require.NoError(t, el.processEvent(context.Background(), created))
+	require.NoError(t, el.processEvent(context.Background(), released))
+	assert.Error(t, el.processEvent(context.Background(), orphaned))
+
+	assert.Equal(t, []string{"0xcreate", "0xrelease", "0xorphan"}, pre)
+	assert.Equal(t, []outcome{{"0xcreate", false}, {"0xrelease", false}, {"0xorphan", true}}, post)
+}
+
+func TestHooks_FailuresDoNotAffectProcessing(t *testing.T) {
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, nil)
+
+	el.AddPreHandleHook(func(event ContractEvent) error {
+		event.Beneficiary = "0x0000000000000000000000000000000000000001"
+		panic("hook bug")
+	})
+	el.AddPostHandleHook(func(event ContractEvent, handleErr error) error {
+		return errors.New("sink unavailable")
+	})
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	require.NoError(t, el.processEvent(context.Background(), &ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xcreate",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+	}))
+
+	schedule, err := db.GetScheduleByBeneficiary(beneficiary)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", schedule.Amount)
+
+	events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
@@ -0,0 +1,75 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// Initial historical sync states
+const (
+	InitialSyncPending  = "pending"
+	InitialSyncRunning  = "syncing"
+	InitialSyncComplete = "complete"
+	InitialSyncFailed   = "failed"
+)
+
+// InitialSyncStatus reports the outcome of the historical sync run at startup
+type InitialSyncStatus struct {
+	State    string `json:"state"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Ready reports whether historical events were fully indexed
+func (s InitialSyncStatus) Ready() bool {
+	return s.State == InitialSyncComplete
+}
+
+// InitialSync reports the state of the startup historical sync
+func (el *EventListener) InitialSync() InitialSyncStatus {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	return el.initialSync
+}
+
+func (el *EventListener) setInitialSync(status InitialSyncStatus) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.initialSync = status
+}
+
+// syncHistoricalWithRetry runs the historical sync, retrying the whole sync
+// with exponential backoff. Each attempt resumes from the last processed block.
+// A start block beyond the chain head is a configuration error and is not retried.
+func (el *EventListener) syncHistoricalWithRetry(ctx context.Context, startBlock uint64) error {
+	retries, backoff := 0, time.Duration(0)
+	if el.config != nil {
+		retries, backoff = el.config.HistoricalSyncRetries, el.config.HistoricalSyncBackoff
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		el.setInitialSync(InitialSyncStatus{State: InitialSyncRunning, Attempts: attempt})
+
+		err = el.syncHistoricalEvents(ctx, startBlock)
+		if err == nil {
+			el.setInitialSync(InitialSyncStatus{State: InitialSyncComplete, Attempts: attempt})
+			return nil
+		}
+		if errors.Is(err, ErrStartBlockAheadOfHead) || attempt > retries {
+			el.setInitialSync(InitialSyncStatus{State: InitialSyncFailed, Attempts: attempt, Error: err.Error()})
+			return err
+		}
+
+		log.Printf("⚠️  Historical sync attempt %d/%d failed, retrying in %s: %v", attempt, retries+1, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			el.setInitialSync(InitialSyncStatus{State: InitialSyncFailed, Attempts: attempt, Error: err.Error()})
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
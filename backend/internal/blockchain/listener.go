@@ -2,55 +2,289 @@ package blockchain
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
 	"github.com/kaldun-tech/token-vesting-backend/internal/database"
 	"github.com/kaldun-tech/token-vesting-backend/internal/models"
 )
 
+// retryInterval is how often events that failed to persist are retried
+const retryInterval = 30 * time.Second
+
+// publishRetryBatch is the number of unpublished events republished per retry
+const publishRetryBatch = 100
+
+// maxRetryAttempts is how many times an event is processed, the first attempt
+// included, before it is moved to the dead-letter table
+const maxRetryAttempts = 10
+
+// gasUsedTimeout bounds the receipt lookup, retries included, made for an event
+const gasUsedTimeout = 10 * time.Second
+
+// Policies for a configured START_BLOCK beyond the chain head
+const (
+	StartBlockAheadWarn = "warn"
+	StartBlockAheadFail = "fail"
+)
+
+// Policies for TokensReleased events that follow a schedule's revocation
+const (
+	// RevokedReleaseApply adds such releases to the released amount
+	RevokedReleaseApply = "apply"
+	// RevokedReleaseFreeze records such releases as events but leaves the
+	// released amount as it stood at revocation
+	RevokedReleaseFreeze = "freeze"
+)
+
+// ErrStartBlockAheadOfHead indicates START_BLOCK is beyond the current chain head,
+// usually because the RPC endpoint points at the wrong network
+var ErrStartBlockAheadOfHead = errors.New("start block is ahead of chain head")
+
+// ChainSource is the subset of the blockchain client used by the listener
+type ChainSource interface {
+	ScheduleReader
+	GetLatestBlockNumber(ctx context.Context) (uint64, error)
+	FetchHistoricalEvents(ctx context.Context, fromBlock, toBlock uint64) ([]*ContractEvent, error)
+	WatchEvents(ctx context.Context, startBlock uint64, eventChan chan<- *ContractEvent) error
+	GetGasUsed(ctx context.Context, txHash string) (uint64, error)
+	FindDeploymentBlock(ctx context.Context) (uint64, error)
+	GetBlockTimestamp(ctx context.Context, block uint64) (time.Time, error)
+	GetBlockTimestamps(ctx context.Context, blocks []uint64) (map[uint64]time.Time, error)
+}
+
 type EventListener struct {
-	client *Client
-	db     *database.Database
+	client    ChainSource
+	db        *database.Database
+	config    *config.Config
+	eventChan chan *ContractEvent
+	publisher EventPublisher
+	hub       *EventHub        // Optional; streams recorded events to API clients
+	webhook   *WebhookNotifier // Optional; POSTs recorded events to an operator endpoint
+
+	// allowlist limits indexing to these beneficiaries (checksummed); nil indexes all
+	allowlist map[string]bool
+
+	// Custom logic run around each handled event
+	preHandleHooks  []PreHandleHook
+	postHandleHooks []PostHandleHook
+
+	throughput throughputCounter
+
+	// mu guards the retry queue and sync checkpoint, and makes backlog snapshots consistent
+	mu          sync.Mutex
+	retryQueue  []*failedEvent
+	initialSync InitialSyncStatus
+	// processedBlock is the highest block the checkpoint should reach once
+	// no event at or before it awaits retry
+	processedBlock uint64
+	// syncCheckpoint is the last event processed by the historical sync; live
+	// events at or before it were already handled
+	syncCheckpoint *eventPosition
+
+	// liveRange is the coverage record extended as live events are processed
+	liveRange *models.SyncRange
+
+	// processing tracks the live event processor started by Start
+	processing sync.WaitGroup
+}
+
+// failedEvent is an event awaiting retry
+type failedEvent struct {
+	event    *ContractEvent
+	attempts int   // Processing attempts made so far
+	err      error // Error from the latest attempt
+}
+
+// eventPosition locates an event on chain
+type eventPosition struct {
+	block    uint64
+	logIndex uint
+}
+
+// positionOf returns an event's position on chain
+func positionOf(event *ContractEvent) eventPosition {
+	return eventPosition{block: event.BlockNumber, logIndex: event.LogIndex}
 }
 
-func NewEventListener(client *Client, db *database.Database) *EventListener {
+// after reports whether p comes later on chain than other
+func (p eventPosition) after(other eventPosition) bool {
+	if p.block != other.block {
+		return p.block > other.block
+	}
+	return p.logIndex > other.logIndex
+}
+
+func NewEventListener(client ChainSource, db *database.Database, cfg *config.Config) *EventListener {
 	return &EventListener{
-		client: client,
-		db:     db,
+		client:      client,
+		db:          db,
+		config:      cfg,
+		eventChan:   make(chan *ContractEvent, 100),
+		publisher:   NoopPublisher{},
+		initialSync: InitialSyncStatus{State: InitialSyncPending},
+	}
+}
+
+// Throughput reports how many events were processed over recent windows
+func (el *EventListener) Throughput() []ThroughputWindow {
+	return el.throughput.windows(time.Now())
+}
+
+// SetPublisher sets where processed events are published
+func (el *EventListener) SetPublisher(publisher EventPublisher) {
+	el.publisher = publisher
+}
+
+// SetEventHub sets the in-process hub newly recorded events are fanned out to
+func (el *EventListener) SetEventHub(hub *EventHub) {
+	el.hub = hub
+}
+
+// SetWebhookNotifier sets the webhook newly recorded events are delivered to
+func (el *EventListener) SetWebhookNotifier(webhook *WebhookNotifier) {
+	el.webhook = webhook
+}
+
+// SetBeneficiaryAllowlist limits indexing to the given beneficiaries. Events of
+// other beneficiaries are skipped without being persisted or published. An
+// empty list indexes every beneficiary.
+func (el *EventListener) SetBeneficiaryAllowlist(beneficiaries []string) error {
+	if len(beneficiaries) == 0 {
+		el.allowlist = nil
+		return nil
+	}
+
+	allowlist := make(map[string]bool, len(beneficiaries))
+	for _, beneficiary := range beneficiaries {
+		if !common.IsHexAddress(beneficiary) {
+			return fmt.Errorf("invalid beneficiary address %q", beneficiary)
+		}
+		allowlist[common.HexToAddress(beneficiary).Hex()] = true
 	}
+	el.allowlist = allowlist
+	return nil
+}
+
+// indexes reports whether events of a beneficiary are indexed
+func (el *EventListener) indexes(beneficiary string) bool {
+	return el.allowlist == nil || el.allowlist[common.HexToAddress(beneficiary).Hex()]
+}
+
+// Backlog reports the number of events buffered in the listener channel, the
+// number of events awaiting retry, and the number of recorded events awaiting
+// publication
+func (el *EventListener) Backlog() (buffered, retrying, unpublished int) {
+	if el.db != nil {
+		count, err := el.db.CountOutboxEvents()
+		if err != nil {
+			log.Printf("⚠️  Failed to count unpublished events: %v", err)
+		}
+		unpublished = int(count)
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	return len(el.eventChan), len(el.retryQueue), unpublished
 }
 
 // Start begins listening for events
 func (el *EventListener) Start(ctx context.Context, startBlock uint64) error {
+	startBlock = el.resolveDeploymentBlock(ctx, startBlock)
+
 	// First, sync historical events
-	if err := el.syncHistoricalEvents(ctx, startBlock); err != nil {
-		log.Printf("⚠️  Warning: Failed to sync historical events: %v", err)
+	if err := el.syncHistoricalWithRetry(ctx, startBlock); err != nil {
+		if errors.Is(err, ErrStartBlockAheadOfHead) {
+			return err
+		}
+		log.Printf("❌ Historical sync failed, continuing with live events only; history may have gaps: %v", err)
 	}
 
-	// Then start watching for new events
-	eventChan := make(chan *ContractEvent, 100)
-
+	// Then start watching for new events from where the sync stopped
 	latestBlock, err := el.client.GetLatestBlockNumber(ctx)
 	if err != nil {
 		return err
 	}
+	watchFrom := el.confirmedHead(latestBlock)
 
-	if err := el.client.WatchEvents(ctx, latestBlock, eventChan); err != nil {
+	if err := el.client.WatchEvents(ctx, watchFrom, el.eventChan); err != nil {
 		return err
 	}
+	el.liveRange = el.recordScannedRange(watchFrom, watchFrom, models.SyncSourceLive)
 
 	// Process events as they come in
-	go el.processEvents(ctx, eventChan)
+	el.processing.Add(1)
+	go func() {
+		defer el.processing.Done()
+		el.processEvents(ctx, el.eventChan)
+	}()
 
 	return nil
 }
 
+// Wait blocks until the live event processor started by Start has stopped,
+// which happens once Start's context is cancelled. It returns immediately if
+// Start never got as far as processing live events.
+func (el *EventListener) Wait() {
+	el.processing.Wait()
+}
+
+// resolveDeploymentBlock returns the block syncing starts from when nothing has
+// been processed yet. A configured START_BLOCK wins and is persisted; otherwise
+// the persisted deployment block is reused, and only on the first cold start is
+// it detected from chain. Detection failures fall back to the configured block.
+func (el *EventListener) resolveDeploymentBlock(ctx context.Context, configured uint64) uint64 {
+	contract := el.contractAddress()
+
+	if configured > 0 {
+		if err := el.db.SaveDeploymentBlock(contract, configured); err != nil {
+			log.Printf("⚠️  Failed to persist deployment block: %v", err)
+		}
+		return configured
+	}
+
+	stored, found, err := el.db.GetDeploymentBlock(contract)
+	if err != nil {
+		log.Printf("⚠️  Could not read persisted deployment block: %v", err)
+	} else if found {
+		log.Printf("📌 Using persisted deployment block %d", stored)
+		return stored
+	}
+
+	detected, err := el.client.FindDeploymentBlock(ctx)
+	if err != nil {
+		log.Printf("⚠️  Could not detect deployment block, syncing from block %d: %v", configured, err)
+		return configured
+	}
+
+	log.Printf("📌 Detected contract deployment at block %d", detected)
+	if err := el.db.SaveDeploymentBlock(contract, detected); err != nil {
+		log.Printf("⚠️  Failed to persist deployment block: %v", err)
+	}
+	return detected
+}
+
+// contractAddress is the vesting contract address checkpoints are keyed by
+func (el *EventListener) contractAddress() string {
+	if el.config == nil {
+		return ""
+	}
+	return common.HexToAddress(el.config.TokenVestingAddress).Hex()
+}
+
 // syncHistoricalEvents fetches and processes past events
 func (el *EventListener) syncHistoricalEvents(ctx context.Context, startBlock uint64) error {
 	log.Println("📜 Syncing historical events...")
+	configuredStart := startBlock
 
 	// Get the last processed block from database
 	lastProcessed, err := el.db.GetLastProcessedBlock()
@@ -68,6 +302,12 @@ func (el *EventListener) syncHistoricalEvents(ctx context.Context, startBlock ui
 		return err
 	}
 
+	if err := el.checkStartBlock(configuredStart, latestBlock); err != nil {
+		return err
+	}
+	// Blocks that could still be reorged away are left to the live watcher
+	latestBlock = el.confirmedHead(latestBlock)
+
 	if startBlock >= latestBlock {
 		log.Println("✅ Already up to date")
 		return nil
@@ -77,37 +317,125 @@ func (el *EventListener) syncHistoricalEvents(ctx context.Context, startBlock ui
 
 	// Fetch and process historical events in batches
 	if err := el.fetchAndProcessHistoricalEvents(ctx, startBlock, latestBlock); err != nil {
-		log.Printf("❌ Failed to fetch and process historical events: %v", err)
+		return err
 	}
 
 	log.Println("✅ Historical sync complete")
 	return nil
 }
 
-// fetchAndProcessHistoricalEvents fetches and processes historical events in batches
+// confirmedHead returns the newest block with CONFIRMATION_BLOCKS blocks built
+// on top of it
+func (el *EventListener) confirmedHead(head uint64) uint64 {
+	if el.config == nil {
+		return head
+	}
+	if head < el.config.ConfirmationBlocks {
+		return 0
+	}
+	return head - el.config.ConfirmationBlocks
+}
+
+// checkStartBlock flags a configured start block beyond the chain head, which
+// would otherwise look like an up-to-date indexer that never indexes anything
+func (el *EventListener) checkStartBlock(startBlock, latestBlock uint64) error {
+	if startBlock <= latestBlock {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: START_BLOCK %d > head %d (check ETHEREUM_RPC and CHAIN_ID)", ErrStartBlockAheadOfHead, startBlock, latestBlock)
+	if el.config != nil && el.config.StartBlockAheadPolicy == StartBlockAheadFail {
+		return err
+	}
+
+	log.Printf("🚨 WARNING: %v. No historical events will be indexed until the chain reaches the start block.", err)
+	return nil
+}
+
+// fetchAndProcessHistoricalEvents fetches and processes historical events in
+// batches. Batches shrink when the RPC node rejects a range as too large and
+// grow back as queries succeed, so providers with different limits work alike.
 func (el *EventListener) fetchAndProcessHistoricalEvents(ctx context.Context, startBlock, latestBlock uint64) error {
-	// Fetch in batches to avoid RPC limits
-	batchSize := uint64(10000)
-	for from := startBlock; from < latestBlock; from += batchSize {
-		to := from + batchSize
+	batch := newAdaptiveBatch(0, 0)
+	if el.config != nil {
+		batch = newAdaptiveBatch(el.config.HistoricalBatchSize, el.config.HistoricalMaxBatchSize)
+	}
+
+	for from := startBlock; from < latestBlock; {
+		to := from + batch.size
 		if to > latestBlock {
 			to = latestBlock
 		}
 
 		events, err := el.client.FetchHistoricalEvents(ctx, from, to)
 		if err != nil {
+			if isRangeTooLargeError(err) && batch.shrink() {
+				log.Printf("🔄 Blocks %d to %d rejected as too large a range, retrying with batches of %d blocks: %v", from, to, batch.size, err)
+				continue
+			}
 			return fmt.Errorf("failed to fetch events from %d to %d: %v", from, to, err)
 		}
+		if err := el.stampBlockTimestamps(ctx, events); err != nil {
+			return err
+		}
 
 		for _, event := range events {
-			if err := el.handleEvent(event); err != nil {
+			if err := el.processEvent(ctx, event); err != nil {
 				return fmt.Errorf("failed to handle event: %v", err)
 			}
+			el.advanceSyncCheckpoint(event)
 		}
+		el.recordScannedRange(from, to, models.SyncSourceHistorical)
+		el.saveLastProcessedBlock(to)
 
 		log.Printf("✅ Processed blocks %d to %d (%d events)", from, to, len(events))
+		from = to
+		batch.grow()
+	}
+
+	return nil
+}
+
+// stampBlockTimestamps sets each event's block timestamp, fetching every
+// distinct block in the batch up front instead of one header per event. A
+// failed lookup fails the batch rather than storing events with a wrong time.
+func (el *EventListener) stampBlockTimestamps(ctx context.Context, events []*ContractEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	seen := make(map[uint64]bool)
+	var blocks []uint64
+	for _, event := range events {
+		if !seen[event.BlockNumber] {
+			seen[event.BlockNumber] = true
+			blocks = append(blocks, event.BlockNumber)
+		}
 	}
 
+	timestamps, err := el.client.GetBlockTimestamps(ctx, blocks)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block timestamps for %d blocks: %w", len(blocks), err)
+	}
+	for _, event := range events {
+		event.Timestamp = timestamps[event.BlockNumber]
+	}
+	return nil
+}
+
+// stampBlockTimestamp sets the block timestamp of an event that was not
+// stamped in bulk, such as a live event. A failed lookup fails the event so it
+// is retried.
+func (el *EventListener) stampBlockTimestamp(ctx context.Context, event *ContractEvent) error {
+	if !event.Timestamp.IsZero() || el.client == nil {
+		return nil
+	}
+
+	timestamp, err := el.client.GetBlockTimestamp(ctx, event.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch timestamp of block %d: %w", event.BlockNumber, err)
+	}
+	event.Timestamp = timestamp
 	return nil
 }
 
@@ -115,14 +443,32 @@ func (el *EventListener) fetchAndProcessHistoricalEvents(ctx context.Context, st
 func (el *EventListener) processEvents(ctx context.Context, eventChan <-chan *ContractEvent) {
 	log.Println("👂 Listening for new events...")
 
+	retryTicker := time.NewTicker(retryInterval)
+	defer retryTicker.Stop()
+
 	for {
 		select {
 		case event := <-eventChan:
-			if err := el.handleEvent(event); err != nil {
-				log.Printf("❌ Failed to handle event: %v", err)
+			if el.seenBySync(event) {
+				// Buffered subscription data overlapping the historical sync
+				log.Printf("⏭️  Skipping live %s event in tx %s: already processed by historical sync", event.EventType, event.TransactionHash)
+				continue
+			}
+			if err := el.processEvent(ctx, event); err != nil {
+				log.Printf("❌ Failed to handle event, queued for retry: %v", err)
+				el.enqueueRetry(event, err)
 			} else {
 				log.Printf("✅ Processed %s event for %s", event.EventType, event.Beneficiary)
+				el.extendLiveRange(event.BlockNumber)
+				// Later logs of the same block may still be on their way, so
+				// only the blocks before it are known to be complete
+				if event.BlockNumber > 0 {
+					el.saveLastProcessedBlock(event.BlockNumber - 1)
+				}
 			}
+		case <-retryTicker.C:
+			el.retryFailedEvents(ctx)
+			el.retryFailedPublishes(ctx)
 		case <-ctx.Done():
 			log.Println("🛑 Stopping event processor")
 			return
@@ -130,8 +476,283 @@ func (el *EventListener) processEvents(ctx context.Context, eventChan <-chan *Co
 	}
 }
 
+// advanceSyncCheckpoint moves the sync checkpoint to a processed historical event
+func (el *EventListener) advanceSyncCheckpoint(event *ContractEvent) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	if position := positionOf(event); el.syncCheckpoint == nil || position.after(*el.syncCheckpoint) {
+		el.syncCheckpoint = &position
+	}
+}
+
+// recordScannedRange adds a scanned block range to the coverage history.
+// Failures are logged and only leave a gap in reported coverage.
+func (el *EventListener) recordScannedRange(fromBlock, toBlock uint64, source string) *models.SyncRange {
+	scanned, err := el.db.RecordScannedRange(fromBlock, toBlock, source)
+	if err != nil {
+		log.Printf("⚠️  Failed to record scanned blocks %d-%d: %v", fromBlock, toBlock, err)
+		return nil
+	}
+	return scanned
+}
+
+// saveLastProcessedBlock advances the persisted checkpoint a restart resumes
+// from. The checkpoint stays below the oldest event awaiting retry, so a
+// restart picks that event up again.
+func (el *EventListener) saveLastProcessedBlock(block uint64) {
+	el.mu.Lock()
+	if block > el.processedBlock {
+		el.processedBlock = block
+	}
+	el.mu.Unlock()
+	el.saveCheckpoint()
+}
+
+// saveCheckpoint persists the highest processed block that no pending retry
+// or unpublished event precedes. A failed save only costs a rescan of the same
+// blocks after a restart.
+func (el *EventListener) saveCheckpoint() {
+	el.mu.Lock()
+	block := el.processedBlock
+	for _, failed := range el.retryQueue {
+		if failed.event.BlockNumber <= block {
+			block = failed.event.BlockNumber - 1
+		}
+	}
+	el.mu.Unlock()
+
+	unpublished, found, err := el.db.GetOldestOutboxBlock()
+	if err != nil {
+		log.Printf("⚠️  Failed to read unpublished events, not saving last processed block: %v", err)
+		return
+	}
+	if found && unpublished <= block {
+		block = unpublished - 1
+	}
+
+	if block == 0 {
+		return
+	}
+	if err := el.db.SaveLastProcessedBlock(block); err != nil {
+		log.Printf("⚠️  Failed to save last processed block %d: %v", block, err)
+	}
+}
+
+// extendLiveRange extends live coverage to the block of a processed live event.
+// Blocks after the latest live event are not counted until another arrives.
+func (el *EventListener) extendLiveRange(block uint64) {
+	if el.liveRange == nil || block <= el.liveRange.ToBlock {
+		return
+	}
+	if err := el.db.ExtendScannedRange(el.liveRange.ID, block); err != nil {
+		log.Printf("⚠️  Failed to extend live coverage to block %d: %v", block, err)
+		return
+	}
+	el.liveRange.ToBlock = block
+}
+
+// seenBySync reports whether a live event is at or before the sync checkpoint
+func (el *EventListener) seenBySync(event *ContractEvent) bool {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	return el.syncCheckpoint != nil && !positionOf(event).after(*el.syncCheckpoint)
+}
+
+// enqueueRetry adds an event that failed its first attempt to the retry queue
+func (el *EventListener) enqueueRetry(event *ContractEvent, err error) {
+	el.requeue(&failedEvent{event: event, attempts: 1, err: err})
+}
+
+// requeue puts a failed event back on the retry queue
+func (el *EventListener) requeue(failed *failedEvent) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.retryQueue = append(el.retryQueue, failed)
+}
+
+// retryFailedEvents reprocesses queued events, keeping those that fail again.
+// An event that fails maxRetryAttempts times is moved to the dead-letter table.
+// The checkpoint then advances past events that no longer await retry.
+func (el *EventListener) retryFailedEvents(ctx context.Context) {
+	el.mu.Lock()
+	pending := el.retryQueue
+	el.retryQueue = nil
+	el.mu.Unlock()
+
+	for _, failed := range pending {
+		err := el.processEvent(ctx, failed.event)
+		if err == nil {
+			continue
+		}
+		failed.attempts++
+		failed.err = err
+
+		if failed.attempts >= maxRetryAttempts {
+			deadErr := el.deadLetter(failed)
+			if deadErr == nil {
+				continue
+			}
+			log.Printf("⚠️  Failed to dead-letter %s event in tx %s, keeping it queued: %v", failed.event.EventType, failed.event.TransactionHash, deadErr)
+		}
+		log.Printf("⚠️  Retry %d failed for %s event in tx %s: %v", failed.attempts, failed.event.EventType, failed.event.TransactionHash, err)
+		el.requeue(failed)
+	}
+
+	if len(pending) > 0 {
+		el.saveCheckpoint()
+	}
+}
+
+// deadLetter records an event that exhausted its retries
+func (el *EventListener) deadLetter(failed *failedEvent) error {
+	event := failed.event
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := el.db.CreateDeadLetterEvent(&models.DeadLetterEvent{
+		EventType:       event.EventType,
+		Beneficiary:     event.Beneficiary,
+		BlockNumber:     event.BlockNumber,
+		TransactionHash: event.TransactionHash,
+		LogIndex:        event.LogIndex,
+		Payload:         string(payload),
+		Error:           failed.err.Error(),
+		Attempts:        failed.attempts,
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("🪦 Gave up on %s event in tx %s (log %d) after %d attempts; recorded as a dead letter: %v",
+		event.EventType, event.TransactionHash, event.LogIndex, failed.attempts, failed.err)
+	return nil
+}
+
+// processEvent persists an event and then publishes it downstream, running the
+// registered hooks around persistence. A failed publish does not fail
+// processing; the event stays in the outbox to publish again. Events of beneficiaries
+// off the allowlist are skipped.
+func (el *EventListener) processEvent(ctx context.Context, event *ContractEvent) error {
+	if !el.indexes(event.Beneficiary) {
+		log.Printf("⏭️  Skipping %s event in tx %s: beneficiary %s is not on the allowlist", event.EventType, event.TransactionHash, event.Beneficiary)
+		return nil
+	}
+
+	if err := el.stampBlockTimestamp(ctx, event); err != nil {
+		return err
+	}
+	el.runPreHandleHooks(event)
+	err := el.handleEvent(ctx, event)
+	el.runPostHandleHooks(event, err)
+	if err != nil {
+		return err
+	}
+	el.throughput.record(time.Now())
+
+	el.publish(ctx, event)
+	return nil
+}
+
+// publish sends a recorded event downstream and clears its outbox row. A failed
+// publish leaves the row for retryFailedPublishes.
+func (el *EventListener) publish(ctx context.Context, event *ContractEvent) bool {
+	if err := el.publisher.Publish(ctx, event); err != nil {
+		log.Printf("⚠️  Failed to publish %s event in tx %s, kept for retry: %v", event.EventType, event.TransactionHash, err)
+		return false
+	}
+	if err := el.db.DeleteOutboxEvent(event.TransactionHash, event.LogIndex); err != nil {
+		// The event stays in the outbox and is published again
+		log.Printf("⚠️  Failed to mark %s event in tx %s as published: %v", event.EventType, event.TransactionHash, err)
+	}
+	return true
+}
+
+// retryFailedPublishes republishes the oldest events awaiting publication in
+// the order they were recorded, stopping at the first that fails again. The
+// checkpoint then advances past events that were published.
+func (el *EventListener) retryFailedPublishes(ctx context.Context) {
+	pending, err := el.db.GetOutboxEvents(publishRetryBatch)
+	if err != nil {
+		log.Printf("⚠️  Failed to read unpublished events: %v", err)
+		return
+	}
+
+	for i := range pending {
+		if !el.publish(ctx, el.storedEvent(&pending[i])) {
+			break
+		}
+	}
+	if len(pending) > 0 {
+		el.saveCheckpoint()
+	}
+}
+
+// replayPageSize is the number of stored events read per page during a replay
+const replayPageSize = 500
+
+// Replay republishes already-indexed events between fromBlock and toBlock
+// (inclusive) in chain order so downstream consumers can rebuild their state.
+// Events are read from the database and are not persisted again. Replay stops
+// at the first failed publish and returns the number of events published.
+func (el *EventListener) Replay(ctx context.Context, fromBlock, toBlock uint64) (int, error) {
+	filter := database.EventFilter{FromBlock: &fromBlock, ToBlock: &toBlock}
+	published := 0
+
+	for {
+		events, err := el.db.GetEventsInRange(filter, replayPageSize, published)
+		if err != nil {
+			return published, fmt.Errorf("failed to read events: %w", err)
+		}
+
+		for i := range events {
+			if err := ctx.Err(); err != nil {
+				return published, err
+			}
+			event := el.storedEvent(&events[i])
+			if err := el.publisher.Publish(ctx, event); err != nil {
+				return published, fmt.Errorf("failed to publish %s event in tx %s: %w", event.EventType, event.TransactionHash, err)
+			}
+			published++
+		}
+
+		if len(events) < replayPageSize {
+			break
+		}
+	}
+
+	log.Printf("🔄 Replayed %d events from blocks %d-%d", published, fromBlock, toBlock)
+	return published, nil
+}
+
+// storedEvent rebuilds a contract event from its database record. The token and
+// schedule terms of creation events are only stored on the schedule, so they
+// are filled in from it when the schedule still exists.
+func (el *EventListener) storedEvent(stored *models.VestingEvent) *ContractEvent {
+	event := &ContractEvent{
+		EventType:       stored.EventType,
+		Beneficiary:     stored.Beneficiary,
+		Amount:          stored.Amount,
+		BlockNumber:     stored.BlockNumber,
+		TransactionHash: stored.TransactionHash,
+		LogIndex:        stored.LogIndex,
+	}
+
+	if event.EventType == "VestingScheduleCreated" {
+		if schedule, err := el.db.GetScheduleIncludingRevoked(stored.Beneficiary); err == nil {
+			event.Token = schedule.Token
+			event.Data = map[string]interface{}{
+				"start":    strconv.FormatInt(schedule.Start.Unix(), 10),
+				"cliff":    strconv.FormatInt(schedule.Cliff.Unix(), 10),
+				"duration": strconv.FormatInt(schedule.Duration, 10),
+			}
+		}
+	}
+
+	return event
+}
+
 // handleEvent processes a single event
-func (el *EventListener) handleEvent(event *ContractEvent) error {
+func (el *EventListener) handleEvent(ctx context.Context, event *ContractEvent) error {
 	// Save event to database
 	vestingEvent := &models.VestingEvent{
 		EventType:       event.EventType,
@@ -139,28 +760,110 @@ func (el *EventListener) handleEvent(event *ContractEvent) error {
 		Amount:          event.Amount,
 		BlockNumber:     event.BlockNumber,
 		TransactionHash: event.TransactionHash,
-		Timestamp:       time.Now(), // In production, get from block timestamp
+		LogIndex:        event.LogIndex,
+		Timestamp:       event.Timestamp,
+		DecodeError:     event.DecodeError,
+	}
+	if vestingEvent.Timestamp.IsZero() && el.client == nil {
+		// Without a chain client there is no block time to read
+		vestingEvent.Timestamp = time.Now()
 	}
 
-	if err := el.db.CreateEvent(vestingEvent); err != nil {
+	// Replayed events are skipped before the chain lookups they would need
+	recorded, err := el.db.HasEvent(event.TransactionHash, event.LogIndex)
+	if err != nil {
 		return err
 	}
+	if recorded {
+		logAlreadyRecorded(event)
+		return nil
+	}
 
-	// Update vesting schedule based on event type
-	switch event.EventType {
-	case "VestingScheduleCreated":
-		return el.handleScheduleCreated(event)
-	case "TokensReleased":
-		return el.handleTokensReleased(event)
-	case "VestingRevoked":
-		return el.handleVestingRevoked(event)
+	if el.config != nil && el.config.IndexGasUsed {
+		vestingEvent.GasUsed = el.fetchGasUsed(ctx, event.TransactionHash)
 	}
 
+	// Chain reads happen before the transaction so a slow node does not hold
+	// it and its row locks open
+	var revocable bool
+	if event.EventType == "VestingScheduleCreated" && event.DecodeError == "" {
+		if revocable, err = el.onChainRevocable(ctx, event.Beneficiary); err != nil {
+			return err
+		}
+	}
+
+	// The event is recorded in the same transaction as the schedule change it
+	// causes, so a failed change leaves no record behind and a retry applies it
+	err = el.db.Transaction(func(tx *database.Database) error {
+		if err := tx.CreateEvent(vestingEvent); err != nil {
+			return err
+		}
+		if err := tx.CreateOutboxEvent(vestingEvent); err != nil {
+			return err
+		}
+
+		if event.DecodeError != "" {
+			// Keep the record for inspection, but its amounts cannot be trusted
+			log.Printf("⚠️  Recorded partially decoded %s event in tx %s (log %d) without applying it: %s",
+				event.EventType, event.TransactionHash, event.LogIndex, event.DecodeError)
+			return nil
+		}
+
+		// Update vesting schedule based on event type
+		switch event.EventType {
+		case "VestingScheduleCreated":
+			return el.handleScheduleCreated(tx, event, revocable)
+		case "TokensReleased":
+			return el.handleTokensReleased(tx, event)
+		case "VestingRevoked":
+			return el.handleVestingRevoked(tx, event)
+		}
+		return nil
+	})
+	if errors.Is(err, database.ErrDuplicateEvent) {
+		// Recorded since the check above
+		logAlreadyRecorded(event)
+		return nil
+	}
+	if err != nil || event.DecodeError != "" {
+		return err
+	}
+
+	// Stream the event once it is fully applied. Duplicates and partially
+	// decoded events returned above are not streamed or delivered.
+	if el.hub != nil {
+		el.hub.Publish(*vestingEvent)
+	}
+	if el.webhook != nil {
+		el.webhook.Notify(*vestingEvent)
+	}
 	return nil
 }
 
-// handleScheduleCreated processes a VestingScheduleCreated event
-func (el *EventListener) handleScheduleCreated(event *ContractEvent) error {
+// logAlreadyRecorded notes an event skipped because it was already applied,
+// e.g. by historical sync replaying a block range
+func logAlreadyRecorded(event *ContractEvent) {
+	log.Printf("🔄 Skipping already recorded %s event in tx %s (log %d)", event.EventType, event.TransactionHash, event.LogIndex)
+}
+
+// fetchGasUsed reads the gas used by an event's transaction. Failures are logged
+// and leave gas unrecorded rather than holding up ingestion.
+func (el *EventListener) fetchGasUsed(ctx context.Context, txHash string) *uint64 {
+	ctx, cancel := context.WithTimeout(ctx, gasUsedTimeout)
+	defer cancel()
+
+	gasUsed, err := el.client.GetGasUsed(ctx, txHash)
+	if err != nil {
+		log.Printf("⚠️  Could not fetch gas used for tx %s: %v", txHash, err)
+		return nil
+	}
+	return &gasUsed
+}
+
+// handleScheduleCreated processes a VestingScheduleCreated event. Whether the
+// schedule is revocable is read from chain beforehand, since the event does
+// not carry it.
+func (el *EventListener) handleScheduleCreated(db *database.Database, event *ContractEvent, revocable bool) error {
 	data := event.Data
 
 	// Parse strings to int64
@@ -180,24 +883,91 @@ func (el *EventListener) handleScheduleCreated(event *ContractEvent) error {
 
 	schedule := &models.VestingSchedule{
 		Beneficiary: event.Beneficiary,
+		Token:       event.Token,
 		Start:       time.Unix(startBig.Int64(), 0),
 		Cliff:       time.Unix(cliffBig.Int64(), 0),
 		Duration:    durationBig.Int64(),
 		Amount:      event.Amount,
 		Released:    "0",
-		Revocable:   true, // Default, should be from event data
+		Revocable:   revocable,
 		Revoked:     false,
+		CreationTx:  event.TransactionHash,
+	}
+
+	return db.CreateOrUpdateSchedule(schedule)
+}
+
+// onChainRevocable reads whether a beneficiary's schedule is revocable, which
+// the creation event does not carry. A schedule that cannot be read fails the
+// event so it is retried rather than stored with a guess. Listeners without a
+// chain client fall back to revocable, the contract's common case.
+func (el *EventListener) onChainRevocable(ctx context.Context, beneficiary string) (bool, error) {
+	if el.client == nil {
+		return true, nil
+	}
+
+	onChain, err := el.client.GetVestingSchedule(ctx, common.HexToAddress(beneficiary))
+	if err != nil {
+		return false, fmt.Errorf("failed to read on-chain schedule for %s: %w", beneficiary, err)
+	}
+	if onChain.Beneficiary == (common.Address{}) {
+		return false, fmt.Errorf("no on-chain schedule found for %s", beneficiary)
 	}
+	return onChain.Revocable, nil
+}
 
-	return el.db.CreateOrUpdateSchedule(schedule)
+// handleTokensReleased processes a TokensReleased event. The event carries the
+// amount released by that transaction, so it accumulates into the total.
+func (el *EventListener) handleTokensReleased(db *database.Database, event *ContractEvent) error {
+	if el.config != nil && el.config.RevokedReleasePolicy == RevokedReleaseFreeze {
+		frozen, err := releasedAfterRevocation(db, event)
+		if err != nil {
+			return err
+		}
+		if frozen {
+			log.Printf("⚠️  Not applying release of %s in tx %s: schedule for %s was already revoked", event.Amount, event.TransactionHash, event.Beneficiary)
+			return nil
+		}
+	}
+	return db.AddReleased(event.Beneficiary, event.Amount, eventCursor(event))
 }
 
-// handleTokensReleased processes a TokensReleased event
-func (el *EventListener) handleTokensReleased(event *ContractEvent) error {
-	return el.db.UpdateReleased(event.Beneficiary, event.Amount)
+// releasedAfterRevocation reports whether a release event comes after the
+// beneficiary's schedule was revoked on chain. Releases ordered before the
+// revocation, such as ones retried after a failed update, still count. A
+// revoked schedule without a recorded revocation event counts as revoked
+// before any release. Schedules deleted on revocation are still consulted.
+func releasedAfterRevocation(db *database.Database, event *ContractEvent) (bool, error) {
+	schedule, err := db.GetScheduleIncludingDeleted(event.Beneficiary)
+	if err != nil {
+		return false, err
+	}
+	if !schedule.Revoked {
+		return false, nil
+	}
+
+	revocations, err := db.GetEventsByBeneficiary(event.Beneficiary, database.EventFilter{EventType: "VestingRevoked"}, 1, 0)
+	if err != nil {
+		return false, err
+	}
+	if len(revocations) == 0 {
+		return true, nil
+	}
+
+	revoked := revocations[0]
+	if event.BlockNumber != revoked.BlockNumber {
+		return event.BlockNumber > revoked.BlockNumber, nil
+	}
+	return event.LogIndex > revoked.LogIndex, nil
 }
 
 // handleVestingRevoked processes a VestingRevoked event
-func (el *EventListener) handleVestingRevoked(event *ContractEvent) error {
-	return el.db.MarkScheduleAsRevoked(event.Beneficiary)
+func (el *EventListener) handleVestingRevoked(db *database.Database, event *ContractEvent) error {
+	return db.MarkScheduleAsRevoked(event.Beneficiary, eventCursor(event))
+}
+
+// eventCursor returns where an event sits on chain, which picks the schedule
+// it applies to when a beneficiary holds several
+func eventCursor(event *ContractEvent) *database.EventCursor {
+	return &database.EventCursor{BlockNumber: event.BlockNumber, LogIndex: event.LogIndex}
 }
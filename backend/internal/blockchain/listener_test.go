@@ -0,0 +1,1189 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+	"github.com/kaldun-tech/token-vesting-backend/pkg/contracts"
+)
+
+// mockChain is a ChainSource with a fixed head and scripted events, receipts,
+// block timestamps and deployment block
+type mockChain struct {
+	head        uint64
+	gasUsed     map[string]uint64
+	gasCalls    int  // Receipt reads
+	gasDeadline bool // Whether the last receipt read was bounded by a deadline
+	deployment  uint64
+	deployments int // Number of deployment block detections
+
+	fetchFailures int // Historical fetches that fail before succeeding
+	fetches       int
+	maxRange      uint64      // Widest block range a historical fetch accepts (0 is unlimited)
+	fetchedRanges [][2]uint64 // Block ranges of successful historical fetches
+
+	events          []*ContractEvent                             // Returned by historical fetches covering their block
+	schedules       map[common.Address]contracts.VestingSchedule // On-chain schedules; others read as empty. When nil, every beneficiary has a revocable schedule
+	scheduleErr     error                                        // Returned by schedule reads when set
+	timestampErr    error                                        // Returned by timestamp reads when set
+	timestampCalls  int
+	timestampBlocks int // Blocks requested across all timestamp calls
+}
+
+func (m *mockChain) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	return m.head, nil
+}
+
+func (m *mockChain) FetchHistoricalEvents(ctx context.Context, fromBlock, toBlock uint64) ([]*ContractEvent, error) {
+	m.fetches++
+	if m.fetches <= m.fetchFailures {
+		return nil, errors.New("rpc timeout")
+	}
+	if m.maxRange > 0 && toBlock-fromBlock > m.maxRange {
+		return nil, fmt.Errorf("query exceeds max block range %d", m.maxRange)
+	}
+	m.fetchedRanges = append(m.fetchedRanges, [2]uint64{fromBlock, toBlock})
+
+	var events []*ContractEvent
+	for _, event := range m.events {
+		if event.BlockNumber >= fromBlock && event.BlockNumber <= toBlock {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *mockChain) GetVestingSchedule(ctx context.Context, beneficiary common.Address) (*contracts.VestingSchedule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if m.scheduleErr != nil {
+		return nil, m.scheduleErr
+	}
+	if m.schedules == nil {
+		return &contracts.VestingSchedule{Beneficiary: beneficiary, Revocable: true}, nil
+	}
+	schedule := m.schedules[beneficiary]
+	return &schedule, nil
+}
+
+func (m *mockChain) GetBlockTimestamp(ctx context.Context, block uint64) (time.Time, error) {
+	m.timestampCalls++
+	m.timestampBlocks++
+	if m.timestampErr != nil {
+		return time.Time{}, m.timestampErr
+	}
+	return mockBlockTime(block), nil
+}
+
+func (m *mockChain) GetBlockTimestamps(ctx context.Context, blocks []uint64) (map[uint64]time.Time, error) {
+	m.timestampCalls++
+	m.timestampBlocks += len(blocks)
+	if m.timestampErr != nil {
+		return nil, m.timestampErr
+	}
+
+	timestamps := make(map[uint64]time.Time, len(blocks))
+	for _, block := range blocks {
+		timestamps[block] = mockBlockTime(block)
+	}
+	return timestamps, nil
+}
+
+// mockBlockTime is the timestamp mockChain reports for a block, 12s per block
+func mockBlockTime(block uint64) time.Time {
+	return time.Unix(1700000000+int64(block)*12, 0).UTC()
+}
+
+func (m *mockChain) WatchEvents(ctx context.Context, startBlock uint64, eventChan chan<- *ContractEvent) error {
+	return nil
+}
+
+func (m *mockChain) FindDeploymentBlock(ctx context.Context) (uint64, error) {
+	m.deployments++
+	if m.deployment == 0 {
+		return 0, errors.New("no contract code")
+	}
+	return m.deployment, nil
+}
+
+func (m *mockChain) GetGasUsed(ctx context.Context, txHash string) (uint64, error) {
+	m.gasCalls++
+	_, m.gasDeadline = ctx.Deadline()
+	gasUsed, ok := m.gasUsed[txHash]
+	if !ok {
+		return 0, errors.New("receipt not found")
+	}
+	return gasUsed, nil
+}
+
+func TestBacklog_ReflectsBufferedAndRetryingEvents(t *testing.T) {
+	el := NewEventListener(nil, nil, nil)
+
+	buffered, retrying, _ := el.Backlog()
+	assert.Equal(t, 0, buffered)
+	assert.Equal(t, 0, retrying)
+
+	// Buffer events without a consumer draining the channel
+	for i := 0; i < 3; i++ {
+		el.eventChan <- &ContractEvent{EventType: "TokensReleased"}
+	}
+	el.enqueueRetry(&ContractEvent{EventType: "VestingRevoked"}, errors.New("rpc error"))
+
+	buffered, retrying, _ = el.Backlog()
+	assert.Equal(t, 3, buffered)
+	assert.Equal(t, 1, retrying)
+
+	<-el.eventChan
+	buffered, _, _ = el.Backlog()
+	assert.Equal(t, 2, buffered)
+}
+
+func TestHandleTokensReleased_AccumulatesReleases(t *testing.T) {
+	el := NewEventListener(&mockChain{head: 100}, setupTestDB(t), nil)
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+
+	require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xcreate",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "1000"},
+	}))
+
+	// Each TokensReleased event carries only the amount released by its own
+	// transaction, so the stored total is their sum
+	require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100", BlockNumber: 11, TransactionHash: "0xrelease1"}))
+	require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "250", BlockNumber: 12, TransactionHash: "0xrelease2"}))
+
+	schedule, err := el.db.GetScheduleByBeneficiary(beneficiary)
+	require.NoError(t, err)
+	assert.Equal(t, "350", schedule.Released)
+}
+
+func TestSyncHistoricalEvents_StartBlockAheadOfHead(t *testing.T) {
+	t.Run("Warn policy logs prominently and continues", func(t *testing.T) {
+		var buf bytes.Buffer
+		original := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(original)
+
+		el := NewEventListener(&mockChain{head: 100}, setupTestDB(t), &config.Config{StartBlockAheadPolicy: StartBlockAheadWarn})
+		err := el.syncHistoricalEvents(context.Background(), 500)
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "WARNING")
+		assert.Contains(t, buf.String(), "START_BLOCK 500 > head 100")
+	})
+
+	t.Run("Fail policy returns an error", func(t *testing.T) {
+		el := NewEventListener(&mockChain{head: 100}, setupTestDB(t), &config.Config{StartBlockAheadPolicy: StartBlockAheadFail})
+		err := el.syncHistoricalEvents(context.Background(), 500)
+
+		assert.True(t, errors.Is(err, ErrStartBlockAheadOfHead))
+	})
+
+	t.Run("Fail policy stops the listener", func(t *testing.T) {
+		el := NewEventListener(&mockChain{head: 100}, setupTestDB(t), &config.Config{StartBlockAheadPolicy: StartBlockAheadFail})
+		err := el.Start(context.Background(), 500)
+
+		assert.True(t, errors.Is(err, ErrStartBlockAheadOfHead))
+	})
+
+	t.Run("Start block within head is accepted", func(t *testing.T) {
+		el := NewEventListener(&mockChain{head: 100}, setupTestDB(t), &config.Config{StartBlockAheadPolicy: StartBlockAheadFail})
+		err := el.syncHistoricalEvents(context.Background(), 50)
+
+		assert.NoError(t, err)
+	})
+}
+
+// createdEvents builds a schedule creation event for each block, several per block
+func createdEvents(blocks []uint64) []*ContractEvent {
+	events := make([]*ContractEvent, len(blocks))
+	for i, block := range blocks {
+		events[i] = &ContractEvent{
+			EventType:       "VestingScheduleCreated",
+			Beneficiary:     common.BigToAddress(big.NewInt(int64(i + 1))).Hex(),
+			Amount:          "1000",
+			BlockNumber:     block,
+			TransactionHash: fmt.Sprintf("0x%064x", i+1),
+			Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+		}
+	}
+	return events
+}
+
+func TestEventListener_RecordsScannedRanges(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{head: 25000}
+	el := NewEventListener(chain, db, &config.Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, el.Start(ctx, 100))
+
+	// Each historical batch is recorded, and live coverage opens at the head
+	ranges, err := db.GetScannedRanges(database.EventFilter{})
+	require.NoError(t, err)
+	type scanned struct {
+		from, to uint64
+		source   string
+	}
+	got := make([]scanned, len(ranges))
+	for i, r := range ranges {
+		got[i] = scanned{r.FromBlock, r.ToBlock, r.Source}
+	}
+	assert.Equal(t, []scanned{
+		{100, 10100, models.SyncSourceHistorical},
+		{10100, 20100, models.SyncSourceHistorical},
+		{20100, 25000, models.SyncSourceHistorical},
+		{25000, 25000, models.SyncSourceLive},
+	}, got)
+
+	// Processing a live event extends live coverage to its block
+	el.eventChan <- createdEvents([]uint64{25010})[0]
+	require.Eventually(t, func() bool {
+		ranges, err := db.GetScannedRanges(database.EventFilter{})
+		return err == nil && len(ranges) == 4 && ranges[3].ToBlock == 25010
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSyncHistoricalEvents_CheckpointAdvancesOverEmptyRanges(t *testing.T) {
+	db := setupTestDB(t)
+	// The only event sits in the first batch; the rest of the range is empty
+	chain := &mockChain{head: 25000, events: createdEvents([]uint64{150})}
+	el := NewEventListener(chain, db, &config.Config{})
+
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+
+	block, err := db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25000), block)
+	assert.Equal(t, 3, chain.fetches)
+
+	// A restart with the head unchanged has nothing left to scan
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+	assert.Equal(t, 3, chain.fetches)
+
+	// Once the head moves on, only the new blocks are scanned
+	chain.head = 26000
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+	assert.Equal(t, 4, chain.fetches)
+
+	block, err = db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(26000), block)
+}
+
+func TestSyncHistoricalEvents_ShrinksBatchesForRangeLimits(t *testing.T) {
+	db := setupTestDB(t)
+	blocks := []uint64{150, 1999, 7000, 12345, 24999}
+	chain := &mockChain{head: 25000, maxRange: 2000, events: createdEvents(blocks)}
+	el := NewEventListener(chain, db, &config.Config{HistoricalBatchSize: 10000, HistoricalMaxBatchSize: 10000})
+
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+
+	// Every accepted range is within the node's limit, and together they
+	// cover the whole sync without gaps
+	require.NotEmpty(t, chain.fetchedRanges)
+	next := uint64(100)
+	for _, r := range chain.fetchedRanges {
+		assert.LessOrEqual(t, r[1]-r[0], chain.maxRange)
+		assert.Equal(t, next, r[0])
+		next = r[1]
+	}
+	assert.Equal(t, uint64(25000), next)
+
+	// Once shrunk, batches stay close to the limit instead of failing repeatedly
+	assert.Less(t, chain.fetches-len(chain.fetchedRanges), 10)
+
+	for _, event := range chain.events {
+		stored, err := db.GetEventsByBeneficiary(event.Beneficiary, database.EventFilter{}, 1, 0)
+		require.NoError(t, err)
+		assert.Len(t, stored, 1, "block %d", event.BlockNumber)
+	}
+
+	block, err := db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25000), block)
+}
+
+func TestProcessEvents_CheckpointCoversOnlyCompleteBlocks(t *testing.T) {
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, &config.Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		el.processEvents(ctx, el.eventChan)
+	}()
+
+	lastProcessed := func() uint64 {
+		block, err := db.GetLastProcessedBlock()
+		require.NoError(t, err)
+		return block
+	}
+
+	events := createdEvents([]uint64{200, 200, 201})
+	events[1].LogIndex = 1
+	el.eventChan <- events[0]
+	el.eventChan <- events[1]
+	require.Eventually(t, func() bool {
+		var count int64
+		require.NoError(t, db.DB.Model(&models.VestingEvent{}).Count(&count).Error)
+		return count == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, uint64(199), lastProcessed())
+
+	// A crash now resumes at block 200, so its remaining logs are not skipped.
+	// The next block's event shows block 200 is complete.
+	el.eventChan <- events[2]
+	require.Eventually(t, func() bool { return lastProcessed() == 200 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestSyncHistoricalEvents_StopsAtConfirmedHead(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{head: 1000, events: createdEvents([]uint64{150, 995})}
+	el := NewEventListener(chain, db, &config.Config{ConfirmationBlocks: 12})
+
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+
+	block, err := db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(988), block)
+
+	// The unconfirmed event is left for the live watcher
+	var count int64
+	require.NoError(t, db.DB.Model(&models.VestingEvent{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSyncHistoricalEvents_PrefetchesBlockTimestamps(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{head: 200, events: createdEvents([]uint64{110, 110, 110, 120, 120, 150})}
+
+	el := NewEventListener(chain, db, &config.Config{})
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+
+	// One batched lookup covering each distinct block, not one call per event
+	assert.Equal(t, 1, chain.timestampCalls)
+	assert.Equal(t, 3, chain.timestampBlocks)
+	assert.Less(t, chain.timestampCalls, len(chain.events))
+
+	for _, event := range chain.events {
+		stored, err := db.GetEventsByBeneficiary(event.Beneficiary, database.EventFilter{}, 1, 0)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.True(t, mockBlockTime(event.BlockNumber).Equal(stored[0].Timestamp), "block %d", event.BlockNumber)
+	}
+}
+
+func TestProcessEvent_StampsLiveEventWithBlockTime(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{}
+	el := NewEventListener(chain, db, &config.Config{})
+
+	event := createdEvents([]uint64{4321})[0]
+	require.NoError(t, el.processEvent(context.Background(), event))
+
+	stored, err := db.GetEventsByBeneficiary(event.Beneficiary, database.EventFilter{}, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.True(t, mockBlockTime(4321).Equal(stored[0].Timestamp), "stored %v", stored[0].Timestamp)
+	assert.Equal(t, 1, chain.timestampCalls)
+}
+
+func TestBlockTimestampFailure_FailsEvents(t *testing.T) {
+	t.Run("Live event is retried", func(t *testing.T) {
+		db := setupTestDB(t)
+		chain := &mockChain{timestampErr: errors.New("header not found")}
+		el := NewEventListener(chain, db, &config.Config{})
+		event := createdEvents([]uint64{4321})[0]
+
+		assert.Error(t, el.processEvent(context.Background(), event))
+		stored, err := db.GetEventsByBeneficiary(event.Beneficiary, database.EventFilter{}, 1, 0)
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+
+		chain.timestampErr = nil
+		require.NoError(t, el.processEvent(context.Background(), event))
+		stored, err = db.GetEventsByBeneficiary(event.Beneficiary, database.EventFilter{}, 1, 0)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.True(t, mockBlockTime(4321).Equal(stored[0].Timestamp), "stored %v", stored[0].Timestamp)
+	})
+
+	t.Run("Historical batch fails", func(t *testing.T) {
+		db := setupTestDB(t)
+		chain := &mockChain{head: 200, events: createdEvents([]uint64{110}), timestampErr: errors.New("header not found")}
+		el := NewEventListener(chain, db, &config.Config{})
+
+		assert.Error(t, el.syncHistoricalEvents(context.Background(), 100))
+		var count int64
+		require.NoError(t, db.DB.Model(&models.VestingEvent{}).Count(&count).Error)
+		assert.Zero(t, count)
+		block, err := db.GetLastProcessedBlock()
+		require.NoError(t, err)
+		assert.Zero(t, block)
+	})
+}
+
+func TestHandleScheduleCreated_RevocableFromChain(t *testing.T) {
+	fixed := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	revocable := common.HexToAddress("0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea")
+
+	db := setupTestDB(t)
+	chain := &mockChain{schedules: map[common.Address]contracts.VestingSchedule{
+		fixed:     {Beneficiary: fixed, Revocable: false},
+		revocable: {Beneficiary: revocable, Revocable: true},
+	}}
+	el := NewEventListener(chain, db, &config.Config{})
+
+	tests := []struct {
+		beneficiary common.Address
+		expected    bool
+	}{
+		{fixed, false},
+		{revocable, true},
+	}
+
+	for i, tt := range tests {
+		require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{
+			EventType:       "VestingScheduleCreated",
+			Beneficiary:     tt.beneficiary.Hex(),
+			Amount:          "1000",
+			TransactionHash: fmt.Sprintf("0x%064x", i+1),
+			Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+		}))
+
+		stored, err := db.GetScheduleByBeneficiary(tt.beneficiary.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, stored.Revocable, tt.beneficiary.Hex())
+	}
+}
+
+func TestProcessEvent_UnreadableRevocabilityIsRetried(t *testing.T) {
+	beneficiary := common.HexToAddress("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	created := createdEvents([]uint64{10})[0]
+	created.Beneficiary = beneficiary.Hex()
+
+	tests := []struct {
+		name  string
+		chain *mockChain
+	}{
+		{"RPC error", &mockChain{scheduleErr: errors.New("rpc timeout")}},
+		{"Empty result", &mockChain{schedules: map[common.Address]contracts.VestingSchedule{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			el := NewEventListener(tt.chain, db, &config.Config{})
+
+			// Nothing is stored with a guessed flag
+			assert.Error(t, el.processEvent(context.Background(), created))
+			_, err := db.GetScheduleByBeneficiary(beneficiary.Hex())
+			assert.Error(t, err)
+
+			// Once the chain answers, the retry stores the real flag
+			tt.chain.scheduleErr = nil
+			tt.chain.schedules = map[common.Address]contracts.VestingSchedule{
+				beneficiary: {Beneficiary: beneficiary, Revocable: false},
+			}
+			require.NoError(t, el.processEvent(context.Background(), created))
+
+			stored, err := db.GetScheduleByBeneficiary(beneficiary.Hex())
+			require.NoError(t, err)
+			assert.False(t, stored.Revocable)
+		})
+	}
+}
+
+func TestHandleEvent_RevocabilityReadIsCancellable(t *testing.T) {
+	db := setupTestDB(t)
+	el := NewEventListener(&mockChain{}, db, &config.Config{})
+	event := createdEvents([]uint64{100})[0]
+
+	// A cancelled read, e.g. on shutdown, fails the event before anything is stored
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, el.handleEvent(ctx, event), context.Canceled)
+
+	recorded, err := db.HasEvent(event.TransactionHash, event.LogIndex)
+	require.NoError(t, err)
+	assert.False(t, recorded)
+	_, err = db.GetScheduleByBeneficiary(event.Beneficiary)
+	assert.Error(t, err)
+}
+
+func TestHandleScheduleCreated_ReplayKeepsStoredSchedule(t *testing.T) {
+	db := setupTestDB(t)
+	el := NewEventListener(&mockChain{}, db, &config.Config{})
+	event := createdEvents([]uint64{100})[0]
+
+	require.NoError(t, el.handleScheduleCreated(db, event, true))
+	require.NoError(t, db.AddReleased(event.Beneficiary, "400", nil))
+
+	// Replaying the creation event must not reset the release applied since
+	require.NoError(t, el.handleScheduleCreated(db, event, true))
+
+	schedules, err := db.GetSchedulesByBeneficiary(event.Beneficiary)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, "400", schedules[0].Released)
+	assert.Equal(t, event.TransactionHash, schedules[0].CreationTx)
+}
+
+func BenchmarkSyncHistoricalEvents_BlockTimestamps(b *testing.B) {
+	blocks := make([]uint64, 200)
+	for i := range blocks {
+		blocks[i] = 100 + uint64(i/4) // Four events per block
+	}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		chain := &mockChain{head: 1000, events: createdEvents(blocks)}
+		el := NewEventListener(chain, setupTestDB(b), &config.Config{})
+		b.StartTimer()
+
+		require.NoError(b, el.syncHistoricalEvents(context.Background(), 100))
+		b.ReportMetric(float64(chain.timestampCalls)/float64(len(blocks)), "header-calls/event")
+	}
+}
+
+func TestSyncHistoricalWithRetry(t *testing.T) {
+	cfg := &config.Config{HistoricalSyncRetries: 2, HistoricalSyncBackoff: time.Millisecond}
+
+	t.Run("Retries until the sync succeeds", func(t *testing.T) {
+		chain := &mockChain{head: 100, fetchFailures: 2}
+		el := NewEventListener(chain, setupTestDB(t), cfg)
+		assert.Equal(t, InitialSyncPending, el.InitialSync().State)
+
+		require.NoError(t, el.syncHistoricalWithRetry(context.Background(), 50))
+
+		assert.Equal(t, 3, chain.fetches)
+		status := el.InitialSync()
+		assert.True(t, status.Ready())
+		assert.Equal(t, InitialSyncComplete, status.State)
+		assert.Equal(t, 3, status.Attempts)
+	})
+
+	t.Run("Reports failure once retries are exhausted", func(t *testing.T) {
+		chain := &mockChain{head: 100, fetchFailures: 10}
+		el := NewEventListener(chain, setupTestDB(t), cfg)
+
+		assert.Error(t, el.syncHistoricalWithRetry(context.Background(), 50))
+
+		assert.Equal(t, 3, chain.fetches)
+		status := el.InitialSync()
+		assert.False(t, status.Ready())
+		assert.Equal(t, InitialSyncFailed, status.State)
+		assert.Contains(t, status.Error, "rpc timeout")
+	})
+
+	t.Run("Start block ahead of head is not retried", func(t *testing.T) {
+		el := NewEventListener(&mockChain{head: 100}, setupTestDB(t), &config.Config{
+			StartBlockAheadPolicy: StartBlockAheadFail,
+			HistoricalSyncRetries: 2,
+		})
+
+		err := el.syncHistoricalWithRetry(context.Background(), 500)
+
+		assert.True(t, errors.Is(err, ErrStartBlockAheadOfHead))
+		assert.Equal(t, 1, el.InitialSync().Attempts)
+	})
+}
+
+func TestResolveDeploymentBlock(t *testing.T) {
+	cfg := &config.Config{TokenVestingAddress: "0x5d6709ce17c956833b66ade058832c1890af19b7"}
+	contract := "0x5d6709ce17C956833B66aDe058832c1890aF19b7" // Checksummed
+
+	t.Run("Detected block is persisted and reused on restart", func(t *testing.T) {
+		db := setupTestDB(t)
+		chain := &mockChain{head: 1000, deployment: 420}
+
+		el := NewEventListener(chain, db, cfg)
+		assert.Equal(t, uint64(420), el.resolveDeploymentBlock(context.Background(), 0))
+		assert.Equal(t, 1, chain.deployments)
+
+		stored, found, err := db.GetDeploymentBlock(contract)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, uint64(420), stored)
+
+		// A new listener over the same database skips detection
+		restarted := NewEventListener(chain, db, cfg)
+		assert.Equal(t, uint64(420), restarted.resolveDeploymentBlock(context.Background(), 0))
+		assert.Equal(t, 1, chain.deployments)
+	})
+
+	t.Run("Configured start block wins and is persisted", func(t *testing.T) {
+		db := setupTestDB(t)
+		chain := &mockChain{head: 1000, deployment: 420}
+		require.NoError(t, db.SaveDeploymentBlock(contract, 420))
+
+		el := NewEventListener(chain, db, cfg)
+		assert.Equal(t, uint64(300), el.resolveDeploymentBlock(context.Background(), 300))
+		assert.Equal(t, 0, chain.deployments)
+
+		stored, _, err := db.GetDeploymentBlock(contract)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(300), stored)
+	})
+
+	t.Run("Failed detection falls back without persisting", func(t *testing.T) {
+		db := setupTestDB(t)
+		el := NewEventListener(&mockChain{head: 1000}, db, cfg)
+
+		assert.Equal(t, uint64(0), el.resolveDeploymentBlock(context.Background(), 0))
+
+		_, found, err := db.GetDeploymentBlock(contract)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestHandleEvent_RecordsGasUsed(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	chain := &mockChain{gasUsed: map[string]uint64{"0xtx1": 52341}}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		txHash   string
+		expected *uint64
+	}{
+		{name: "Enabled", enabled: true, txHash: "0xtx1", expected: func() *uint64 { v := uint64(52341); return &v }()},
+		{name: "Disabled", enabled: false, txHash: "0xtx1", expected: nil},
+		{name: "Receipt unavailable", enabled: true, txHash: "0xmissing", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			el := NewEventListener(chain, db, &config.Config{IndexGasUsed: tt.enabled})
+			require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{Beneficiary: beneficiary, Amount: "1000", Released: "0"}))
+
+			require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{
+				EventType:       "TokensReleased",
+				Beneficiary:     beneficiary,
+				Amount:          "100",
+				BlockNumber:     10,
+				TransactionHash: tt.txHash,
+			}))
+
+			events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{}, 10, 0)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, tt.expected, events[0].GasUsed)
+		})
+	}
+}
+
+func TestHandleEvent_SkipsReceiptForRecordedEvents(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{gasUsed: map[string]uint64{"0xtx1": 52341}}
+	el := NewEventListener(chain, db, &config.Config{IndexGasUsed: true})
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{Beneficiary: beneficiary, Amount: "1000", Released: "0"}))
+
+	event := &ContractEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     beneficiary,
+		Amount:          "100",
+		BlockNumber:     10,
+		TransactionHash: "0xtx1",
+	}
+	require.NoError(t, el.handleEvent(context.Background(), event))
+	assert.Equal(t, 1, chain.gasCalls)
+	assert.True(t, chain.gasDeadline, "receipt reads should be bounded by a timeout")
+
+	// A replayed log is recognised before any receipt is fetched
+	require.NoError(t, el.handleEvent(context.Background(), event))
+	assert.Equal(t, 1, chain.gasCalls)
+}
+
+func TestProcessEvent_BeneficiaryAllowlist(t *testing.T) {
+	allowed := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	other := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+
+	db := setupTestDB(t)
+	publisher := &mockPublisher{}
+	el := NewEventListener(&mockChain{}, db, &config.Config{})
+	el.SetPublisher(publisher)
+	require.NoError(t, el.SetBeneficiaryAllowlist([]string{strings.ToLower(allowed)}))
+
+	for i, beneficiary := range []string{allowed, other} {
+		require.NoError(t, el.processEvent(context.Background(), &ContractEvent{
+			EventType:       "VestingScheduleCreated",
+			Beneficiary:     beneficiary,
+			Amount:          "1000",
+			BlockNumber:     uint64(10 + i),
+			TransactionHash: fmt.Sprintf("0x%064x", i+1),
+			Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+		}))
+	}
+
+	// The allowlisted beneficiary's event and schedule are persisted
+	events, err := db.GetEventsByBeneficiary(allowed, database.EventFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	_, err = db.GetScheduleByBeneficiary(allowed)
+	assert.NoError(t, err)
+
+	// The other is skipped entirely
+	events, err = db.GetEventsByBeneficiary(other, database.EventFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	_, err = db.GetScheduleByBeneficiary(other)
+	assert.Error(t, err)
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, allowed, publisher.published[0].Beneficiary)
+
+	assert.Error(t, el.SetBeneficiaryAllowlist([]string{"not-an-address"}))
+}
+
+func TestHandleEvent_PartialDecode(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	db := setupTestDB(t)
+	hub := NewEventHub()
+	el := NewEventListener(&mockChain{}, db, nil)
+	el.SetEventHub(hub)
+
+	stream, unsubscribe := hub.Subscribe(beneficiary)
+	defer unsubscribe()
+
+	err := el.handleEvent(context.Background(), &ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xtx1",
+		Data:            map[string]interface{}{"start": "1700000000"},
+		DecodeError:     "cliff: data is 64 bytes, field needs bytes 64-96",
+	})
+	require.NoError(t, err)
+
+	// The event is kept with its error flag
+	events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1000", events[0].Amount)
+	assert.Equal(t, "cliff: data is 64 bytes, field needs bytes 64-96", events[0].DecodeError)
+
+	// but not applied or streamed
+	_, err = db.GetScheduleByBeneficiary(beneficiary)
+	assert.Error(t, err)
+	assert.Empty(t, stream)
+}
+
+// mockPublisher records published events and can fail a number of times first
+type mockPublisher struct {
+	failures  int
+	published []*ContractEvent
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, event *ContractEvent) error {
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("broker unavailable")
+	}
+	m.published = append(m.published, event)
+	return nil
+}
+
+func TestProcessEvent_PublishesAfterPersistence(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	created := &ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xcreate",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+	}
+	released := &ContractEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     beneficiary,
+		Amount:          "100",
+		BlockNumber:     11,
+		TransactionHash: "0xrelease",
+	}
+	orphaned := &ContractEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea",
+		Amount:          "100",
+		BlockNumber:     12,
+		TransactionHash: "0xorphan",
+	}
+
+	t.Run("Publishes each successfully processed event once", func(t *testing.T) {
+		publisher := &mockPublisher{}
+		el := NewEventListener(nil, setupTestDB(t), nil)
+		el.SetPublisher(publisher)
+
+		require.NoError(t, el.processEvent(context.Background(), created))
+		require.NoError(t, el.processEvent(context.Background(), released))
+		// Fails to persist the schedule update, so it must not be published
+		assert.Error(t, el.processEvent(context.Background(), orphaned))
+
+		assert.Equal(t, []*ContractEvent{created, released}, publisher.published)
+	})
+
+	t.Run("Failed publishes are retried from the outbox", func(t *testing.T) {
+		publisher := &mockPublisher{failures: 1}
+		db := setupTestDB(t)
+		el := NewEventListener(nil, db, nil)
+		el.SetPublisher(publisher)
+
+		require.NoError(t, el.processEvent(context.Background(), created))
+		require.NoError(t, el.processEvent(context.Background(), released))
+		el.saveLastProcessedBlock(released.BlockNumber)
+		assert.Equal(t, []*ContractEvent{released}, publisher.published)
+
+		// The unpublished event is persisted and holds the checkpoint back
+		_, _, unpublished := el.Backlog()
+		assert.Equal(t, 1, unpublished)
+		last, err := db.GetLastProcessedBlock()
+		require.NoError(t, err)
+		assert.Equal(t, created.BlockNumber-1, last)
+
+		// A listener restarted on the same database publishes it
+		restarted := NewEventListener(nil, db, nil)
+		restarted.SetPublisher(publisher)
+		restarted.saveLastProcessedBlock(released.BlockNumber)
+		restarted.retryFailedPublishes(context.Background())
+		require.Len(t, publisher.published, 2)
+		assert.Equal(t, created.TransactionHash, publisher.published[1].TransactionHash)
+		assert.Equal(t, created.Token, publisher.published[1].Token)
+
+		_, _, unpublished = restarted.Backlog()
+		assert.Equal(t, 0, unpublished)
+		last, err = db.GetLastProcessedBlock()
+		require.NoError(t, err)
+		assert.Equal(t, released.BlockNumber, last)
+
+		// Nothing is left to publish again
+		restarted.retryFailedPublishes(context.Background())
+		assert.Len(t, publisher.published, 2)
+	})
+}
+
+func TestHandleEvent_ReprocessingIsIdempotent(t *testing.T) {
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, nil)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	created := createdEvents([]uint64{10})[0]
+	created.Beneficiary = beneficiary
+	// One transaction releasing twice emits two logs
+	releases := []*ContractEvent{
+		{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100", BlockNumber: 11, TransactionHash: "0xrelease", LogIndex: 0},
+		{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "50", BlockNumber: 11, TransactionHash: "0xrelease", LogIndex: 1},
+	}
+
+	// Process the range twice, as historical sync does after a restart
+	for pass := 0; pass < 2; pass++ {
+		require.NoError(t, el.handleEvent(context.Background(), created))
+		for _, release := range releases {
+			require.NoError(t, el.handleEvent(context.Background(), release))
+		}
+	}
+
+	schedule, err := db.GetScheduleByBeneficiary(beneficiary)
+	require.NoError(t, err)
+	assert.Equal(t, "150", schedule.Released)
+
+	events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+}
+
+func TestProcessEvent_FailedUpdateIsRetried(t *testing.T) {
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, nil)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	created := createdEvents([]uint64{10})[0]
+	created.Beneficiary = beneficiary
+	require.NoError(t, el.processEvent(context.Background(), created))
+
+	// The first schedule update fails, as on a dropped connection
+	failed := false
+	require.NoError(t, db.DB.Callback().Update().Before("gorm:update").Register("test:fail_once", func(tx *gorm.DB) {
+		if !failed && tx.Statement.Table == "vesting_schedules" {
+			failed = true
+			_ = tx.AddError(errors.New("connection reset"))
+		}
+	}))
+
+	release := &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100", BlockNumber: 11, TransactionHash: "0xrelease"}
+	require.Error(t, el.processEvent(context.Background(), release))
+	el.enqueueRetry(release, errors.New("connection reset"))
+
+	// The failed update left no event behind, so the retry applies the release
+	el.retryFailedEvents(context.Background())
+
+	schedule, err := db.GetScheduleByBeneficiary(beneficiary)
+	require.NoError(t, err)
+	assert.Equal(t, "100", schedule.Released)
+
+	events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{EventType: "TokensReleased"}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	_, retrying, _ := el.Backlog()
+	assert.Equal(t, 0, retrying)
+}
+
+func TestRetryFailedEvents_HoldsCheckpointUntilRetried(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{scheduleErr: errors.New("rpc error")}
+	el := NewEventListener(chain, db, &config.Config{})
+
+	event := createdEvents([]uint64{10})[0]
+	require.Error(t, el.processEvent(context.Background(), event))
+	el.enqueueRetry(event, errors.New("rpc error"))
+
+	// Later blocks are processed, but a restart must still revisit block 10
+	el.saveLastProcessedBlock(20)
+	last, err := db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), last)
+
+	chain.scheduleErr = nil
+	el.retryFailedEvents(context.Background())
+
+	last, err = db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), last)
+}
+
+func TestRetryFailedEvents_DeadLettersExhaustedEvents(t *testing.T) {
+	db := setupTestDB(t)
+	chain := &mockChain{scheduleErr: errors.New("rpc error")}
+	el := NewEventListener(chain, db, &config.Config{})
+
+	event := createdEvents([]uint64{10})[0]
+	el.enqueueRetry(event, errors.New("rpc error"))
+	el.saveLastProcessedBlock(20)
+
+	for attempt := 1; attempt < maxRetryAttempts-1; attempt++ {
+		el.retryFailedEvents(context.Background())
+	}
+	_, retrying, _ := el.Backlog()
+	assert.Equal(t, 1, retrying)
+
+	el.retryFailedEvents(context.Background())
+	_, retrying, _ = el.Backlog()
+	assert.Equal(t, 0, retrying)
+
+	var dead []models.DeadLetterEvent
+	require.NoError(t, db.DB.Find(&dead).Error)
+	require.Len(t, dead, 1)
+	assert.Equal(t, event.TransactionHash, dead[0].TransactionHash)
+	assert.Equal(t, maxRetryAttempts, dead[0].Attempts)
+	assert.Contains(t, dead[0].Error, "rpc error")
+	assert.Contains(t, dead[0].Payload, event.Beneficiary)
+
+	// The dead letter no longer holds the checkpoint back
+	last, err := db.GetLastProcessedBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), last)
+}
+
+func TestHandleTokensReleased_AfterRevocation(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	created := createdEvents([]uint64{10})[0]
+	created.Beneficiary = beneficiary
+	releasedBefore := &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100", BlockNumber: 11, TransactionHash: "0xrelease1"}
+	revoked := &ContractEvent{EventType: "VestingRevoked", Beneficiary: beneficiary, Amount: "600", BlockNumber: 12, TransactionHash: "0xrevoke", LogIndex: 2}
+	releasedAfter := &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "50", BlockNumber: 12, TransactionHash: "0xrelease2", LogIndex: 3}
+
+	tests := []struct {
+		policy   string
+		released string
+	}{
+		{policy: RevokedReleaseApply, released: "150"},
+		{policy: RevokedReleaseFreeze, released: "100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			db := setupTestDB(t)
+			el := NewEventListener(nil, db, &config.Config{RevokedReleasePolicy: tt.policy})
+
+			require.NoError(t, el.handleEvent(context.Background(), created))
+			require.NoError(t, el.handleEvent(context.Background(), revoked))
+			// A release ordered before the revocation counts even when processed after it
+			require.NoError(t, el.handleEvent(context.Background(), releasedBefore))
+			require.NoError(t, el.handleEvent(context.Background(), releasedAfter))
+
+			schedule, err := db.GetScheduleIncludingRevoked(beneficiary)
+			require.NoError(t, err)
+			assert.True(t, schedule.Revoked)
+			assert.Equal(t, tt.released, schedule.Released)
+
+			// The release is recorded either way
+			events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{EventType: "TokensReleased"}, 10, 0)
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+		})
+	}
+}
+
+func TestHandleTokensReleased_FreezeWithDeleteMode(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	created := createdEvents([]uint64{10})[0]
+	created.Beneficiary = beneficiary
+	revoked := &ContractEvent{EventType: "VestingRevoked", Beneficiary: beneficiary, Amount: "600", BlockNumber: 12, TransactionHash: "0xrevoke"}
+	releasedAfter := &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "50", BlockNumber: 13, TransactionHash: "0xrelease"}
+
+	db := setupTestDB(t)
+	db.SetRevocationMode(database.RevocationModeDelete)
+	el := NewEventListener(nil, db, &config.Config{RevokedReleasePolicy: RevokedReleaseFreeze})
+
+	require.NoError(t, el.handleEvent(context.Background(), created))
+	require.NoError(t, el.handleEvent(context.Background(), revoked))
+	// The deleted schedule is still found, so the release is frozen rather than failing
+	require.NoError(t, el.handleEvent(context.Background(), releasedAfter))
+
+	_, err := db.GetScheduleByBeneficiary(beneficiary)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	schedule, err := db.GetScheduleIncludingDeleted(beneficiary)
+	require.NoError(t, err)
+	assert.True(t, schedule.Revoked)
+	assert.Equal(t, "0", schedule.Released)
+
+	events, err := db.GetEventsByBeneficiary(beneficiary, database.EventFilter{EventType: "TokensReleased"}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestHandleEvent_MultiModeAppliesEventsToOneSchedule(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.ApplyScheduleMode(database.ScheduleModeMulti))
+	el := NewEventListener(nil, db, &config.Config{})
+
+	created := createdEvents([]uint64{10, 12})
+	beneficiary := created[0].Beneficiary
+	created[1].Beneficiary = beneficiary
+	released := &ContractEvent{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100", BlockNumber: 11, TransactionHash: "0xrelease"}
+	revoked := &ContractEvent{EventType: "VestingRevoked", Beneficiary: beneficiary, Amount: "900", BlockNumber: 13, TransactionHash: "0xrevoke"}
+
+	require.NoError(t, el.handleEvent(context.Background(), created[0]))
+	require.NoError(t, el.handleEvent(context.Background(), created[1]))
+	// The release is processed after the second schedule exists, but its block
+	// places it under the first
+	require.NoError(t, el.handleEvent(context.Background(), released))
+	require.NoError(t, el.handleEvent(context.Background(), revoked))
+
+	schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "100", schedules[0].Released)
+	assert.False(t, schedules[0].Revoked)
+	assert.Equal(t, "0", schedules[1].Released)
+	assert.True(t, schedules[1].Revoked)
+}
+
+func TestProcessEvents_SkipsLiveEventsBelowSyncCheckpoint(t *testing.T) {
+	events := createdEvents([]uint64{110, 120})
+	events[1].LogIndex = 4
+	chain := &mockChain{head: 120, events: events}
+
+	el := NewEventListener(chain, setupTestDB(t), &config.Config{})
+	require.NoError(t, el.syncHistoricalEvents(context.Background(), 100))
+
+	handled := make(chan *ContractEvent, 10)
+	el.AddPreHandleHook(func(event ContractEvent) error {
+		handled <- &event
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go el.processEvents(ctx, el.eventChan)
+
+	// Replayed subscription data up to the checkpoint, then a new log in the same block
+	stale := *events[1]
+	fresh := createdEvents([]uint64{120, 120, 120})[2]
+	fresh.LogIndex = 5
+	el.eventChan <- &stale
+	el.eventChan <- events[0]
+	el.eventChan <- fresh
+
+	select {
+	case event := <-handled:
+		assert.Equal(t, fresh.TransactionHash, event.TransactionHash)
+	case <-time.After(time.Second):
+		t.Fatal("live event after the checkpoint was not processed")
+	}
+	assert.Empty(t, handled)
+}
+
+func TestReplay_RepublishesStoredEventsInRange(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, nil)
+
+	events := []*ContractEvent{
+		{
+			EventType:       "VestingScheduleCreated",
+			Beneficiary:     beneficiary,
+			Token:           "0x5D6709Ce17C956833b66Ade058832C1890af19b7",
+			Amount:          "1000",
+			BlockNumber:     10,
+			TransactionHash: "0xcreate",
+			Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+		},
+		{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100", BlockNumber: 11, TransactionHash: "0xrelease1"},
+		{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "200", BlockNumber: 15, TransactionHash: "0xrelease2"},
+	}
+	for _, event := range events {
+		require.NoError(t, el.processEvent(context.Background(), event))
+	}
+
+	subscriber := &mockPublisher{}
+	el.SetPublisher(subscriber)
+
+	replayed, err := el.Replay(context.Background(), 10, 11)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.Equal(t, events[:2], subscriber.published)
+
+	// Replay does not persist the events again
+	var count int64
+	require.NoError(t, db.DB.Model(&models.VestingEvent{}).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+
+	t.Run("Stops at the first failed publish", func(t *testing.T) {
+		el.SetPublisher(&mockPublisher{failures: 1})
+
+		replayed, err := el.Replay(context.Background(), 0, 100)
+		assert.Error(t, err)
+		assert.Equal(t, 0, replayed)
+	})
+}
@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// How orphaned events (events with no schedule row) are handled
+const (
+	OrphanedEventsReport   = "report"   // Log them only
+	OrphanedEventsPrune    = "prune"    // Delete them
+	OrphanedEventsBackfill = "backfill" // Recreate the missing schedule from chain
+)
+
+// OrphanedEventsResult summarizes one orphaned event sweep
+type OrphanedEventsResult struct {
+	Orphaned   []string // Beneficiaries with events but no schedule
+	Pruned     int64    // Events deleted
+	Backfilled int      // Schedules recreated from chain
+}
+
+// OrphanedEventsJob periodically finds events whose schedule row is missing,
+// usually left behind by out-of-order ingestion failures, and handles them
+// according to its mode
+type OrphanedEventsJob struct {
+	chain    ScheduleReader
+	db       *database.Database
+	mode     string
+	interval time.Duration
+}
+
+func NewOrphanedEventsJob(chain ScheduleReader, db *database.Database, mode string, interval time.Duration) *OrphanedEventsJob {
+	return &OrphanedEventsJob{
+		chain:    chain,
+		db:       db,
+		mode:     mode,
+		interval: interval,
+	}
+}
+
+// Start runs the sweep loop until the context is cancelled
+func (j *OrphanedEventsJob) Start(ctx context.Context) {
+	log.Printf("🧹 Checking for orphaned events every %s (mode: %s)", j.interval, j.mode)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			result, err := j.RunOnce(ctx)
+			if err != nil {
+				log.Printf("⚠️  Orphaned event sweep failed: %v", err)
+			} else if len(result.Orphaned) > 0 {
+				log.Printf("⚠️  Found events without schedules for %d beneficiaries (pruned %d events, backfilled %d schedules)",
+					len(result.Orphaned), result.Pruned, result.Backfilled)
+			}
+		case <-ctx.Done():
+			log.Println("🛑 Stopping orphaned event sweep")
+			return
+		}
+	}
+}
+
+// RunOnce finds orphaned events and reports, prunes, or backfills them
+func (j *OrphanedEventsJob) RunOnce(ctx context.Context) (OrphanedEventsResult, error) {
+	var result OrphanedEventsResult
+
+	orphaned, err := j.db.GetOrphanedEventBeneficiaries()
+	if err != nil {
+		return result, fmt.Errorf("failed to find orphaned events: %w", err)
+	}
+	result.Orphaned = orphaned
+
+	for _, beneficiary := range orphaned {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		switch j.mode {
+		case OrphanedEventsPrune:
+			pruned, err := j.db.DeleteEventsByBeneficiary(beneficiary)
+			if err != nil {
+				return result, fmt.Errorf("failed to prune events for %s: %w", beneficiary, err)
+			}
+			result.Pruned += pruned
+		case OrphanedEventsBackfill:
+			backfilled, err := j.backfill(ctx, beneficiary)
+			if err != nil {
+				log.Printf("⚠️  Failed to backfill schedule for %s: %v", beneficiary, err)
+				continue
+			}
+			if backfilled {
+				result.Backfilled++
+			}
+		default:
+			log.Printf("⚠️  Events exist for %s but no schedule is stored", beneficiary)
+		}
+	}
+
+	return result, nil
+}
+
+// backfill recreates a missing schedule from on-chain state. Reports false if
+// the contract has no schedule for the beneficiary either.
+func (j *OrphanedEventsJob) backfill(ctx context.Context, beneficiary string) (bool, error) {
+	onChain, err := j.chain.GetVestingSchedule(ctx, common.HexToAddress(beneficiary))
+	if err != nil {
+		return false, err
+	}
+	if onChain.Amount == nil || onChain.Amount.Sign() == 0 {
+		return false, nil
+	}
+
+	released := "0"
+	if onChain.Released != nil {
+		released = onChain.Released.String()
+	}
+
+	schedule := &models.VestingSchedule{
+		Beneficiary: beneficiary,
+		Start:       time.Unix(int64OrZero(onChain.Start), 0),
+		Cliff:       time.Unix(int64OrZero(onChain.Cliff), 0),
+		Duration:    int64OrZero(onChain.Duration),
+		Amount:      onChain.Amount.String(),
+		Released:    released,
+		Revocable:   onChain.Revocable,
+		Revoked:     onChain.Revoked,
+	}
+
+	return true, j.db.CreateOrUpdateSchedule(schedule)
+}
+
+// int64OrZero converts an optional contract integer, treating nil as zero
+func int64OrZero(value *big.Int) int64 {
+	if value == nil {
+		return 0
+	}
+	return value.Int64()
+}
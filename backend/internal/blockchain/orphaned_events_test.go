@@ -0,0 +1,122 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+	"github.com/kaldun-tech/token-vesting-backend/pkg/contracts"
+)
+
+// mockScheduleChain returns full on-chain schedules keyed by beneficiary
+type mockScheduleChain struct {
+	schedules map[string]*contracts.VestingSchedule
+}
+
+func (m *mockScheduleChain) GetVestingSchedule(ctx context.Context, beneficiary common.Address) (*contracts.VestingSchedule, error) {
+	schedule, ok := m.schedules[beneficiary.Hex()]
+	if !ok {
+		return nil, errors.New("rpc error")
+	}
+	return schedule, nil
+}
+
+// seedOrphanedEvent stores a scheduled beneficiary and an orphaned one, each with an event
+func seedOrphanedEvent(t *testing.T, db *database.Database, scheduled, orphaned string) {
+	require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: scheduled,
+		Start:       time.Now(),
+		Cliff:       time.Now(),
+		Duration:    1000,
+		Amount:      "1000",
+		Released:    "0",
+	}))
+
+	for i, beneficiary := range []string{scheduled, orphaned} {
+		require.NoError(t, db.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     beneficiary,
+			Amount:          "100",
+			BlockNumber:     uint64(10 + i),
+			TransactionHash: "0xtx" + beneficiary,
+			Timestamp:       time.Now(),
+		}))
+	}
+}
+
+func TestOrphanedEventsJob(t *testing.T) {
+	scheduled := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	orphaned := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+
+	chain := &mockScheduleChain{schedules: map[string]*contracts.VestingSchedule{
+		orphaned: {
+			Beneficiary: common.HexToAddress(orphaned),
+			Start:       big.NewInt(1700000000),
+			Cliff:       big.NewInt(1700003600),
+			Duration:    big.NewInt(7200),
+			Amount:      big.NewInt(5000),
+			Released:    big.NewInt(100),
+			Revocable:   true,
+		},
+	}}
+
+	t.Run("Report leaves events in place", func(t *testing.T) {
+		db := setupTestDB(t)
+		seedOrphanedEvent(t, db, scheduled, orphaned)
+
+		result, err := NewOrphanedEventsJob(chain, db, OrphanedEventsReport, time.Minute).RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{orphaned}, result.Orphaned)
+		assert.Zero(t, result.Pruned)
+		assert.Zero(t, result.Backfilled)
+
+		events, err := db.GetEventsByBeneficiary(orphaned, database.EventFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("Prune deletes orphaned events only", func(t *testing.T) {
+		db := setupTestDB(t)
+		seedOrphanedEvent(t, db, scheduled, orphaned)
+
+		result, err := NewOrphanedEventsJob(chain, db, OrphanedEventsPrune, time.Minute).RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.Pruned)
+
+		events, err := db.GetEventsByBeneficiary(orphaned, database.EventFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		events, err = db.GetEventsByBeneficiary(scheduled, database.EventFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("Backfill recreates the schedule from chain", func(t *testing.T) {
+		db := setupTestDB(t)
+		seedOrphanedEvent(t, db, scheduled, orphaned)
+
+		result, err := NewOrphanedEventsJob(chain, db, OrphanedEventsBackfill, time.Minute).RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Backfilled)
+
+		schedule, err := db.GetScheduleByBeneficiary(orphaned)
+		require.NoError(t, err)
+		assert.Equal(t, "5000", schedule.Amount)
+		assert.Equal(t, "100", schedule.Released)
+		assert.Equal(t, int64(7200), schedule.Duration)
+		assert.True(t, schedule.Revocable)
+
+		remaining, err := db.GetOrphanedEventBeneficiaries()
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+}
@@ -0,0 +1,88 @@
+package blockchain
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// abiWordSize is the size of one encoded static ABI value
+const abiWordSize = 32
+
+// partialEvent salvages a log whose data failed to unpack as a whole, when
+// DECODE_PARTIAL_EVENTS is enabled. Fields are decoded one at a time up to the
+// first that fails; the event keeps what was decoded and records the failing
+// field in DecodeError. Otherwise unpackErr is returned and the log is dropped.
+func (c *Client) partialEvent(event *ContractEvent, name string, vLog types.Log, unpackErr error) (*ContractEvent, error) {
+	if c.config == nil || !c.config.DecodePartialEvents {
+		return nil, unpackErr
+	}
+
+	values, field, err := unpackPartial(c.contractAbi.Events[name].Inputs, vLog.Data)
+	if field == "" {
+		// Every field decoded on its own, so the layout as a whole is wrong
+		field, err = "data", unpackErr
+	}
+
+	event.EventType = name
+	event.Beneficiary = common.HexToAddress(vLog.Topics[1].Hex()).Hex()
+	event.DecodeError = fmt.Sprintf("%s: %v", field, err)
+
+	decoded := func(key string) string {
+		if value, ok := values[key].(*big.Int); ok {
+			return value.String()
+		}
+		return ""
+	}
+	switch name {
+	case "VestingScheduleCreated":
+		event.Amount = decoded("amount")
+		event.Token = c.scheduleToken(c.contractAbi.Events[name], vLog)
+		event.Data = map[string]interface{}{}
+		for _, key := range []string{"start", "cliff", "duration"} {
+			if value := decoded(key); value != "" {
+				event.Data[key] = value
+			}
+		}
+	case "TokensReleased":
+		event.Amount = decoded("amount")
+	case "VestingRevoked":
+		event.Amount = decoded("refunded")
+	}
+
+	log.Printf("⚠️  Partially decoded %s log in tx %s (log %d): field %s failed: %v; raw data 0x%x",
+		name, event.TransactionHash, event.LogIndex, field, err, vLog.Data)
+	return event, nil
+}
+
+// unpackPartial decodes the non-indexed arguments of an event one by one,
+// stopping at the first that fails. Returns the decoded values by name, and
+// the name of the failing argument with its error; the name is empty when all
+// decoded. Only single-word static arguments can be decoded independently.
+func unpackPartial(arguments abi.Arguments, data []byte) (map[string]interface{}, string, error) {
+	values := make(map[string]interface{})
+	offset := 0
+	for _, argument := range arguments.NonIndexed() {
+		switch argument.Type.T {
+		case abi.IntTy, abi.UintTy, abi.BoolTy, abi.AddressTy, abi.FixedBytesTy, abi.HashTy:
+		default:
+			return values, argument.Name, fmt.Errorf("%s values cannot be decoded on their own", argument.Type)
+		}
+
+		if offset+abiWordSize > len(data) {
+			return values, argument.Name, fmt.Errorf("data is %d bytes, field needs bytes %d-%d", len(data), offset, offset+abiWordSize)
+		}
+
+		decoded, err := abi.Arguments{argument}.Unpack(data[offset : offset+abiWordSize])
+		if err != nil {
+			return values, argument.Name, err
+		}
+		values[argument.Name] = decoded[0]
+		offset += abiWordSize
+	}
+	return values, "", nil
+}
@@ -0,0 +1,18 @@
+package blockchain
+
+import "context"
+
+// EventPublisher forwards processed events to downstream consumers such as a
+// NATS subject or Kafka topic. Events are published only after they have been
+// persisted, and failed publishes are retried, so consumers see each event at
+// least once and must tolerate duplicates.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *ContractEvent) error
+}
+
+// NoopPublisher discards events; it is the default when no broker is configured
+type NoopPublisher struct{}
+
+func (NoopPublisher) Publish(ctx context.Context, event *ContractEvent) error {
+	return nil
+}
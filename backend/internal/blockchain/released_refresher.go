@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/pkg/contracts"
+)
+
+// ScheduleReader reads vesting schedule state from the contract
+type ScheduleReader interface {
+	GetVestingSchedule(ctx context.Context, beneficiary common.Address) (*contracts.VestingSchedule, error)
+}
+
+// ReleasedRefresher periodically reconciles stored released amounts with
+// on-chain state, catching releases that did not produce an indexed event
+type ReleasedRefresher struct {
+	chain     ScheduleReader
+	db        *database.Database
+	interval  time.Duration
+	batchSize int
+
+	freezeRevoked bool // Leave revoked schedules' released amounts as they stood at revocation
+}
+
+func NewReleasedRefresher(chain ScheduleReader, db *database.Database, interval time.Duration, batchSize int) *ReleasedRefresher {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &ReleasedRefresher{
+		chain:     chain,
+		db:        db,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// SetRevokedReleasePolicy applies the listener's policy for releases after a
+// revocation: under RevokedReleaseFreeze revoked schedules are not refreshed
+func (r *ReleasedRefresher) SetRevokedReleasePolicy(policy string) {
+	r.freezeRevoked = policy == RevokedReleaseFreeze
+}
+
+// Start runs the refresh loop until the context is cancelled
+func (r *ReleasedRefresher) Start(ctx context.Context) {
+	log.Printf("🔄 Refreshing released amounts every %s", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			updated, err := r.RefreshOnce(ctx)
+			if err != nil {
+				log.Printf("⚠️  Released amount refresh failed: %v", err)
+			} else if updated > 0 {
+				log.Printf("✅ Refreshed released amounts for %d schedules", updated)
+			}
+		case <-ctx.Done():
+			log.Println("🛑 Stopping released amount refresher")
+			return
+		}
+	}
+}
+
+// RefreshOnce reads on-chain released amounts and updates the stored schedules
+// that have fallen behind. The contract holds one schedule per beneficiary, so
+// only each beneficiary's newest stored schedule is compared; older ones in
+// multi-schedule mode are left alone. Returns the number of schedules updated.
+func (r *ReleasedRefresher) RefreshOnce(ctx context.Context) (int, error) {
+	updated := 0
+
+	for offset := 0; ; offset += r.batchSize {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+
+		schedules, err := r.db.GetAllSchedules(database.ScheduleFilter{IncludeRevoked: true}, r.batchSize, offset)
+		if err != nil {
+			return updated, fmt.Errorf("failed to load schedules: %w", err)
+		}
+
+		beneficiaries := make([]string, len(schedules))
+		for i, schedule := range schedules {
+			beneficiaries[i] = schedule.Beneficiary
+		}
+		latest, err := r.db.GetLatestScheduleIDs(beneficiaries)
+		if err != nil {
+			return updated, fmt.Errorf("failed to load newest schedules: %w", err)
+		}
+
+		for _, schedule := range schedules {
+			if latest[schedule.Beneficiary] != schedule.ID || (schedule.Revoked && r.freezeRevoked) {
+				continue
+			}
+
+			onChain, err := r.chain.GetVestingSchedule(ctx, common.HexToAddress(schedule.Beneficiary))
+			if err != nil {
+				log.Printf("⚠️  Failed to read on-chain schedule for %s: %v", schedule.Beneficiary, err)
+				continue
+			}
+			if onChain.Released == nil {
+				continue
+			}
+
+			stored, ok := new(big.Int).SetString(schedule.Released, 10)
+			if !ok {
+				stored = big.NewInt(0)
+			}
+
+			// Only move forward; released never decreases on-chain
+			if onChain.Released.Cmp(stored) <= 0 {
+				continue
+			}
+
+			if err := r.db.UpdateReleased(schedule.ID, onChain.Released.String()); err != nil {
+				return updated, fmt.Errorf("failed to update released for %s: %w", schedule.Beneficiary, err)
+			}
+			updated++
+		}
+
+		if len(schedules) < r.batchSize {
+			return updated, nil
+		}
+	}
+}
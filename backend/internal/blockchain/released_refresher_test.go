@@ -0,0 +1,140 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/database"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+	"github.com/kaldun-tech/token-vesting-backend/pkg/contracts"
+)
+
+// mockScheduleReader returns on-chain released amounts keyed by beneficiary
+type mockScheduleReader struct {
+	released map[string]*big.Int
+}
+
+func (m *mockScheduleReader) GetVestingSchedule(ctx context.Context, beneficiary common.Address) (*contracts.VestingSchedule, error) {
+	released, ok := m.released[beneficiary.Hex()]
+	if !ok {
+		return nil, errors.New("rpc error")
+	}
+	return &contracts.VestingSchedule{Beneficiary: beneficiary, Released: released}, nil
+}
+
+// setupTestDB creates an in-memory SQLite database for testing
+func setupTestDB(t testing.TB) *database.Database {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = gormDB.AutoMigrate(&models.VestingSchedule{}, &models.VestingEvent{}, &models.SyncCheckpoint{}, &models.SyncRange{}, &models.SyncState{}, &models.DeadLetterEvent{}, &models.OutboxEvent{})
+	require.NoError(t, err)
+
+	return &database.Database{DB: gormDB}
+}
+
+func TestReleasedRefresher_UpdatesAdvancedReleased(t *testing.T) {
+	db := setupTestDB(t)
+
+	advanced := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	unchanged := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	failing := "0x0000000000000000000000000000000000000001"
+
+	for _, beneficiary := range []string{advanced, unchanged, failing} {
+		err := db.CreateOrUpdateSchedule(&models.VestingSchedule{
+			Beneficiary: beneficiary,
+			Start:       time.Now(),
+			Cliff:       time.Now(),
+			Duration:    1000,
+			Amount:      "1000",
+			Released:    "100",
+		})
+		require.NoError(t, err)
+	}
+
+	chain := &mockScheduleReader{released: map[string]*big.Int{
+		advanced:  big.NewInt(400),
+		unchanged: big.NewInt(100),
+	}}
+
+	// Small batch size exercises pagination
+	refresher := NewReleasedRefresher(chain, db, time.Minute, 2)
+	updated, err := refresher.RefreshOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+
+	schedule, err := db.GetScheduleByBeneficiary(advanced)
+	require.NoError(t, err)
+	assert.Equal(t, "400", schedule.Released)
+
+	schedule, err = db.GetScheduleByBeneficiary(unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, "100", schedule.Released)
+
+	schedule, err = db.GetScheduleByBeneficiary(failing)
+	require.NoError(t, err)
+	assert.Equal(t, "100", schedule.Released)
+}
+
+func TestReleasedRefresher_UpdatesOnlyTheNewestSchedule(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	revokedOnly := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+
+	tests := []struct {
+		name            string
+		policy          string
+		revokedReleased string
+	}{
+		{name: "Apply policy refreshes revoked schedules", policy: RevokedReleaseApply, revokedReleased: "700"},
+		{name: "Freeze policy leaves revoked schedules", policy: RevokedReleaseFreeze, revokedReleased: "100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+
+			// An earlier schedule revoked before the beneficiary's current one was
+			// created, as kept in multi-schedule mode, and a beneficiary whose only
+			// schedule was revoked
+			schedules := []models.VestingSchedule{
+				{Beneficiary: beneficiary, Amount: "1000", Released: "300", Revoked: true, CreationTx: "0x01"},
+				{Beneficiary: beneficiary, Amount: "2000", Released: "100", CreationTx: "0x02"},
+				{Beneficiary: revokedOnly, Amount: "1000", Released: "100", Revoked: true, CreationTx: "0x03"},
+			}
+			require.NoError(t, db.DB.Create(&schedules).Error)
+
+			chain := &mockScheduleReader{released: map[string]*big.Int{
+				beneficiary: big.NewInt(500),
+				revokedOnly: big.NewInt(700),
+			}}
+			refresher := NewReleasedRefresher(chain, db, time.Minute, 2)
+			refresher.SetRevokedReleasePolicy(tt.policy)
+
+			_, err := refresher.RefreshOnce(context.Background())
+			require.NoError(t, err)
+
+			stored, err := db.GetSchedulesByBeneficiary(beneficiary)
+			require.NoError(t, err)
+			require.Len(t, stored, 2)
+			assert.Equal(t, "300", stored[0].Released, "the earlier schedule keeps its own released amount")
+			assert.Equal(t, "500", stored[1].Released)
+
+			stored, err = db.GetSchedulesByBeneficiary(revokedOnly)
+			require.NoError(t, err)
+			require.Len(t, stored, 1)
+			assert.Equal(t, tt.revokedReleased, stored[0].Released)
+		})
+	}
+}
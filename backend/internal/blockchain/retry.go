@@ -0,0 +1,145 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RetryPolicy bounds retries of RPC calls that fail transiently. The zero
+// value makes a single attempt.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts, including the first
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each retry
+	MaxDelay    time.Duration // Upper bound on the delay between attempts (0 is unbounded)
+}
+
+// delay returns how long to wait before the given retry (1 for the first)
+func (p RetryPolicy) delay(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// do runs call until it succeeds, fails with an error that is not transient,
+// or the attempts are exhausted, returning the last error. Waiting between
+// attempts stops early when ctx is done.
+func (p RetryPolicy) do(ctx context.Context, method string, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= p.MaxAttempts || !isTransientRPCError(ctx, err) {
+			return err
+		}
+
+		delay := p.delay(attempt)
+		log.Printf("🔄 RPC %s failed (attempt %d/%d), retrying in %s: %v", method, attempt, p.MaxAttempts, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// isTransientRPCError reports whether a failed call is worth retrying: network
+// failures, timeouts, rate limiting and server errors. Cancellation by the
+// caller and errors returned by the node itself, such as reverts, are final.
+func isTransientRPCError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// rpcBackend is the subset of node calls made through the retry policy
+type rpcBackend interface {
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// retryingBackend retries log queries, header reads, code reads, receipt
+// reads and contract calls that fail transiently. It satisfies bind.ContractCaller so
+// contract bindings can be built on it.
+type retryingBackend struct {
+	rpcBackend
+	policy RetryPolicy
+}
+
+// FilterLogs runs a log query, retrying transient failures
+func (b *retryingBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = b.policy.do(ctx, "eth_getLogs", func() error {
+		logs, err = b.rpcBackend.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// HeaderByNumber reads a block header, retrying transient failures
+func (b *retryingBackend) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = b.policy.do(ctx, "eth_getBlockByNumber", func() error {
+		header, err = b.rpcBackend.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// CodeAt reads a contract's code at a block, retrying transient failures
+func (b *retryingBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = b.policy.do(ctx, "eth_getCode", func() error {
+		code, err = b.rpcBackend.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// CallContract executes a read-only contract call, retrying transient failures
+func (b *retryingBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (output []byte, err error) {
+	err = b.policy.do(ctx, "eth_call", func() error {
+		output, err = b.rpcBackend.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return output, err
+}
+
+// TransactionReceipt reads a transaction receipt, retrying transient failures
+func (b *retryingBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = b.policy.do(ctx, "eth_getTransactionReceipt", func() error {
+		receipt, err = b.rpcBackend.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyBackend returns the queued errors in turn, repeating the last one;
+// a nil error lets the call succeed
+type flakyBackend struct {
+	rpcBackend
+	failures []error
+	calls    int
+}
+
+func (f *flakyBackend) next() error {
+	f.calls++
+	if len(f.failures) == 0 {
+		return nil
+	}
+	err := f.failures[0]
+	if len(f.failures) > 1 {
+		f.failures = f.failures[1:]
+	}
+	return err
+}
+
+func (f *flakyBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return []types.Log{{BlockNumber: 42}}, nil
+}
+
+func (f *flakyBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return &types.Header{Number: big.NewInt(100)}, nil
+}
+
+// CodeAt reports contract code from block 40 on
+func (f *flakyBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	if blockNumber.Uint64() < 40 {
+		return nil, nil
+	}
+	return []byte{0x1}, nil
+}
+
+// CallContract answers every call with 6, as a token's decimals
+func (f *flakyBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return common.LeftPadBytes([]byte{6}, 32), nil
+}
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+func TestRetryingBackend_RecoversFromTransientFailures(t *testing.T) {
+	backend := &flakyBackend{failures: []error{
+		rpc.HTTPError{StatusCode: http.StatusTooManyRequests},
+		rpc.HTTPError{StatusCode: http.StatusBadGateway},
+		nil,
+	}}
+	node := &retryingBackend{rpcBackend: backend, policy: testRetryPolicy}
+
+	logs, err := node.FilterLogs(context.Background(), ethereum.FilterQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.Log{{BlockNumber: 42}}, logs)
+	assert.Equal(t, 3, backend.calls)
+}
+
+func TestRetryingBackend_GivesUp(t *testing.T) {
+	t.Run("Transient errors are returned once attempts are exhausted", func(t *testing.T) {
+		unavailable := rpc.HTTPError{StatusCode: http.StatusServiceUnavailable}
+		backend := &flakyBackend{failures: []error{unavailable}}
+		node := &retryingBackend{rpcBackend: backend, policy: testRetryPolicy}
+
+		_, err := node.FilterLogs(context.Background(), ethereum.FilterQuery{})
+		assert.Equal(t, unavailable, err)
+		assert.Equal(t, 3, backend.calls)
+	})
+
+	t.Run("Errors from the node are not retried", func(t *testing.T) {
+		backend := &flakyBackend{failures: []error{errors.New("query returned more than 10000 results")}}
+		node := &retryingBackend{rpcBackend: backend, policy: testRetryPolicy}
+
+		_, err := node.FilterLogs(context.Background(), ethereum.FilterQuery{})
+		assert.Error(t, err)
+		assert.Equal(t, 1, backend.calls)
+	})
+
+	t.Run("Cancellation is not retried", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		backend := &flakyBackend{failures: []error{rpc.HTTPError{StatusCode: http.StatusBadGateway}}}
+		node := &retryingBackend{rpcBackend: backend, policy: testRetryPolicy}
+
+		_, err := node.FilterLogs(ctx, ethereum.FilterQuery{})
+		assert.Error(t, err)
+		assert.Equal(t, 1, backend.calls)
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2))
+	assert.Equal(t, 800*time.Millisecond, policy.delay(4))
+	assert.Equal(t, time.Second, policy.delay(5))
+	assert.Equal(t, time.Second, policy.delay(64))
+}
+
+func TestGetLatestBlockNumber_RetriesRateLimitedNode(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= 2 {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		header := &types.Header{Number: big.NewInt(1234), Difficulty: big.NewInt(0)}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": header}))
+	}))
+	t.Cleanup(server.Close)
+
+	ethClient, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(ethClient.Close)
+	client := &Client{ethClient: ethClient, node: &retryingBackend{rpcBackend: ethClient, policy: testRetryPolicy}}
+
+	head, err := client.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234), head)
+	assert.Equal(t, 3, requests)
+}
+
+func TestStartupLookups_RetryTransientFailures(t *testing.T) {
+	unavailable := rpc.HTTPError{StatusCode: http.StatusServiceUnavailable}
+
+	t.Run("Deployment block", func(t *testing.T) {
+		// The head read and a code read each fail once
+		backend := &flakyBackend{failures: []error{unavailable, nil, unavailable, nil}}
+		client := &Client{node: &retryingBackend{rpcBackend: backend, policy: testRetryPolicy}}
+
+		block, err := client.FindDeploymentBlock(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(40), block)
+	})
+
+	t.Run("Token decimals", func(t *testing.T) {
+		backend := &flakyBackend{failures: []error{unavailable, nil}}
+		client := &Client{node: &retryingBackend{rpcBackend: backend, policy: testRetryPolicy}}
+
+		decimals, err := client.GetTokenDecimals(context.Background(), common.HexToAddress("0x5d6709ce17C956833B66aDe058832c1890aF19b7"))
+		require.NoError(t, err)
+		assert.Equal(t, uint8(6), decimals)
+		assert.Equal(t, 2, backend.calls)
+	})
+}
@@ -0,0 +1,170 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// HeadReader reads the current chain head
+type HeadReader interface {
+	GetLatestBlockNumber(ctx context.Context) (uint64, error)
+}
+
+// ProcessedBlockReader reads the highest block the indexer has persisted
+type ProcessedBlockReader interface {
+	GetLastProcessedBlock() (uint64, error)
+}
+
+// BacklogReader reports events waiting to be processed
+type BacklogReader interface {
+	Backlog() (buffered, retrying, unpublished int)
+}
+
+// SyncSample is one observation of chain head and indexer progress
+type SyncSample struct {
+	At        time.Time `json:"at"`
+	Head      uint64    `json:"head"`
+	Processed uint64    `json:"processed"`
+	Pending   int       `json:"pending"`
+}
+
+// SyncProgress summarizes whether the indexer is keeping up with the chain
+type SyncProgress struct {
+	Healthy   bool   `json:"healthy"`
+	Reason    string `json:"reason,omitempty"`
+	Head      uint64 `json:"head"`
+	Processed uint64 `json:"processed"`
+	Lag       uint64 `json:"lag"` // Blocks between head and processed while events are pending; 0 when caught up
+	Samples   int    `json:"samples"`
+	RPCError  string `json:"rpc_error,omitempty"` // Why the last head read failed, if it did
+}
+
+// SyncProgressMonitor keeps a rolling history of chain head and last processed
+// block and flags an indexer that stops advancing while the chain keeps moving.
+//
+// The last processed block only moves when an event is persisted, so a quiet
+// contract looks idle rather than stuck; the indexer is only considered stuck
+// when events are pending and none have been persisted over the whole window.
+type SyncProgressMonitor struct {
+	head      HeadReader
+	processed ProcessedBlockReader
+	backlog   BacklogReader // Optional
+	window    time.Duration
+
+	mu      sync.Mutex
+	history []SyncSample
+	headErr error // Outcome of the last head read
+}
+
+func NewSyncProgressMonitor(head HeadReader, processed ProcessedBlockReader, backlog BacklogReader, window time.Duration) *SyncProgressMonitor {
+	return &SyncProgressMonitor{
+		head:      head,
+		processed: processed,
+		backlog:   backlog,
+		window:    window,
+	}
+}
+
+// Start samples sync progress several times per window until the context is cancelled
+func (m *SyncProgressMonitor) Start(ctx context.Context) {
+	log.Printf("🩺 Monitoring sync progress over a %s window", m.window)
+
+	ticker := time.NewTicker(m.window / 4)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sample(ctx, time.Now()); err != nil {
+			log.Printf("⚠️  Sync progress sample failed: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Println("🛑 Stopping sync progress monitor")
+			return
+		}
+	}
+}
+
+// Sample records the current chain head and processed block
+func (m *SyncProgressMonitor) Sample(ctx context.Context, now time.Time) error {
+	head, err := m.head.GetLatestBlockNumber(ctx)
+	m.mu.Lock()
+	m.headErr = err
+	m.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to read chain head: %w", err)
+	}
+
+	processed, err := m.processed.GetLastProcessedBlock()
+	if err != nil {
+		return fmt.Errorf("failed to read last processed block: %w", err)
+	}
+
+	pending := 0
+	if m.backlog != nil {
+		buffered, retrying, _ := m.backlog.Backlog()
+		pending = buffered + retrying
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.history = append(m.history, SyncSample{At: now, Head: head, Processed: processed, Pending: pending})
+
+	// Keep one sample at or beyond the window so there is always a full-window baseline
+	cutoff := now.Add(-m.window)
+	drop := 0
+	for drop+1 < len(m.history) && !m.history[drop+1].At.After(cutoff) {
+		drop++
+	}
+	m.history = m.history[drop:]
+
+	return nil
+}
+
+// Progress compares the newest sample with the oldest one in the window
+func (m *SyncProgressMonitor) Progress() SyncProgress {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rpcError string
+	if m.headErr != nil {
+		rpcError = m.headErr.Error()
+	}
+
+	if len(m.history) == 0 {
+		return SyncProgress{Healthy: true, Reason: "no samples yet", RPCError: rpcError}
+	}
+
+	latest := m.history[len(m.history)-1]
+	baseline := m.history[0]
+	progress := SyncProgress{
+		Healthy:   true,
+		Head:      latest.Head,
+		Processed: latest.Processed,
+		Samples:   len(m.history),
+		RPCError:  rpcError,
+	}
+
+	// The processed block only moves with events, so a gap to the head is
+	// only lag while events are waiting
+	if latest.Pending > 0 && latest.Head > latest.Processed {
+		progress.Lag = latest.Head - latest.Processed
+	}
+
+	if latest.At.Sub(baseline.At) < m.window {
+		return progress
+	}
+
+	if latest.Head > baseline.Head && latest.Processed <= baseline.Processed && latest.Pending > 0 {
+		progress.Healthy = false
+		progress.Reason = fmt.Sprintf("indexer stuck at block %d for %s while head advanced %d blocks with %d events pending",
+			latest.Processed, latest.At.Sub(baseline.At).Round(time.Second), latest.Head-baseline.Head, latest.Pending)
+	}
+
+	return progress
+}
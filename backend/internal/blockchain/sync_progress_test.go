@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSyncState serves scripted head, processed block, and backlog values
+type fakeSyncState struct {
+	head      uint64
+	processed uint64
+	pending   int
+	headErr   error
+}
+
+func (f *fakeSyncState) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	return f.head, f.headErr
+}
+
+func (f *fakeSyncState) GetLastProcessedBlock() (uint64, error) {
+	return f.processed, nil
+}
+
+func (f *fakeSyncState) Backlog() (buffered, retrying, unpublished int) {
+	return f.pending, 0, 0
+}
+
+func TestSyncProgressMonitor_StuckIndexer(t *testing.T) {
+	state := &fakeSyncState{head: 1000, processed: 990, pending: 3}
+	monitor := NewSyncProgressMonitor(state, state, state, time.Minute)
+	start := time.Unix(1700000000, 0)
+
+	for i := 0; i <= 4; i++ {
+		require.NoError(t, monitor.Sample(context.Background(), start.Add(time.Duration(i)*15*time.Second)))
+		state.head += 5
+	}
+
+	progress := monitor.Progress()
+	assert.False(t, progress.Healthy)
+	assert.Contains(t, progress.Reason, "stuck at block 990")
+	assert.Equal(t, uint64(1020), progress.Head)
+	assert.Equal(t, uint64(990), progress.Processed)
+	assert.Equal(t, uint64(30), progress.Lag)
+}
+
+func TestSyncProgressMonitor_Lag(t *testing.T) {
+	state := &fakeSyncState{head: 1000, processed: 990}
+	monitor := NewSyncProgressMonitor(state, state, state, time.Minute)
+	start := time.Unix(1700000000, 0)
+
+	// A quiet contract is not behind, however old its last event
+	require.NoError(t, monitor.Sample(context.Background(), start))
+	assert.Zero(t, monitor.Progress().Lag)
+
+	state.pending = 2
+	require.NoError(t, monitor.Sample(context.Background(), start.Add(15*time.Second)))
+	assert.Equal(t, uint64(10), monitor.Progress().Lag)
+}
+
+func TestSyncProgressMonitor_ReportsHeadReadFailure(t *testing.T) {
+	state := &fakeSyncState{head: 1000, processed: 990}
+	monitor := NewSyncProgressMonitor(state, state, state, time.Minute)
+	start := time.Unix(1700000000, 0)
+
+	state.headErr = errors.New("connection refused")
+	require.Error(t, monitor.Sample(context.Background(), start))
+	assert.Contains(t, monitor.Progress().RPCError, "connection refused")
+
+	// The next successful read clears it
+	state.headErr = nil
+	require.NoError(t, monitor.Sample(context.Background(), start.Add(15*time.Second)))
+	assert.Empty(t, monitor.Progress().RPCError)
+}
+
+func TestSyncProgressMonitor_Healthy(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		advance func(s *fakeSyncState)
+	}{
+		{
+			name: "indexer advancing",
+			advance: func(s *fakeSyncState) {
+				s.head += 5
+				s.processed += 5
+			},
+		},
+		{
+			name: "head not advancing",
+			advance: func(s *fakeSyncState) {
+				s.pending = 3
+			},
+		},
+		{
+			name: "quiet contract with nothing pending",
+			advance: func(s *fakeSyncState) {
+				s.head += 5
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &fakeSyncState{head: 1000, processed: 990}
+			monitor := NewSyncProgressMonitor(state, state, state, time.Minute)
+
+			for i := 0; i <= 4; i++ {
+				require.NoError(t, monitor.Sample(context.Background(), start.Add(time.Duration(i)*15*time.Second)))
+				tt.advance(state)
+			}
+
+			assert.True(t, monitor.Progress().Healthy)
+		})
+	}
+}
+
+func TestSyncProgressMonitor_WaitsForFullWindow(t *testing.T) {
+	state := &fakeSyncState{head: 1000, processed: 990, pending: 3}
+	monitor := NewSyncProgressMonitor(state, state, state, time.Minute)
+	start := time.Unix(1700000000, 0)
+
+	assert.True(t, monitor.Progress().Healthy)
+
+	require.NoError(t, monitor.Sample(context.Background(), start))
+	state.head += 5
+	require.NoError(t, monitor.Sample(context.Background(), start.Add(30*time.Second)))
+
+	assert.True(t, monitor.Progress().Healthy)
+}
+
+func TestSyncProgressMonitor_TrimsHistory(t *testing.T) {
+	state := &fakeSyncState{head: 1000, processed: 990}
+	monitor := NewSyncProgressMonitor(state, state, state, time.Minute)
+	start := time.Unix(1700000000, 0)
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, monitor.Sample(context.Background(), start.Add(time.Duration(i)*15*time.Second)))
+	}
+
+	// Samples within the window plus one baseline at the window edge
+	assert.Equal(t, 5, monitor.Progress().Samples)
+}
@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"sync"
+	"time"
+)
+
+// throughputWindows are the trailing windows reported by Throughput, in minutes
+var throughputWindows = []int{1, 5, 15, 60}
+
+// ThroughputWindow is the processing rate over one trailing window
+type ThroughputWindow struct {
+	Window    string  `json:"window"`
+	Events    int     `json:"events"`
+	PerMinute float64 `json:"per_minute"`
+}
+
+// throughputCounter counts processed events in per-minute buckets covering the
+// last hour. Buckets are reused as the clock moves on.
+type throughputCounter struct {
+	mu      sync.Mutex
+	counts  [60]int
+	minutes [60]int64 // Unix minute each bucket currently holds
+}
+
+// record counts one processed event at the given time
+func (t *throughputCounter) record(now time.Time) {
+	minute := now.Unix() / 60
+	i := minute % int64(len(t.counts))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.minutes[i] != minute {
+		t.minutes[i] = minute
+		t.counts[i] = 0
+	}
+	t.counts[i]++
+}
+
+// windows reports event counts and rates over each trailing window, including
+// the current partial minute
+func (t *throughputCounter) windows(now time.Time) []ThroughputWindow {
+	current := now.Unix() / 60
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]ThroughputWindow, 0, len(throughputWindows))
+	for _, size := range throughputWindows {
+		events := 0
+		for minute := current - int64(size) + 1; minute <= current; minute++ {
+			i := minute % int64(len(t.counts))
+			if t.minutes[i] == minute {
+				events += t.counts[i]
+			}
+		}
+
+		result = append(result, ThroughputWindow{
+			Window:    (time.Duration(size) * time.Minute).String(),
+			Events:    events,
+			PerMinute: float64(events) / float64(size),
+		})
+	}
+	return result
+}
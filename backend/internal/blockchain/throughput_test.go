@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThroughputCounter_Windows(t *testing.T) {
+	var counter throughputCounter
+	now := time.Unix(1700000000, 0)
+
+	// 10 events three minutes ago and 5 in the current minute
+	for i := 0; i < 10; i++ {
+		counter.record(now.Add(-3 * time.Minute))
+	}
+	for i := 0; i < 5; i++ {
+		counter.record(now)
+	}
+
+	windows := counter.windows(now)
+	require.Len(t, windows, 4)
+
+	assert.Equal(t, ThroughputWindow{Window: "1m0s", Events: 5, PerMinute: 5}, windows[0])
+	assert.Equal(t, ThroughputWindow{Window: "5m0s", Events: 15, PerMinute: 3}, windows[1])
+	assert.Equal(t, 15, windows[3].Events)
+
+	// Events older than the hour window age out
+	later := now.Add(57 * time.Minute)
+	counter.record(later)
+	assert.Equal(t, 6, counter.windows(later)[3].Events)
+	assert.Equal(t, 0, counter.windows(later.Add(time.Hour))[3].Events)
+}
+
+func TestThroughput_IncreasesAfterBurst(t *testing.T) {
+	el := NewEventListener(nil, setupTestDB(t), nil)
+
+	before := el.Throughput()
+	assert.Zero(t, before[0].PerMinute)
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, el.processEvent(context.Background(), &ContractEvent{
+			EventType:       "VestingScheduleCreated",
+			Beneficiary:     fmt.Sprintf("0x%040x", i+1),
+			Amount:          "1000",
+			BlockNumber:     uint64(i),
+			TransactionHash: fmt.Sprintf("0xtx%d", i),
+			Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+		}))
+	}
+
+	after := el.Throughput()
+	assert.Greater(t, after[0].PerMinute, before[0].PerMinute)
+	assert.Equal(t, 20, after[len(after)-1].Events)
+}
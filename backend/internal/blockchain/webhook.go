@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// Headers sent with each webhook delivery
+const (
+	// WebhookSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the
+	// request body keyed by the webhook secret
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookEventHeader carries the event type, so receivers can route
+	// without parsing the body
+	WebhookEventHeader = "X-Webhook-Event"
+)
+
+// WebhookNotifier POSTs newly recorded events to an HTTP endpoint. Events are
+// queued and delivered in order by a single worker, so a slow endpoint never
+// holds up ingestion; when the queue is full, new events are dropped.
+type WebhookNotifier struct {
+	url        string
+	secret     string
+	eventTypes map[string]bool // Nil delivers every type
+	retry      RetryPolicy
+	client     *http.Client
+	queue      chan models.VestingEvent
+}
+
+// NewWebhookNotifier creates a notifier for a webhook configuration. Call
+// Start to begin delivering.
+func NewWebhookNotifier(cfg config.WebhookConfig) *WebhookNotifier {
+	var eventTypes map[string]bool
+	if len(cfg.EventTypes) > 0 {
+		eventTypes = make(map[string]bool, len(cfg.EventTypes))
+		for _, eventType := range cfg.EventTypes {
+			eventTypes[eventType] = true
+		}
+	}
+
+	return &WebhookNotifier{
+		url:        cfg.URL,
+		secret:     cfg.Secret,
+		eventTypes: eventTypes,
+		retry:      RetryPolicy{MaxAttempts: max(cfg.MaxAttempts, 1), BaseDelay: cfg.RetryBackoff},
+		client:     &http.Client{Timeout: cfg.Timeout},
+		queue:      make(chan models.VestingEvent, max(cfg.QueueSize, 1)),
+	}
+}
+
+// Notify queues an event for delivery without blocking. Reports false when the
+// event was dropped because the queue is full; filtered event types report true.
+func (w *WebhookNotifier) Notify(event models.VestingEvent) bool {
+	if w.eventTypes != nil && !w.eventTypes[event.EventType] {
+		return true
+	}
+
+	select {
+	case w.queue <- event:
+		return true
+	default:
+		log.Printf("⚠️  Webhook queue full, dropping %s event in tx %s (log %d)", event.EventType, event.TransactionHash, event.LogIndex)
+		return false
+	}
+}
+
+// Start delivers queued events until ctx is cancelled
+func (w *WebhookNotifier) Start(ctx context.Context) {
+	for {
+		select {
+		case event := <-w.queue:
+			if err := w.deliver(ctx, event); err != nil {
+				log.Printf("❌ Webhook delivery failed for %s event in tx %s (log %d): %v", event.EventType, event.TransactionHash, event.LogIndex, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver POSTs an event, retrying failed requests and non-2xx responses with
+// backoff until the attempts are exhausted
+func (w *WebhookNotifier) deliver(ctx context.Context, event models.VestingEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	signature := SignWebhookBody(w.secret, body)
+
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, event.EventType, body, signature)
+		if err == nil || attempt >= w.retry.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := w.retry.delay(attempt)
+		log.Printf("🔄 Webhook delivery failed (attempt %d/%d), retrying in %s: %v", attempt, w.retry.MaxAttempts, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// post sends one delivery attempt
+func (w *WebhookNotifier) post(ctx context.Context, eventType string, body []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, eventType)
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// SignWebhookBody returns the signature header value for a webhook body:
+// "sha256=" followed by the hex HMAC-SHA256 of the body keyed by secret.
+// Receivers recompute it over the raw body and compare in constant time.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,160 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/config"
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// webhookDelivery is a request received by the test endpoint
+type webhookDelivery struct {
+	body      []byte
+	signature string
+	eventType string
+}
+
+func TestWebhookNotifier_SignsDeliveries(t *testing.T) {
+	const secret = "s3cret"
+	deliveries := make(chan webhookDelivery, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		deliveries <- webhookDelivery{body: body, signature: r.Header.Get(WebhookSignatureHeader), eventType: r.Header.Get(WebhookEventHeader)}
+	}))
+	defer server.Close()
+
+	webhook := NewWebhookNotifier(config.WebhookConfig{
+		URL:         server.URL,
+		Secret:      secret,
+		EventTypes:  []string{"TokensReleased", "VestingRevoked"},
+		QueueSize:   10,
+		MaxAttempts: 1,
+		Timeout:     time.Second,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go webhook.Start(ctx)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	db := setupTestDB(t)
+	el := NewEventListener(nil, db, nil)
+	el.SetWebhookNotifier(webhook)
+
+	// Creation events are filtered out; the release is delivered once applied
+	require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000",
+		BlockNumber:     10,
+		TransactionHash: "0xcreate",
+		Data:            map[string]interface{}{"start": "1700000000", "cliff": "1700000000", "duration": "3600"},
+	}))
+	require.NoError(t, el.handleEvent(context.Background(), &ContractEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     beneficiary,
+		Amount:          "250",
+		BlockNumber:     11,
+		TransactionHash: "0xrelease",
+	}))
+
+	var delivery webhookDelivery
+	select {
+	case delivery = <-deliveries:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivered")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(delivery.body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), delivery.signature)
+	assert.Equal(t, "TokensReleased", delivery.eventType)
+
+	var event models.VestingEvent
+	require.NoError(t, json.Unmarshal(delivery.body, &event))
+	assert.Equal(t, "TokensReleased", event.EventType)
+	assert.Equal(t, beneficiary, event.Beneficiary)
+	assert.Equal(t, "250", event.Amount)
+	assert.NotZero(t, event.ID)
+
+	select {
+	case extra := <-deliveries:
+		t.Fatalf("unexpected delivery of %s", extra.eventType)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWebhookNotifier_RetriesServerErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		failures    int32 // Requests answered with 500 before succeeding
+		maxAttempts int
+		attempts    int32
+		delivered   bool
+	}{
+		{name: "Recovers after retries", failures: 2, maxAttempts: 5, attempts: 3, delivered: true},
+		{name: "Gives up after max attempts", failures: 10, maxAttempts: 3, attempts: 3, delivered: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			webhook := NewWebhookNotifier(config.WebhookConfig{
+				URL:          server.URL,
+				QueueSize:    1,
+				MaxAttempts:  tt.maxAttempts,
+				RetryBackoff: time.Millisecond,
+				Timeout:      time.Second,
+			})
+
+			err := webhook.deliver(context.Background(), models.VestingEvent{EventType: "VestingRevoked", Amount: "10"})
+			if tt.delivered {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "500")
+			}
+			assert.Equal(t, tt.attempts, requests.Load())
+		})
+	}
+}
+
+func TestWebhookNotifier_BoundedQueue(t *testing.T) {
+	webhook := NewWebhookNotifier(config.WebhookConfig{URL: "http://127.0.0.1:1", QueueSize: 2, MaxAttempts: 1})
+
+	// Without a running worker, as with a stalled endpoint, the queue fills
+	// and further events are dropped instead of blocking the caller
+	event := models.VestingEvent{EventType: "TokensReleased"}
+	assert.True(t, webhook.Notify(event))
+	assert.True(t, webhook.Notify(event))
+
+	done := make(chan bool)
+	go func() { done <- webhook.Notify(event) }()
+	select {
+	case queued := <-done:
+		assert.False(t, queued)
+	case <-time.After(time.Second):
+		t.Fatal("Notify blocked on a full queue")
+	}
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm/clause"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// allocationFile is the on-disk format of a merkle allocation set
+type allocationFile struct {
+	Root        string `json:"root"`
+	Allocations []struct {
+		Beneficiary string   `json:"beneficiary"`
+		Amount      string   `json:"amount"`
+		Leaf        string   `json:"leaf"`
+		Proof       []string `json:"proof"`
+	} `json:"allocations"`
+}
+
+// LoadAllocationFile reads a merkle allocation set from a JSON file
+func LoadAllocationFile(path string) ([]models.MerkleAllocation, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allocation file: %w", err)
+	}
+
+	var file allocationFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse allocation file: %w", err)
+	}
+
+	allocations := make([]models.MerkleAllocation, 0, len(file.Allocations))
+	for i, entry := range file.Allocations {
+		if !common.IsHexAddress(entry.Beneficiary) {
+			return nil, fmt.Errorf("allocation %d: invalid beneficiary %q", i, entry.Beneficiary)
+		}
+		if entry.Leaf == "" {
+			return nil, fmt.Errorf("allocation %d: missing leaf", i)
+		}
+
+		proof := entry.Proof
+		if proof == nil {
+			proof = []string{}
+		}
+
+		allocations = append(allocations, models.MerkleAllocation{
+			Beneficiary: common.HexToAddress(entry.Beneficiary).Hex(),
+			Amount:      entry.Amount,
+			Leaf:        entry.Leaf,
+			Proof:       proof,
+			Root:        file.Root,
+		})
+	}
+
+	return allocations, nil
+}
+
+// UpsertMerkleAllocations stores allocations, replacing any existing entry per beneficiary
+func (d *Database) UpsertMerkleAllocations(allocations []models.MerkleAllocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+	return d.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "beneficiary"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount", "leaf", "proof", "root", "updated_at"}),
+	}).Create(&allocations).Error
+}
+
+// GetMerkleAllocation retrieves the merkle allocation for a beneficiary.
+// Returns gorm.ErrRecordNotFound when the beneficiary has no allocation.
+func (d *Database) GetMerkleAllocation(beneficiary string) (*models.MerkleAllocation, error) {
+	var allocation models.MerkleAllocation
+	result := d.DB.Where("beneficiary = ?", beneficiary).First(&allocation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &allocation, nil
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAllocationFile = `{
+	"root": "0x1111111111111111111111111111111111111111111111111111111111111111",
+	"allocations": [
+		{
+			"beneficiary": "0xf25da65784d566ffcc60a1f113650afb688a14ed",
+			"amount": "1000000000000000000000",
+			"leaf": "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			"proof": [
+				"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+				"0xcccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+			]
+		},
+		{
+			"beneficiary": "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea",
+			"amount": "500000000000000000000",
+			"leaf": "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			"proof": ["0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"]
+		}
+	]
+}`
+
+// writeAllocationFile writes content to a temporary allocation file
+func writeAllocationFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "allocations.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadAndGetMerkleAllocation(t *testing.T) {
+	db := setupTestDB(t)
+
+	allocations, err := LoadAllocationFile(writeAllocationFile(t, testAllocationFile))
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+
+	require.NoError(t, db.UpsertMerkleAllocations(allocations))
+
+	// Addresses are stored checksummed
+	allocation, err := db.GetMerkleAllocation("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000", allocation.Amount)
+	assert.Equal(t, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", allocation.Leaf)
+	assert.Equal(t, []string{
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		"0xcccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc",
+	}, allocation.Proof)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111111111111111111111111111", allocation.Root)
+
+	// Reloading replaces rather than duplicates
+	require.NoError(t, db.UpsertMerkleAllocations(allocations))
+	var count int64
+	db.DB.Table("merkle_allocations").Count(&count)
+	assert.Equal(t, int64(2), count)
+
+	_, err = db.GetMerkleAllocation("0x0000000000000000000000000000000000000999")
+	assert.Error(t, err)
+}
+
+func TestLoadAllocationFile_InvalidBeneficiary(t *testing.T) {
+	_, err := LoadAllocationFile(writeAllocationFile(t, `{"allocations": [{"beneficiary": "nope", "leaf": "0x01"}]}`))
+	assert.Error(t, err)
+}
@@ -1,24 +1,293 @@
 package database
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
+	"strings"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 
 	"github.com/kaldun-tech/token-vesting-backend/internal/models"
 )
 
+// maxUpdateAttempts bounds retries of optimistic-locked schedule updates
+const maxUpdateAttempts = 10
+
+// ErrConcurrentUpdate is returned when a schedule update keeps losing races to other writers
+var ErrConcurrentUpdate = errors.New("schedule was modified concurrently")
+
+// ErrDuplicateEvent is returned by CreateEvent when the event was already recorded
+var ErrDuplicateEvent = errors.New("event already recorded")
+
+// Status filters accepted by GetSchedulesByStatus
+const (
+	StatusFilterActive    = "active"    // Not revoked, in any phase
+	StatusFilterCliff     = "cliff"     // Not revoked, before the cliff (including not yet started)
+	StatusFilterVesting   = "vesting"   // Not revoked, past the cliff but not fully vested
+	StatusFilterCompleted = "completed" // Not revoked, fully vested: now >= start+duration
+	StatusFilterRevoked   = "revoked"   // Revoked by the owner
+)
+
+// StatusFilters lists the accepted status filters in lifecycle order
+var StatusFilters = []string{StatusFilterActive, StatusFilterCliff, StatusFilterVesting, StatusFilterCompleted, StatusFilterRevoked}
+
+// ErrUnknownStatus is returned for a status filter not in StatusFilters
+var ErrUnknownStatus = errors.New("unknown schedule status")
+
+// ErrInvalidCursor is returned when a pagination cursor points at no row
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// legacyEventTxIndex is the former unique index on transaction_hash alone, which
+// rejected transactions emitting more than one event
+const legacyEventTxIndex = "idx_vesting_events_transaction_hash"
+
 type Database struct {
 	DB *gorm.DB
+
+	// scheduleOrder is the default ordering for schedule listings (ID ascending when unset)
+	scheduleOrder ScheduleOrder
+	// scheduleMode selects how schedules are matched on upsert (single when unset)
+	scheduleMode ScheduleMode
+	// revocationMode selects whether revoked schedules are kept or deleted (flag when unset)
+	revocationMode RevocationMode
+}
+
+// ScheduleFilter holds optional constraints applied to schedule listings
+type ScheduleFilter struct {
+	Token          string // Token contract address
+	Label          string // Exact label the schedule must carry
+	IncludeRevoked bool   // Include revoked schedules, which are excluded by default
+	AfterID        uint   // Cursor: only schedules ordered after this one (0 starts at the beginning)
+}
+
+// apply adds the filter's conditions to a query
+func (f ScheduleFilter) apply(query *gorm.DB) *gorm.DB {
+	if !f.IncludeRevoked {
+		query = query.Where("revoked = ?", false)
+	}
+	if f.Token != "" {
+		query = query.Where("token = ?", f.Token)
+	}
+	if f.Label != "" {
+		query = query.Where(`labels LIKE ? ESCAPE '\'`, labelPattern(f.Label))
+	}
+	return query
+}
+
+// labelPattern builds a LIKE pattern matching one whole element of the JSON
+// encoded labels column. The label is JSON encoded the same way it is stored,
+// so the surrounding quotes prevent matching a prefix of a longer label.
+func labelPattern(label string) string {
+	encoded, _ := json.Marshal(label)
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(string(encoded))
+	return "%" + escaped + "%"
+}
+
+// ScheduleOrder describes the ordering applied to schedule listings
+type ScheduleOrder struct {
+	Column string
+	Desc   bool
+}
+
+// sortableScheduleColumns whitelists the columns schedule listings may be ordered by
+var sortableScheduleColumns = map[string]bool{
+	"id":          true,
+	"beneficiary": true,
+	"start":       true,
+	"cliff":       true,
+	"duration":    true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+// ParseScheduleOrder parses an order spec such as "created_at desc"
+func ParseScheduleOrder(spec string) (ScheduleOrder, error) {
+	fields := strings.Fields(strings.ToLower(spec))
+	if len(fields) == 0 || len(fields) > 2 {
+		return ScheduleOrder{}, fmt.Errorf("invalid schedule order %q", spec)
+	}
+
+	order := ScheduleOrder{Column: fields[0]}
+	if !sortableScheduleColumns[order.Column] {
+		return ScheduleOrder{}, fmt.Errorf("unsupported schedule order column %q", order.Column)
+	}
+
+	if len(fields) == 2 {
+		switch fields[1] {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return ScheduleOrder{}, fmt.Errorf("invalid schedule order direction %q", fields[1])
+		}
+	}
+
+	return order, nil
+}
+
+// SetScheduleOrder sets the default ordering for schedule listings
+func (d *Database) SetScheduleOrder(order ScheduleOrder) {
+	d.scheduleOrder = order
+}
+
+// orderColumn returns the column schedule listings are ordered by
+func (d *Database) orderColumn() string {
+	if d.scheduleOrder.Column == "" {
+		return "id"
+	}
+	return d.scheduleOrder.Column
+}
+
+// orderSchedules applies the default schedule ordering, using ID as a tie-breaker
+// so pagination is stable
+func (d *Database) orderSchedules(query *gorm.DB) *gorm.DB {
+	column := d.orderColumn()
+	query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: d.scheduleOrder.Desc})
+	if column != "id" {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+	}
+	return query
+}
+
+// afterSchedule restricts an ordered listing to the schedules that come after
+// the one with the given ID. The anchor's order column is read back, deleted or
+// not, so the cursor stays valid while rows change between pages.
+func (d *Database) afterSchedule(query *gorm.DB, id uint) (*gorm.DB, error) {
+	column := d.orderColumn()
+	idColumn := clause.Column{Name: "id"}
+	if column == "id" {
+		if d.scheduleOrder.Desc {
+			return query.Where(clause.Lt{Column: idColumn, Value: id}), nil
+		}
+		return query.Where(clause.Gt{Column: idColumn, Value: id}), nil
+	}
+
+	var anchor models.VestingSchedule
+	if err := d.DB.Unscoped().Select("id", column).Where("id = ?", id).Take(&anchor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCursor
+		}
+		return nil, err
+	}
+
+	var value interface{}
+	switch column {
+	case "beneficiary":
+		value = anchor.Beneficiary
+	case "start":
+		value = anchor.Start
+	case "cliff":
+		value = anchor.Cliff
+	case "duration":
+		value = anchor.Duration
+	case "created_at":
+		value = anchor.CreatedAt
+	case "updated_at":
+		value = anchor.UpdatedAt
+	}
+
+	orderColumn := clause.Column{Name: column}
+	var past clause.Expression = clause.Gt{Column: orderColumn, Value: value}
+	if d.scheduleOrder.Desc {
+		past = clause.Lt{Column: orderColumn, Value: value}
+	}
+	// Ties on the order column are broken by ascending ID
+	return query.Where(clause.Or(past, clause.And(
+		clause.Eq{Column: orderColumn, Value: value},
+		clause.Gt{Column: idColumn, Value: id},
+	))), nil
+}
+
+// pageSchedules orders a schedule listing and selects one page of it, starting
+// after filter.AfterID when set and at offset otherwise
+func (d *Database) pageSchedules(query *gorm.DB, filter ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+	if filter.AfterID != 0 {
+		var err error
+		if query, err = d.afterSchedule(query, filter.AfterID); err != nil {
+			return nil, err
+		}
+		offset = 0
+	}
+
+	var schedules []models.VestingSchedule
+	result := d.orderSchedules(query).Limit(limit).Offset(offset).Find(&schedules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return schedules, nil
+}
+
+// EventCursor is the position of an event in listing order
+type EventCursor struct {
+	BlockNumber uint64
+	LogIndex    uint
+	ID          uint
+}
+
+// EventFilter holds optional constraints and ordering applied to event queries
+type EventFilter struct {
+	FromBlock *uint64      // Inclusive lower bound
+	ToBlock   *uint64      // Inclusive upper bound
+	MinAmount *big.Int     // Inclusive lower bound on amount, in token base units
+	EventType string       // Only events of this type, e.g. TokensReleased
+	Ascending bool         // Oldest first; newest first by default
+	After     *EventCursor // Cursor: only events ordered after this position
+}
+
+// apply adds the filter's conditions to a query
+func (f EventFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.FromBlock != nil {
+		query = query.Where("block_number >= ?", *f.FromBlock)
+	}
+	if f.ToBlock != nil {
+		query = query.Where("block_number <= ?", *f.ToBlock)
+	}
+	if f.MinAmount != nil {
+		// Amounts are stored as decimal strings; compare numerically, skipping empty amounts
+		query = query.Where("CAST(NULLIF(amount, '') AS NUMERIC) >= CAST(? AS NUMERIC)", f.MinAmount.String())
+	}
+	if f.EventType != "" {
+		query = query.Where("event_type = ?", f.EventType)
+	}
+	if f.After != nil {
+		// Keyset comparison on (block_number, log_index, id), spelled out for portability
+		op := "<"
+		if f.Ascending {
+			op = ">"
+		}
+		after := *f.After
+		query = query.Where(
+			fmt.Sprintf("block_number %[1]s ? OR (block_number = ? AND (log_index %[1]s ? OR (log_index = ? AND id %[1]s ?)))", op),
+			after.BlockNumber, after.BlockNumber, after.LogIndex, after.LogIndex, after.ID,
+		)
+	}
+	return query
+}
+
+// orderBy returns the block ordering for the filter. ID breaks ties so the
+// order is total and cursors never skip or repeat events.
+func (f EventFilter) orderBy() string {
+	if f.Ascending {
+		return "block_number ASC, log_index ASC, id ASC"
+	}
+	return "block_number DESC, log_index DESC, id DESC"
 }
 
 // NewDatabase creates a new database connection
 func NewDatabase(databaseURL string) (*Database, error) {
 	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Stamp created_at/updated_at in UTC regardless of the host's zone
+		NowFunc: func() time.Time { return time.Now().UTC() },
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -28,16 +297,83 @@ func NewDatabase(databaseURL string) (*Database, error) {
 	if err := db.AutoMigrate(
 		&models.VestingSchedule{},
 		&models.VestingEvent{},
+		&models.MerkleAllocation{},
+		&models.SyncCheckpoint{},
+		&models.SyncRange{},
+		&models.SyncState{},
+		&models.DeadLetterEvent{},
+		&models.OutboxEvent{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to auto-migrate database: %w", err)
 	}
+	if err := db.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", legacyEventTxIndex)).Error; err != nil {
+		return nil, fmt.Errorf("failed to drop index %s: %w", legacyEventTxIndex, err)
+	}
+	// Schedules stored before ends_at existed
+	if err := db.Exec("UPDATE vesting_schedules SET ends_at = start + duration * INTERVAL '1 second' WHERE ends_at IS NULL").Error; err != nil {
+		return nil, fmt.Errorf("failed to backfill schedule end times: %w", err)
+	}
 
 	log.Println("✅ Database connected and migrated successfully")
 
 	return &Database{DB: db}, nil
 }
 
-// GetScheduleByBeneficiary retrieves a vesting schedule by beneficiary address
+// UseReadReplica serves reads from a Postgres read replica. Writes and
+// transactions stay on the primary, and Primary pins reads to it.
+func (d *Database) UseReadReplica(replicaURL string) error {
+	return d.useReplica(postgres.Open(replicaURL))
+}
+
+// useReplica registers a read replica behind the primary connection
+func (d *Database) useReplica(replica gorm.Dialector) error {
+	if err := d.DB.Use(dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{replica}})); err != nil {
+		return fmt.Errorf("failed to register read replica: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection pool. Call it only once nothing else
+// uses the database.
+func (d *Database) Close() error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get connection pool: %w", err)
+	}
+	return sqlDB.Close()
+}
+
+// Ping verifies the database connection is alive
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get connection pool: %w", err)
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Primary returns a view of the database whose reads go to the primary, for
+// callers that must see their own or other clients' latest writes. Without a
+// read replica it reads from the same connection as d.
+func (d *Database) Primary() *Database {
+	primary := *d
+	primary.DB = d.DB.Clauses(dbresolver.Write).Session(&gorm.Session{})
+	return &primary
+}
+
+// Transaction runs fn in a database transaction on the primary, passing it a
+// view of d bound to the transaction. An error from fn rolls back every write
+// made through that view.
+func (d *Database) Transaction(fn func(tx *Database) error) error {
+	return d.DB.Clauses(dbresolver.Write).Transaction(func(tx *gorm.DB) error {
+		scoped := *d
+		scoped.DB = tx
+		return fn(&scoped)
+	})
+}
+
+// GetScheduleByBeneficiary retrieves a vesting schedule by beneficiary address.
+// Returns gorm.ErrRecordNotFound when the beneficiary has no active schedule.
 func (d *Database) GetScheduleByBeneficiary(beneficiary string) (*models.VestingSchedule, error) {
 	var schedule models.VestingSchedule
 	result := d.DB.Where("beneficiary = ? AND revoked = ?", beneficiary, false).First(&schedule)
@@ -47,40 +383,415 @@ func (d *Database) GetScheduleByBeneficiary(beneficiary string) (*models.Vesting
 	return &schedule, nil
 }
 
-// GetAllSchedules retrieves all active vesting schedules
-func (d *Database) GetAllSchedules(limit, offset int) ([]models.VestingSchedule, error) {
+// GetScheduleIncludingRevoked retrieves a beneficiary's schedule whether or not it was revoked.
+// Returns gorm.ErrRecordNotFound when the beneficiary has no schedule.
+func (d *Database) GetScheduleIncludingRevoked(beneficiary string) (*models.VestingSchedule, error) {
+	var schedule models.VestingSchedule
+	result := d.DB.Where("beneficiary = ?", beneficiary).First(&schedule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &schedule, nil
+}
+
+// GetScheduleIncludingDeleted retrieves a beneficiary's schedule even when it
+// was soft-deleted on revocation. Event processing uses it to see revocations
+// that every read path hides in delete mode.
+func (d *Database) GetScheduleIncludingDeleted(beneficiary string) (*models.VestingSchedule, error) {
+	var schedule models.VestingSchedule
+	result := d.DB.Unscoped().Where("beneficiary = ?", beneficiary).First(&schedule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &schedule, nil
+}
+
+// GetSchedulesByBeneficiary retrieves every schedule for a beneficiary,
+// including revoked ones, ordered by ID
+func (d *Database) GetSchedulesByBeneficiary(beneficiary string) ([]models.VestingSchedule, error) {
 	var schedules []models.VestingSchedule
-	result := d.DB.Where("revoked = ?", false).Limit(limit).Offset(offset).Find(&schedules)
+	result := d.DB.Where("beneficiary = ?", beneficiary).Order("id").Find(&schedules)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return schedules, nil
 }
 
-// CreateOrUpdateSchedule creates or updates a vesting schedule
+// GetLatestScheduleIDs returns the ID of each given beneficiary's newest
+// schedule, revoked or not. Beneficiaries without a schedule are left out.
+func (d *Database) GetLatestScheduleIDs(beneficiaries []string) (map[string]uint, error) {
+	var rows []struct {
+		Beneficiary string
+		ID          uint
+	}
+	result := d.DB.Model(&models.VestingSchedule{}).
+		Select("beneficiary, MAX(id) AS id").
+		Where("beneficiary IN ?", beneficiaries).
+		Group("beneficiary").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	latest := make(map[string]uint, len(rows))
+	for _, row := range rows {
+		latest[row.Beneficiary] = row.ID
+	}
+	return latest, nil
+}
+
+// GetTotalAllocated sums the amounts of all active schedules in a single
+// aggregate query. Amounts are stored as decimal strings, so the sum is taken
+// over a numeric cast and returned as text to keep full precision.
+func (d *Database) GetTotalAllocated() (*big.Int, error) {
+	var total string
+	result := d.DB.Model(&models.VestingSchedule{}).
+		Where("revoked = ?", false).
+		Select("CAST(COALESCE(SUM(CAST(amount AS NUMERIC)), 0) AS TEXT)").
+		Scan(&total)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return parseNumericSum(total)
+}
+
+// CountSchedules counts the schedules matching filter, as listed by
+// GetAllSchedules without pagination. The cursor is ignored.
+func (d *Database) CountSchedules(filter ScheduleFilter) (int64, error) {
+	return countSchedules(filter.apply(d.DB.Model(&models.VestingSchedule{})))
+}
+
+// countSchedules runs a count of a schedule query
+func countSchedules(query *gorm.DB) (int64, error) {
+	var count int64
+	if result := query.Count(&count); result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// ScheduleSums are amount totals over active schedules, in token base units
+type ScheduleSums struct {
+	Allocated *big.Int
+	Released  *big.Int
+}
+
+// SumAmounts totals the allocated and released amounts of active schedules.
+// Amounts are decimal strings, so they are summed as numerics in SQL.
+func (d *Database) SumAmounts() (ScheduleSums, error) {
+	var row struct {
+		Allocated string
+		Released  string
+	}
+	result := d.DB.Model(&models.VestingSchedule{}).
+		Where("revoked = ?", false).
+		Select("CAST(COALESCE(SUM(CAST(NULLIF(amount, '') AS NUMERIC)), 0) AS TEXT) AS allocated, " +
+			"CAST(COALESCE(SUM(CAST(NULLIF(released, '') AS NUMERIC)), 0) AS TEXT) AS released").
+		Scan(&row)
+	if result.Error != nil {
+		return ScheduleSums{}, result.Error
+	}
+
+	allocated, err := parseNumericSum(row.Allocated)
+	if err != nil {
+		return ScheduleSums{}, err
+	}
+	released, err := parseNumericSum(row.Released)
+	if err != nil {
+		return ScheduleSums{}, err
+	}
+	return ScheduleSums{Allocated: allocated, Released: released}, nil
+}
+
+// parseNumericSum parses an aggregate over numeric amounts. Postgres returns an
+// exact integer; SQLite may return a float once the sum exceeds 64 bits.
+func parseNumericSum(value string) (*big.Int, error) {
+	if sum, ok := new(big.Int).SetString(value, 10); ok {
+		return sum, nil
+	}
+	sum, _, err := big.ParseFloat(value, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount sum %q: %w", value, err)
+	}
+	integer, _ := sum.Int(nil)
+	return integer, nil
+}
+
+// GetAllSchedules retrieves active vesting schedules, or all schedules when the
+// filter includes revoked ones
+func (d *Database) GetAllSchedules(filter ScheduleFilter, limit, offset int) ([]models.VestingSchedule, error) {
+	return d.pageSchedules(filter.apply(d.DB), filter, limit, offset)
+}
+
+// GetSchedulesByStatus retrieves a page of schedules in the given status at
+// now, further narrowed by filter. Phases are compared in the database against
+// the stored cliff and end (start+duration), with the same boundaries as
+// models.VestingSchedule.ComputeStatus; "completed" means now >= start+duration.
+// Returns ErrUnknownStatus for a status not in StatusFilters.
+func (d *Database) GetSchedulesByStatus(status string, filter ScheduleFilter, now time.Time, limit, offset int) ([]models.VestingSchedule, error) {
+	query, err := statusQuery(d.DB, status, filter, now)
+	if err != nil {
+		return nil, err
+	}
+	return d.pageSchedules(query, filter, limit, offset)
+}
+
+// CountSchedulesByStatus counts the schedules GetSchedulesByStatus lists,
+// without pagination
+func (d *Database) CountSchedulesByStatus(status string, filter ScheduleFilter, now time.Time) (int64, error) {
+	query, err := statusQuery(d.DB.Model(&models.VestingSchedule{}), status, filter, now)
+	if err != nil {
+		return 0, err
+	}
+	return countSchedules(query)
+}
+
+// statusQuery narrows a schedule query to the given status at now
+func statusQuery(query *gorm.DB, status string, filter ScheduleFilter, now time.Time) (*gorm.DB, error) {
+	now = now.UTC()
+
+	// The status decides whether revoked schedules match
+	filter.IncludeRevoked = true
+	query = filter.apply(query)
+	switch status {
+	case StatusFilterActive:
+		query = query.Where("revoked = ?", false)
+	case StatusFilterCliff:
+		query = query.Where("revoked = ? AND cliff > ?", false, now)
+	case StatusFilterVesting:
+		query = query.Where("revoked = ? AND cliff <= ? AND ends_at > ?", false, now, now)
+	case StatusFilterCompleted:
+		query = query.Where("revoked = ? AND ends_at <= ?", false, now)
+	case StatusFilterRevoked:
+		query = query.Where("revoked = ?", true)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownStatus, status)
+	}
+	return query, nil
+}
+
+// CreateOrUpdateSchedule creates or updates a vesting schedule, matched by
+// beneficiary or, in multi-schedule mode, by creation transaction. Updates are
+// applied with optimistic locking and retried if another writer got there first.
+//
+// Storing a schedule from the same creation transaction as the stored one is a
+// no-op, so reprocessing a creation event keeps releases and revocations
+// applied since; schedule is then filled with the stored row.
 func (d *Database) CreateOrUpdateSchedule(schedule *models.VestingSchedule) error {
-	var existing models.VestingSchedule
-	result := d.DB.Where("beneficiary = ?", schedule.Beneficiary).First(&existing)
+	// Save hooks don't run on the values passed to Updates, so normalize here
+	schedule.NormalizeTimestamps()
+	// The row being updated must not be read from a lagging replica
+	d = d.Primary()
 
-	if result.Error == gorm.ErrRecordNotFound {
-		// Create new schedule
-		return d.DB.Create(schedule).Error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var existing models.VestingSchedule
+		key, value := d.scheduleKey(schedule)
+		result := d.DB.Where(key, value).First(&existing)
+
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			// Replaying the creation of a schedule deleted on revocation must
+			// not bring it back
+			if deleted, err := d.deletedCreation(schedule.CreationTx); err != nil || deleted != nil {
+				if deleted != nil {
+					*schedule = *deleted
+				}
+				return err
+			}
+
+			// Create new schedule
+			schedule.Version = 1
+			return d.DB.Create(schedule).Error
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if schedule.CreationTx != "" && existing.CreationTx == schedule.CreationTx {
+			*schedule = existing
+			return nil
+		}
+
+		// Update existing schedule only if nobody else has since
+		schedule.Version = existing.Version + 1
+		updated, err := d.updateIfVersion(&existing, schedule)
+		if err != nil || updated {
+			return err
+		}
+	}
+	return ErrConcurrentUpdate
+}
+
+// deletedCreation returns the soft-deleted schedule created by creationTx, or
+// nil when there is none
+func (d *Database) deletedCreation(creationTx string) (*models.VestingSchedule, error) {
+	if creationTx == "" {
+		return nil, nil
+	}
+	var deleted models.VestingSchedule
+	result := d.DB.Unscoped().
+		Where("creation_tx = ? AND deleted_at IS NOT NULL", creationTx).
+		Limit(1).
+		Find(&deleted)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
 	}
+	return &deleted, nil
+}
 
-	// Update existing schedule
-	return d.DB.Model(&existing).Updates(schedule).Error
+// hasDeletedSchedule reports whether a beneficiary has a soft-deleted schedule
+func (d *Database) hasDeletedSchedule(beneficiary string) (bool, error) {
+	var count int64
+	result := d.DB.Unscoped().Model(&models.VestingSchedule{}).
+		Where("beneficiary = ? AND deleted_at IS NOT NULL", beneficiary).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+// updateIfVersion applies updates to a schedule only if its version still
+// matches the copy that was read. Reports whether the row was updated.
+func (d *Database) updateIfVersion(existing *models.VestingSchedule, updates interface{}) (bool, error) {
+	result := d.DB.Model(&models.VestingSchedule{}).
+		Where("id = ? AND version = ?", existing.ID, existing.Version).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
 }
 
-// CreateEvent creates a new vesting event
+// AddReleased increments the released amount for the schedule a release at
+// the given position applies to (see eventScheduleKey)
+func (d *Database) AddReleased(beneficiary string, amount string, at *EventCursor) error {
+	delta, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return fmt.Errorf("invalid released amount %q", amount)
+	}
+	// The row being updated must not be read from a lagging replica
+	d = d.Primary()
+
+	key, value, err := d.eventScheduleKey(beneficiary, at)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var existing models.VestingSchedule
+		if err := d.DB.Where(key, value).First(&existing).Error; err != nil {
+			// Schedules deleted on revocation no longer track releases
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if deleted, lookupErr := d.hasDeletedSchedule(beneficiary); lookupErr != nil || deleted {
+					return lookupErr
+				}
+			}
+			return err
+		}
+
+		released, ok := new(big.Int).SetString(existing.Released, 10)
+		if !ok {
+			released = big.NewInt(0)
+		}
+		released.Add(released, delta)
+
+		updated, err := d.updateIfVersion(&existing, map[string]interface{}{
+			"released": released.String(),
+			"version":  existing.Version + 1,
+		})
+		if err != nil || updated {
+			return err
+		}
+	}
+	return ErrConcurrentUpdate
+}
+
+// CreateEvent records a vesting event. Events are keyed on transaction hash
+// and log index, so recording one again (e.g. when a block range is
+// reprocessed) leaves the stored row untouched and returns ErrDuplicateEvent.
 func (d *Database) CreateEvent(event *models.VestingEvent) error {
-	return d.DB.Create(event).Error
+	result := d.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "transaction_hash"}, {Name: "log_index"}},
+		DoNothing: true,
+	}).Create(event)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDuplicateEvent
+	}
+	return nil
+}
+
+// CreateOutboxEvent marks a recorded event as awaiting publication
+func (d *Database) CreateOutboxEvent(event *models.VestingEvent) error {
+	return d.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.OutboxEvent{EventID: event.ID, BlockNumber: event.BlockNumber}).Error
+}
+
+// DeleteOutboxEvent marks the event at a transaction's log index as published
+func (d *Database) DeleteOutboxEvent(txHash string, logIndex uint) error {
+	return d.DB.Where("event_id IN (?)", d.DB.Model(&models.VestingEvent{}).
+		Select("id").
+		Where("transaction_hash = ? AND log_index = ?", txHash, logIndex)).
+		Delete(&models.OutboxEvent{}).Error
+}
+
+// GetOutboxEvents returns up to limit events awaiting publication, oldest first
+func (d *Database) GetOutboxEvents(limit int) ([]models.VestingEvent, error) {
+	var events []models.VestingEvent
+	result := d.DB.Joins("JOIN event_outbox ON event_outbox.event_id = vesting_events.id").
+		Order("event_outbox.id").
+		Limit(limit).
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// CountOutboxEvents returns the number of events awaiting publication
+func (d *Database) CountOutboxEvents() (int64, error) {
+	var count int64
+	result := d.DB.Model(&models.OutboxEvent{}).Count(&count)
+	return count, result.Error
+}
+
+// GetOldestOutboxBlock returns the lowest block holding an event awaiting
+// publication. found is false when every event has been published.
+func (d *Database) GetOldestOutboxBlock() (block uint64, found bool, err error) {
+	var outbox models.OutboxEvent
+	result := d.DB.Order("block_number").Limit(1).Find(&outbox)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return 0, false, result.Error
+	}
+	return outbox.BlockNumber, true, nil
+}
+
+// HasEvent reports whether the event at a transaction's log index is recorded
+func (d *Database) HasEvent(txHash string, logIndex uint) (bool, error) {
+	var count int64
+	result := d.DB.Model(&models.VestingEvent{}).
+		Where("transaction_hash = ? AND log_index = ?", txHash, logIndex).
+		Count(&count)
+	return count > 0, result.Error
 }
 
 // GetEventsByBeneficiary retrieves all events for a beneficiary
-func (d *Database) GetEventsByBeneficiary(beneficiary string, limit, offset int) ([]models.VestingEvent, error) {
+func (d *Database) GetEventsByBeneficiary(beneficiary string, filter EventFilter, limit, offset int) ([]models.VestingEvent, error) {
+	var events []models.VestingEvent
+	result := filter.apply(d.DB.Where("beneficiary = ?", beneficiary)).
+		Order(filter.orderBy()).
+		Limit(limit).
+		Offset(offset).
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// GetEventsByBeneficiaries retrieves events for a set of beneficiaries
+func (d *Database) GetEventsByBeneficiaries(beneficiaries []string, filter EventFilter, limit, offset int) ([]models.VestingEvent, error) {
 	var events []models.VestingEvent
-	result := d.DB.Where("beneficiary = ?", beneficiary).
-		Order("block_number DESC").
+	result := filter.apply(d.DB.Where("beneficiary IN ?", beneficiaries)).
+		Order(filter.orderBy()).
 		Limit(limit).
 		Offset(offset).
 		Find(&events)
@@ -90,10 +801,72 @@ func (d *Database) GetEventsByBeneficiary(beneficiary string, limit, offset int)
 	return events, nil
 }
 
-// GetLastProcessedBlock gets the highest block number we've processed
+// CountEventsByBeneficiaries counts the events of a set of beneficiaries
+// matching filter, ignoring its cursor
+func (d *Database) CountEventsByBeneficiaries(beneficiaries []string, filter EventFilter) (int64, error) {
+	filter.After = nil
+
+	var count int64
+	result := filter.apply(d.DB.Model(&models.VestingEvent{}).Where("beneficiary IN ?", beneficiaries)).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// GetEventsInRange retrieves events across all beneficiaries in chain order,
+// narrowed by the filter's block range, event type and minimum amount
+func (d *Database) GetEventsInRange(filter EventFilter, limit, offset int) ([]models.VestingEvent, error) {
+	var events []models.VestingEvent
+	result := filter.apply(d.DB).
+		Order("block_number ASC, log_index ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// GetLatestEventsByBeneficiaries retrieves the newest event for each beneficiary
+// in a single query, keyed by beneficiary. Beneficiaries without events are absent.
+func (d *Database) GetLatestEventsByBeneficiaries(beneficiaries []string) (map[string]models.VestingEvent, error) {
+	latest := make(map[string]models.VestingEvent, len(beneficiaries))
+	if len(beneficiaries) == 0 {
+		return latest, nil
+	}
+
+	var events []models.VestingEvent
+	result := d.DB.Where("beneficiary IN ?", beneficiaries).
+		Where(`id = (SELECT e.id FROM vesting_events e WHERE e.beneficiary = vesting_events.beneficiary
+			ORDER BY e.block_number DESC, e.id DESC LIMIT 1)`).
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, event := range events {
+		latest[event.Beneficiary] = event
+	}
+	return latest, nil
+}
+
+// GetLastProcessedBlock returns the block the indexer has processed up to.
+// Databases that predate the sync state table fall back to the highest
+// indexed event block until the first checkpoint is saved.
 func (d *Database) GetLastProcessedBlock() (uint64, error) {
+	var state models.SyncState
+	result := d.DB.Where("id = ?", models.SyncStateID).Limit(1).Find(&state)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return state.LastProcessedBlock, nil
+	}
+
 	var event models.VestingEvent
-	result := d.DB.Order("block_number DESC").First(&event)
+	result = d.DB.Order("block_number DESC").First(&event)
 	if result.Error == gorm.ErrRecordNotFound {
 		return 0, nil
 	}
@@ -103,16 +876,209 @@ func (d *Database) GetLastProcessedBlock() (uint64, error) {
 	return event.BlockNumber, nil
 }
 
-// MarkScheduleAsRevoked marks a schedule as revoked
-func (d *Database) MarkScheduleAsRevoked(beneficiary string) error {
+// SaveLastProcessedBlock records that every block up to block has been
+// processed. The checkpoint only moves forward.
+func (d *Database) SaveLastProcessedBlock(block uint64) error {
+	state := models.SyncState{ID: models.SyncStateID, LastProcessedBlock: block}
+	if err := d.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&state).Error; err != nil {
+		return err
+	}
+	return d.DB.Model(&models.SyncState{}).
+		Where("id = ? AND last_processed_block < ?", models.SyncStateID, block).
+		Update("last_processed_block", block).Error
+}
+
+// GetDeploymentBlock returns the persisted deployment block of a contract.
+// found is false when none has been recorded yet.
+func (d *Database) GetDeploymentBlock(contract string) (block uint64, found bool, err error) {
+	var checkpoint models.SyncCheckpoint
+	result := d.DB.Where("contract_address = ?", contract).First(&checkpoint)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	return checkpoint.DeploymentBlock, true, nil
+}
+
+// SaveDeploymentBlock persists a contract's deployment block, replacing any previous value
+func (d *Database) SaveDeploymentBlock(contract string, block uint64) error {
+	return d.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"deployment_block", "updated_at"}),
+	}).Create(&models.SyncCheckpoint{ContractAddress: contract, DeploymentBlock: block}).Error
+}
+
+// CreateDeadLetterEvent records an event that exhausted its retries. Recording
+// the same event again leaves the stored row untouched.
+func (d *Database) CreateDeadLetterEvent(event *models.DeadLetterEvent) error {
+	return d.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "transaction_hash"}, {Name: "log_index"}},
+		DoNothing: true,
+	}).Create(event).Error
+}
+
+// RecordScannedRange adds an inclusive block range to the sync coverage history
+func (d *Database) RecordScannedRange(fromBlock, toBlock uint64, source string) (*models.SyncRange, error) {
+	scanned := &models.SyncRange{FromBlock: fromBlock, ToBlock: toBlock, Source: source}
+	if err := d.DB.Create(scanned).Error; err != nil {
+		return nil, err
+	}
+	return scanned, nil
+}
+
+// ExtendScannedRange moves the end of a recorded range forward to toBlock.
+// Ranges never shrink.
+func (d *Database) ExtendScannedRange(id uint, toBlock uint64) error {
+	return d.DB.Model(&models.SyncRange{}).
+		Where("id = ? AND to_block < ?", id, toBlock).
+		Update("to_block", toBlock).Error
+}
+
+// GetScannedRanges retrieves the recorded ranges overlapping the filter's block
+// window, ordered by their first block. Other filter fields are ignored.
+func (d *Database) GetScannedRanges(filter EventFilter) ([]models.SyncRange, error) {
+	ranges := make([]models.SyncRange, 0)
+	query := d.DB.Model(&models.SyncRange{})
+	if filter.FromBlock != nil {
+		query = query.Where("to_block >= ?", *filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		query = query.Where("from_block <= ?", *filter.ToBlock)
+	}
+	if err := query.Order("from_block ASC, to_block ASC").Find(&ranges).Error; err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// GetEventBlocks retrieves the distinct block numbers holding events that match
+// the filter, in ascending order, up to limit blocks
+func (d *Database) GetEventBlocks(filter EventFilter, limit int) ([]uint64, error) {
+	blocks := make([]uint64, 0)
+	result := filter.apply(d.DB.Model(&models.VestingEvent{})).
+		Distinct("block_number").
+		Order("block_number ASC").
+		Limit(limit).
+		Pluck("block_number", &blocks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return blocks, nil
+}
+
+// EventTypeHighWater is the latest block at which an event type was indexed
+type EventTypeHighWater struct {
+	EventType   string `json:"event_type"`
+	LatestBlock uint64 `json:"latest_block"`
+	Count       int64  `json:"count"`
+}
+
+// GetEventTypeHighWaterMarks returns the highest indexed block and event count
+// for each event type, ordered by event type
+func (d *Database) GetEventTypeHighWaterMarks() ([]EventTypeHighWater, error) {
+	marks := make([]EventTypeHighWater, 0)
+	result := d.DB.Model(&models.VestingEvent{}).
+		Select("event_type, MAX(block_number) AS latest_block, COUNT(*) AS count").
+		Group("event_type").
+		Order("event_type").
+		Scan(&marks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return marks, nil
+}
+
+// MarkScheduleAsRevoked marks the schedule a revocation at the given position
+// applies to (see eventScheduleKey) as revoked. In delete mode the schedule is
+// also soft-deleted, hiding it from every read.
+func (d *Database) MarkScheduleAsRevoked(beneficiary string, at *EventCursor) error {
+	key, value, err := d.eventScheduleKey(beneficiary, at)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"revoked": true,
+		"version": gorm.Expr("version + 1"),
+	}
+	if d.revocationMode == RevocationModeDelete {
+		updates["deleted_at"] = d.DB.NowFunc()
+	}
 	return d.DB.Model(&models.VestingSchedule{}).
+		Where(key, value).
+		Updates(updates).Error
+}
+
+// SetScheduleLabels replaces the labels on a beneficiary's schedule
+func (d *Database) SetScheduleLabels(beneficiary string, labels []string) error {
+	encoded, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+
+	result := d.DB.Model(&models.VestingSchedule{}).
 		Where("beneficiary = ?", beneficiary).
-		Update("revoked", true).Error
+		Updates(map[string]interface{}{
+			"labels":  string(encoded),
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
-// UpdateReleased updates the released amount for a schedule
-func (d *Database) UpdateReleased(beneficiary string, released string) error {
+// UpdateReleased updates the released amount of one schedule
+func (d *Database) UpdateReleased(id uint, released string) error {
 	return d.DB.Model(&models.VestingSchedule{}).
-		Where("beneficiary = ?", beneficiary).
-		Update("released", released).Error
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"released": released,
+			"version":  gorm.Expr("version + 1"),
+		}).Error
+}
+
+// GetOrphanedEventBeneficiaries lists beneficiaries that have events but no schedule row
+func (d *Database) GetOrphanedEventBeneficiaries() ([]string, error) {
+	var beneficiaries []string
+	result := d.DB.Model(&models.VestingEvent{}).
+		Distinct("beneficiary").
+		Where("NOT EXISTS (SELECT 1 FROM vesting_schedules WHERE vesting_schedules.beneficiary = vesting_events.beneficiary)").
+		Order("beneficiary").
+		Pluck("beneficiary", &beneficiaries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return beneficiaries, nil
+}
+
+// BeneficiaryPresence reports which records exist for a beneficiary
+type BeneficiaryPresence struct {
+	HasSchedules bool // Any schedule, active or revoked
+	HasEvents    bool
+}
+
+// GetBeneficiaryPresence checks whether a beneficiary has any schedule or
+// event without loading them
+func (d *Database) GetBeneficiaryPresence(beneficiary string) (BeneficiaryPresence, error) {
+	var presence BeneficiaryPresence
+	result := d.DB.Raw("SELECT EXISTS (?) AS has_schedules, EXISTS (?) AS has_events",
+		d.DB.Model(&models.VestingSchedule{}).Select("1").Where("beneficiary = ?", beneficiary),
+		d.DB.Model(&models.VestingEvent{}).Select("1").Where("beneficiary = ?", beneficiary),
+	).Scan(&presence)
+	if result.Error != nil {
+		return presence, result.Error
+	}
+	return presence, nil
+}
+
+// DeleteEventsByBeneficiary deletes all events for a beneficiary, returning the number removed
+func (d *Database) DeleteEventsByBeneficiary(beneficiary string) (int64, error) {
+	result := d.DB.Where("beneficiary = ?", beneficiary).Delete(&models.VestingEvent{})
+	return result.RowsAffected, result.Error
 }
@@ -1,10 +1,17 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
@@ -17,12 +24,54 @@ func setupTestDB(t *testing.T) *Database {
 	assert.NoError(t, err)
 
 	// Auto-migrate tables
-	err = db.AutoMigrate(&models.VestingSchedule{}, &models.VestingEvent{})
+	err = db.AutoMigrate(&models.VestingSchedule{}, &models.VestingEvent{}, &models.MerkleAllocation{}, &models.SyncCheckpoint{}, &models.SyncRange{}, &models.SyncState{}, &models.DeadLetterEvent{}, &models.OutboxEvent{})
 	assert.NoError(t, err)
 
 	return &Database{DB: db}
 }
 
+func TestPrimary_ReadsBypassReplica(t *testing.T) {
+	open := func(name string) *gorm.DB {
+		db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name)), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.VestingSchedule{}))
+		return db
+	}
+
+	// The replica lags: it has not seen the schedule written to the primary
+	replica := open("replica.db")
+	require.NoError(t, replica.Create(&models.VestingSchedule{Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Amount: "1", Released: "0"}).Error)
+
+	db := &Database{DB: open("primary.db")}
+	require.NoError(t, db.useReplica(replica.Dialector))
+	require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", Amount: "2", Released: "0"}))
+
+	eventual, err := db.GetAllSchedules(ScheduleFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, eventual, 1)
+	assert.Equal(t, "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", eventual[0].Beneficiary)
+
+	// Reuse the primary view for several reads to check conditions don't leak between them
+	primary := db.Primary()
+	for i := 0; i < 2; i++ {
+		strong, err := primary.GetScheduleByBeneficiary("0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+		require.NoError(t, err)
+		assert.Equal(t, "2", strong.Amount)
+	}
+	count, err := primary.CountSchedules(ScheduleFilter{IncludeRevoked: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestPing(t *testing.T) {
+	db := setupTestDB(t)
+
+	assert.NoError(t, db.Ping(context.Background()))
+
+	require.NoError(t, db.Close())
+	assert.Error(t, db.Ping(context.Background()))
+}
+
 func TestCreateOrUpdateSchedule(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -57,6 +106,40 @@ func TestCreateOrUpdateSchedule(t *testing.T) {
 	assert.Equal(t, "500000000000000000000", updated.Released)
 }
 
+func TestCreateOrUpdateSchedule_StoresUTC(t *testing.T) {
+	db := setupTestDB(t)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, tokyo)
+	schedule := &models.VestingSchedule{
+		Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+		Start:       start,
+		Cliff:       start,
+		Duration:    3600,
+		Amount:      "1000",
+		Released:    "0",
+	}
+	require.NoError(t, db.CreateOrUpdateSchedule(schedule))
+	require.NoError(t, db.CreateEvent(&models.VestingEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     schedule.Beneficiary,
+		TransactionHash: "0x01",
+		Timestamp:       start,
+	}))
+
+	stored, err := db.GetScheduleByBeneficiary(schedule.Beneficiary)
+	require.NoError(t, err)
+	_, offset := stored.Start.Zone()
+	assert.Equal(t, 0, offset)
+	assert.True(t, stored.Start.Equal(start))
+
+	events, err := db.GetEventsByBeneficiary(schedule.Beneficiary, EventFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	_, offset = events[0].Timestamp.Zone()
+	assert.Equal(t, 0, offset)
+}
+
 func TestGetScheduleByBeneficiary_NotFound(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -64,6 +147,76 @@ func TestGetScheduleByBeneficiary_NotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetSchedulesByBeneficiary(t *testing.T) {
+	db := setupTestDB(t)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	schedules := []models.VestingSchedule{
+		{Beneficiary: beneficiary, Token: "0x1111111111111111111111111111111111111111", Amount: "1000", Released: "0"},
+		{Beneficiary: beneficiary, Token: "0x2222222222222222222222222222222222222222", Amount: "500", Released: "0", Revoked: true},
+		{Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Amount: "700", Released: "0"},
+	}
+	// Insert directly: CreateOrUpdateSchedule upserts one schedule per beneficiary
+	require.NoError(t, db.DB.Create(&schedules).Error)
+
+	found, err := db.GetSchedulesByBeneficiary(beneficiary)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "1000", found[0].Amount)
+	assert.True(t, found[1].Revoked)
+}
+
+func TestGetTotalAllocated(t *testing.T) {
+	db := setupTestDB(t)
+
+	total, err := db.GetTotalAllocated()
+	require.NoError(t, err)
+	assert.Equal(t, "0", total.String())
+
+	schedules := []models.VestingSchedule{
+		{Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", Amount: "1000000000000000000000", Released: "0"},
+		{Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Amount: "3000000000000000000000", Released: "0"},
+		{Beneficiary: "0x0000000000000000000000000000000000000001", Amount: "500000000000000000000", Released: "0", Revoked: true},
+	}
+	require.NoError(t, db.DB.Create(&schedules).Error)
+
+	// Revoked schedules are excluded
+	total, err = db.GetTotalAllocated()
+	require.NoError(t, err)
+	assert.Equal(t, "4000000000000000000000", total.String())
+}
+
+func TestCountSchedulesAndSumAmounts(t *testing.T) {
+	db := setupTestDB(t)
+
+	sums, err := db.SumAmounts()
+	require.NoError(t, err)
+	assert.Equal(t, "0", sums.Allocated.String())
+	assert.Equal(t, "0", sums.Released.String())
+
+	schedules := []models.VestingSchedule{
+		{Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED", Amount: "1000000000000000000000", Released: "250000000000000000000"},
+		{Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", Amount: "3000000000000000000000", Released: "0"},
+		{Beneficiary: "0x0000000000000000000000000000000000000002", Amount: "2000000000000000000000", Released: "500000000000000000000"},
+		{Beneficiary: "0x0000000000000000000000000000000000000001", Amount: "500000000000000000000", Released: "100000000000000000000", Revoked: true},
+	}
+	require.NoError(t, db.DB.Create(&schedules).Error)
+
+	total, err := db.CountSchedules(ScheduleFilter{IncludeRevoked: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	active, err := db.CountSchedules(ScheduleFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), active)
+
+	// Revoked schedules are excluded from the sums
+	sums, err = db.SumAmounts()
+	require.NoError(t, err)
+	assert.Equal(t, "6000000000000000000000", sums.Allocated.String())
+	assert.Equal(t, "750000000000000000000", sums.Released.String())
+}
+
 func TestGetAllSchedules(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -84,13 +237,253 @@ func TestGetAllSchedules(t *testing.T) {
 	}
 
 	// Test pagination
-	schedules, err := db.GetAllSchedules(3, 0)
+	schedules, err := db.GetAllSchedules(ScheduleFilter{}, 3, 0)
 	assert.NoError(t, err)
 	assert.Len(t, schedules, 3)
 
-	schedules, err = db.GetAllSchedules(10, 0)
+	schedules, err = db.GetAllSchedules(ScheduleFilter{}, 10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, schedules, 5)
+
+	// Revoked schedules are only listed on request
+	assert.NoError(t, db.MarkScheduleAsRevoked(schedules[0].Beneficiary, nil))
+
+	schedules, err = db.GetAllSchedules(ScheduleFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 4)
+
+	schedules, err = db.GetAllSchedules(ScheduleFilter{IncludeRevoked: true}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 5)
+}
+
+func TestGetSchedulesByStatus(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	const day = 24 * time.Hour
+
+	// Each schedule sits on or just beside a phase boundary at now; all last a day
+	seed := []struct {
+		beneficiary string
+		start       time.Time
+		cliff       time.Time
+		revoked     bool
+	}{
+		{"0x0000000000000000000000000000000000000001", now.Add(time.Second), now.Add(time.Hour), false},         // Not started
+		{"0x0000000000000000000000000000000000000002", now.Add(-time.Hour), now.Add(time.Second), false},        // Cliff one second away
+		{"0x0000000000000000000000000000000000000003", now.Add(-time.Hour), now, false},                         // Cliff reached right now
+		{"0x0000000000000000000000000000000000000004", now.Add(-day), now, false},                               // Ends right now
+		{"0x0000000000000000000000000000000000000005", now.Add(-day + time.Second), now.Add(-time.Hour), false}, // Ends in one second
+		{"0x0000000000000000000000000000000000000006", now.Add(-10 * day), now.Add(-9 * day), true},             // Revoked after completing
+	}
+	for _, s := range seed {
+		require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{
+			Beneficiary: s.beneficiary,
+			Start:       s.start,
+			Cliff:       s.cliff,
+			Duration:    int64(day / time.Second),
+			Amount:      "1000",
+			Released:    "0",
+			Revoked:     s.revoked,
+		}))
+	}
+
+	expected := map[string][]string{
+		StatusFilterActive:    {"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002", "0x0000000000000000000000000000000000000003", "0x0000000000000000000000000000000000000004", "0x0000000000000000000000000000000000000005"},
+		StatusFilterCliff:     {"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"},
+		StatusFilterVesting:   {"0x0000000000000000000000000000000000000003", "0x0000000000000000000000000000000000000005"},
+		StatusFilterCompleted: {"0x0000000000000000000000000000000000000004"},
+		StatusFilterRevoked:   {"0x0000000000000000000000000000000000000006"},
+	}
+
+	for _, status := range StatusFilters {
+		t.Run(status, func(t *testing.T) {
+			schedules, err := db.GetSchedulesByStatus(status, ScheduleFilter{}, now, 100, 0)
+			require.NoError(t, err)
+
+			beneficiaries := make([]string, len(schedules))
+			for i, schedule := range schedules {
+				beneficiaries[i] = schedule.Beneficiary
+			}
+			assert.Equal(t, expected[status], beneficiaries)
+		})
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		schedules, err := db.GetSchedulesByStatus(StatusFilterActive, ScheduleFilter{}, now, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, "0x0000000000000000000000000000000000000003", schedules[0].Beneficiary)
+	})
+
+	t.Run("Unknown status", func(t *testing.T) {
+		_, err := db.GetSchedulesByStatus("vested-ish", ScheduleFilter{}, now, 100, 0)
+		assert.ErrorIs(t, err, ErrUnknownStatus)
+	})
+}
+
+func TestGetAllSchedules_FilterByToken(t *testing.T) {
+	db := setupTestDB(t)
+
+	tokenA := "0x751f3c0aF0Ed18d9F70108CD0c4d878Aa0De59A8"
+	tokenB := "0x1111111111111111111111111111111111111111"
+
+	for i, token := range []string{tokenA, tokenB, tokenA} {
+		schedule := &models.VestingSchedule{
+			Beneficiary: "0x000000000000000000000000000000000000000" + string('0'+rune(i)),
+			Token:       token,
+			Start:       time.Now(),
+			Cliff:       time.Now(),
+			Duration:    1000,
+			Amount:      "1000",
+			Released:    "0",
+		}
+		err := db.CreateOrUpdateSchedule(schedule)
+		assert.NoError(t, err)
+	}
+
+	schedules, err := db.GetAllSchedules(ScheduleFilter{Token: tokenA}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 2)
+	for _, s := range schedules {
+		assert.Equal(t, tokenA, s.Token)
+	}
+
+	schedules, err = db.GetAllSchedules(ScheduleFilter{Token: tokenB}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 1)
+
+	schedules, err = db.GetAllSchedules(ScheduleFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 3)
+}
+
+func TestGetAllSchedules_StableOrdering(t *testing.T) {
+	db := setupTestDB(t)
+
+	for i := 0; i < 6; i++ {
+		schedule := &models.VestingSchedule{
+			Beneficiary: "0x000000000000000000000000000000000000000" + string('0'+rune(i)),
+			Start:       time.Now(),
+			Cliff:       time.Now(),
+			Duration:    int64(100 - i), // Reverse of insertion order
+			Amount:      "1000",
+			Released:    "0",
+		}
+		err := db.CreateOrUpdateSchedule(schedule)
+		assert.NoError(t, err)
+	}
+
+	ids := func(schedules []models.VestingSchedule) []uint {
+		out := make([]uint, len(schedules))
+		for i, s := range schedules {
+			out[i] = s.ID
+		}
+		return out
+	}
+
+	// Default ordering is ID ascending and repeatable
+	first, err := db.GetAllSchedules(ScheduleFilter{}, 10, 0)
+	assert.NoError(t, err)
+	second, err := db.GetAllSchedules(ScheduleFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ids(first), ids(second))
+	assert.Equal(t, []uint{1, 2, 3, 4, 5, 6}, ids(first))
+
+	// Pages are contiguous slices of the full ordering
+	page1, err := db.GetAllSchedules(ScheduleFilter{}, 3, 0)
+	assert.NoError(t, err)
+	page2, err := db.GetAllSchedules(ScheduleFilter{}, 3, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, ids(first), append(ids(page1), ids(page2)...))
+
+	// Configured ordering is applied
+	order, err := ParseScheduleOrder("duration asc")
+	assert.NoError(t, err)
+	db.SetScheduleOrder(order)
+
+	byDuration, err := db.GetAllSchedules(ScheduleFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{6, 5, 4, 3, 2, 1}, ids(byDuration))
+}
+
+func TestGetAllSchedules_Cursor(t *testing.T) {
+	db := setupTestDB(t)
+
+	// Durations repeat so cursors must break ties on ID
+	for i := 0; i < 7; i++ {
+		schedule := &models.VestingSchedule{
+			Beneficiary: "0x000000000000000000000000000000000000000" + string('0'+rune(i)),
+			Start:       time.Now(),
+			Cliff:       time.Now(),
+			Duration:    int64(100 - i%3),
+			Amount:      "1000",
+			Released:    "0",
+		}
+		assert.NoError(t, db.CreateOrUpdateSchedule(schedule))
+	}
+
+	walk := func(t *testing.T, limit int) []uint {
+		var seen []uint
+		filter := ScheduleFilter{}
+		for {
+			page, err := db.GetAllSchedules(filter, limit, 0)
+			assert.NoError(t, err)
+			for _, s := range page {
+				seen = append(seen, s.ID)
+			}
+			if len(page) < limit {
+				return seen
+			}
+			filter.AfterID = page[len(page)-1].ID
+		}
+	}
+
+	for _, orderBy := range []string{"id asc", "id desc", "duration asc", "duration desc"} {
+		t.Run(orderBy, func(t *testing.T) {
+			order, err := ParseScheduleOrder(orderBy)
+			assert.NoError(t, err)
+			db.SetScheduleOrder(order)
+
+			all, err := db.GetAllSchedules(ScheduleFilter{}, 100, 0)
+			assert.NoError(t, err)
+			want := make([]uint, len(all))
+			for i, s := range all {
+				want[i] = s.ID
+			}
+
+			// Every page size covers the listing once, in order, with no gaps or repeats
+			for _, limit := range []int{1, 2, 3, 7} {
+				assert.Equal(t, want, walk(t, limit), "limit %d", limit)
+			}
+		})
+	}
+
+	// A cursor anchored on a missing schedule is rejected
+	order, err := ParseScheduleOrder("duration asc")
+	assert.NoError(t, err)
+	db.SetScheduleOrder(order)
+	_, err = db.GetAllSchedules(ScheduleFilter{AfterID: 999}, 10, 0)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestParseScheduleOrder(t *testing.T) {
+	order, err := ParseScheduleOrder("created_at DESC")
+	assert.NoError(t, err)
+	assert.Equal(t, ScheduleOrder{Column: "created_at", Desc: true}, order)
+
+	order, err = ParseScheduleOrder("id")
+	assert.NoError(t, err)
+	assert.Equal(t, ScheduleOrder{Column: "id"}, order)
+
+	_, err = ParseScheduleOrder("amount; DROP TABLE vesting_schedules")
+	assert.Error(t, err)
+
+	_, err = ParseScheduleOrder("id sideways")
+	assert.Error(t, err)
+
+	_, err = ParseScheduleOrder("")
+	assert.Error(t, err)
 }
 
 func TestMarkScheduleAsRevoked(t *testing.T) {
@@ -113,13 +506,123 @@ func TestMarkScheduleAsRevoked(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Mark as revoked
-	err = db.MarkScheduleAsRevoked(beneficiary)
+	err = db.MarkScheduleAsRevoked(beneficiary, nil)
 	assert.NoError(t, err)
 
 	// Verify it's revoked
 	_, err = db.GetScheduleByBeneficiary(beneficiary)
 	// Should return error because GetScheduleByBeneficiary filters out revoked schedules
 	assert.Error(t, err)
+
+	// Still visible when revoked schedules are included
+	revoked, err := db.GetScheduleIncludingRevoked(beneficiary)
+	assert.NoError(t, err)
+	assert.True(t, revoked.Revoked)
+}
+
+func TestMarkScheduleAsRevoked_RevocationModes(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	now := time.Now()
+
+	revoke := func(t *testing.T, mode RevocationMode) *Database {
+		db := setupTestDB(t)
+		db.SetRevocationMode(mode)
+
+		require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{
+			Beneficiary: beneficiary,
+			CreationTx:  "0xcreate",
+			Start:       now,
+			Cliff:       now,
+			Duration:    3600,
+			Amount:      "1000",
+			Released:    "0",
+			Revocable:   true,
+		}))
+		require.NoError(t, db.MarkScheduleAsRevoked(beneficiary, nil))
+		return db
+	}
+
+	t.Run("Flag keeps the revoked schedule", func(t *testing.T) {
+		db := revoke(t, RevocationModeFlag)
+
+		_, err := db.GetScheduleByBeneficiary(beneficiary)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		revoked, err := db.GetScheduleIncludingRevoked(beneficiary)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked)
+
+		count, err := db.CountSchedulesByStatus(StatusFilterRevoked, ScheduleFilter{}, now)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		presence, err := db.GetBeneficiaryPresence(beneficiary)
+		require.NoError(t, err)
+		assert.True(t, presence.HasSchedules)
+	})
+
+	t.Run("Delete hides the schedule from every read", func(t *testing.T) {
+		db := revoke(t, RevocationModeDelete)
+
+		_, err := db.GetScheduleByBeneficiary(beneficiary)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		_, err = db.GetScheduleIncludingRevoked(beneficiary)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+		require.NoError(t, err)
+		assert.Empty(t, schedules)
+
+		count, err := db.CountSchedules(ScheduleFilter{IncludeRevoked: true})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		count, err = db.CountSchedulesByStatus(StatusFilterRevoked, ScheduleFilter{}, now)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		presence, err := db.GetBeneficiaryPresence(beneficiary)
+		require.NoError(t, err)
+		assert.False(t, presence.HasSchedules)
+
+		// The row is kept, marked revoked, for auditing
+		var deleted models.VestingSchedule
+		require.NoError(t, db.DB.Unscoped().Where("beneficiary = ?", beneficiary).First(&deleted).Error)
+		assert.True(t, deleted.Revoked)
+		assert.True(t, deleted.DeletedAt.Valid)
+	})
+
+	t.Run("Delete survives replayed events", func(t *testing.T) {
+		db := revoke(t, RevocationModeDelete)
+
+		require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{
+			Beneficiary: beneficiary,
+			CreationTx:  "0xcreate",
+			Start:       now,
+			Cliff:       now,
+			Duration:    3600,
+			Amount:      "1000",
+			Released:    "0",
+		}))
+		assert.NoError(t, db.AddReleased(beneficiary, "100", nil))
+
+		_, err := db.GetScheduleIncludingRevoked(beneficiary)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		var count int64
+		require.NoError(t, db.DB.Unscoped().Model(&models.VestingSchedule{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestParseRevocationMode(t *testing.T) {
+	mode, err := ParseRevocationMode(" Delete ")
+	require.NoError(t, err)
+	assert.Equal(t, RevocationModeDelete, mode)
+
+	_, err = ParseRevocationMode("purge")
+	assert.Error(t, err)
 }
 
 func TestUpdateReleased(t *testing.T) {
@@ -143,7 +646,7 @@ func TestUpdateReleased(t *testing.T) {
 
 	// Update released amount
 	newReleased := "250000000000000000000"
-	err = db.UpdateReleased(beneficiary, newReleased)
+	err = db.UpdateReleased(schedule.ID, newReleased)
 	assert.NoError(t, err)
 
 	// Verify update
@@ -152,6 +655,159 @@ func TestUpdateReleased(t *testing.T) {
 	assert.Equal(t, newReleased, retrieved.Released)
 }
 
+func TestGetLatestScheduleIDs(t *testing.T) {
+	db := setupTestDB(t)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	schedules := []models.VestingSchedule{
+		{Beneficiary: alice, Amount: "1000", Released: "0", Revoked: true, CreationTx: "0x01"},
+		{Beneficiary: alice, Amount: "2000", Released: "0", CreationTx: "0x02"},
+		{Beneficiary: bob, Amount: "1000", Released: "0", Revoked: true, CreationTx: "0x03"},
+	}
+	require.NoError(t, db.DB.Create(&schedules).Error)
+
+	latest, err := db.GetLatestScheduleIDs([]string{alice, bob, "0x0000000000000000000000000000000000000001"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint{alice: schedules[1].ID, bob: schedules[2].ID}, latest)
+}
+
+func TestAddReleased_ConcurrentUpdates(t *testing.T) {
+	db := setupTestDB(t)
+
+	// A single connection keeps the in-memory database shared across goroutines
+	sqlDB, err := db.DB.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	err = db.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: beneficiary,
+		Start:       time.Now(),
+		Cliff:       time.Now(),
+		Duration:    1000,
+		Amount:      "1000000",
+		Released:    "0",
+	})
+	assert.NoError(t, err)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	var succeeded int32
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := db.AddReleased(beneficiary, "100", nil); err != nil {
+				errs <- err
+				return
+			}
+			atomic.AddInt32(&succeeded, 1)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Only lost races may fail, and they must say so
+	for err := range errs {
+		assert.ErrorIs(t, err, ErrConcurrentUpdate)
+	}
+	assert.Greater(t, succeeded, int32(0))
+
+	// No successful increment is lost
+	var schedule models.VestingSchedule
+	err = db.DB.Where("beneficiary = ?", beneficiary).First(&schedule).Error
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d", 100*succeeded), schedule.Released)
+	assert.Equal(t, uint(1+succeeded), schedule.Version)
+}
+
+func TestCreateOrUpdateSchedule_ConcurrentUpdates(t *testing.T) {
+	db := setupTestDB(t)
+
+	// A single connection keeps the in-memory database shared across goroutines
+	sqlDB, err := db.DB.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	err = db.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: beneficiary,
+		Start:       time.Now(),
+		Cliff:       time.Now(),
+		Duration:    1000,
+		Amount:      "1000000",
+		Released:    "0",
+	})
+	assert.NoError(t, err)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	var succeeded int32
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := db.CreateOrUpdateSchedule(&models.VestingSchedule{
+				Beneficiary: beneficiary,
+				Duration:    1000,
+				Amount:      "1000000",
+				Released:    fmt.Sprintf("%d", i+1),
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			atomic.AddInt32(&succeeded, 1)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	// Only lost races may fail, and they must say so
+	for err := range errs {
+		assert.ErrorIs(t, err, ErrConcurrentUpdate)
+	}
+	assert.Greater(t, succeeded, int32(0))
+
+	// Every successful update was applied on top of the one before it
+	var schedule models.VestingSchedule
+	err = db.DB.Where("beneficiary = ?", beneficiary).First(&schedule).Error
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1+succeeded), schedule.Version)
+	assert.NotEqual(t, "0", schedule.Released)
+}
+
+func TestCreateOrUpdateSchedule_StaleVersionRejected(t *testing.T) {
+	db := setupTestDB(t)
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	err := db.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: beneficiary,
+		Amount:      "1000",
+		Released:    "0",
+	})
+	assert.NoError(t, err)
+
+	var stale models.VestingSchedule
+	assert.NoError(t, db.DB.Where("beneficiary = ?", beneficiary).First(&stale).Error)
+
+	// Another writer bumps the version
+	assert.NoError(t, db.UpdateReleased(stale.ID, "10"))
+
+	updated, err := db.updateIfVersion(&stale, map[string]interface{}{"released": "999"})
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	current, err := db.GetScheduleByBeneficiary(beneficiary)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", current.Released)
+}
+
 func TestCreateEvent(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -168,12 +824,39 @@ func TestCreateEvent(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Retrieve events
-	events, err := db.GetEventsByBeneficiary(event.Beneficiary, 10, 0)
+	events, err := db.GetEventsByBeneficiary(event.Beneficiary, EventFilter{}, 10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, events, 1)
 	assert.Equal(t, event.EventType, events[0].EventType)
 }
 
+func TestCreateEvent_Idempotent(t *testing.T) {
+	db := setupTestDB(t)
+
+	txHash := "0xabcdef1234567890"
+	newEvent := func(eventType string, logIndex uint) *models.VestingEvent {
+		return &models.VestingEvent{
+			EventType:       eventType,
+			Beneficiary:     "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+			Amount:          "1000",
+			BlockNumber:     12345678,
+			TransactionHash: txHash,
+			LogIndex:        logIndex,
+			Timestamp:       time.Now(),
+		}
+	}
+
+	require.NoError(t, db.CreateEvent(newEvent("TokensReleased", 3)))
+	// The same transaction may emit several events
+	require.NoError(t, db.CreateEvent(newEvent("VestingRevoked", 4)))
+	// Reprocessing an event leaves the stored row alone
+	assert.ErrorIs(t, db.CreateEvent(newEvent("TokensReleased", 3)), ErrDuplicateEvent)
+
+	var count int64
+	require.NoError(t, db.DB.Model(&models.VestingEvent{}).Where("transaction_hash = ?", txHash).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
 func TestGetEventsByBeneficiary(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -195,12 +878,269 @@ func TestGetEventsByBeneficiary(t *testing.T) {
 	}
 
 	// Test retrieval
-	events, err := db.GetEventsByBeneficiary(beneficiary, 10, 0)
+	events, err := db.GetEventsByBeneficiary(beneficiary, EventFilter{}, 10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, events, 3)
 
 	// Events should be ordered by block_number DESC
 	assert.True(t, events[0].BlockNumber >= events[1].BlockNumber)
+
+	// Ascending order returns the oldest event first
+	events, err = db.GetEventsByBeneficiary(beneficiary, EventFilter{Ascending: true}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, uint64(12345678), events[0].BlockNumber)
+	assert.Equal(t, "VestingScheduleCreated", events[0].EventType)
+	assert.True(t, events[1].BlockNumber < events[2].BlockNumber)
+}
+
+func TestGetEvents_MinAmount(t *testing.T) {
+	db := setupTestDB(t)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	events := []models.VestingEvent{
+		// Lexically greater than "1000" but numerically smaller
+		{EventType: "TokensReleased", Beneficiary: alice, Amount: "999", BlockNumber: 1, TransactionHash: "0x01"},
+		{EventType: "TokensReleased", Beneficiary: alice, Amount: "1000", BlockNumber: 2, TransactionHash: "0x02"},
+		{EventType: "TokensReleased", Beneficiary: alice, Amount: "5000", BlockNumber: 3, TransactionHash: "0x03"},
+		{EventType: "TokensReleased", Beneficiary: alice, Amount: "250000000000000000000", BlockNumber: 4, TransactionHash: "0x04"},
+		{EventType: "VestingRevoked", Beneficiary: alice, Amount: "", BlockNumber: 5, TransactionHash: "0x05"},
+		{EventType: "TokensReleased", Beneficiary: bob, Amount: "20", BlockNumber: 6, TransactionHash: "0x06"},
+		{EventType: "TokensReleased", Beneficiary: bob, Amount: "7000", BlockNumber: 7, TransactionHash: "0x07"},
+	}
+	for i := range events {
+		require.NoError(t, db.CreateEvent(&events[i]))
+	}
+
+	filter := EventFilter{MinAmount: big.NewInt(1000), Ascending: true}
+
+	found, err := db.GetEventsByBeneficiary(alice, filter, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	assert.Equal(t, "1000", found[0].Amount)
+	assert.Equal(t, "5000", found[1].Amount)
+	assert.Equal(t, "250000000000000000000", found[2].Amount)
+
+	found, err = db.GetEventsByBeneficiaries([]string{alice, bob}, filter, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, found, 4)
+	for _, event := range found {
+		assert.NotEqual(t, "20", event.Amount)
+		assert.NotEqual(t, "999", event.Amount)
+	}
+}
+
+func TestGetEvents_EventType(t *testing.T) {
+	db := setupTestDB(t)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	events := []models.VestingEvent{
+		{EventType: "VestingScheduleCreated", Beneficiary: alice, Amount: "5000", BlockNumber: 1, TransactionHash: "0x01"},
+		{EventType: "TokensReleased", Beneficiary: alice, Amount: "100", BlockNumber: 2, TransactionHash: "0x02"},
+		{EventType: "TokensReleased", Beneficiary: alice, Amount: "200", BlockNumber: 3, TransactionHash: "0x03"},
+	}
+	for i := range events {
+		require.NoError(t, db.CreateEvent(&events[i]))
+	}
+
+	found, err := db.GetEventsByBeneficiary(alice, EventFilter{EventType: "TokensReleased", Ascending: true}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "100", found[0].Amount)
+	assert.Equal(t, "200", found[1].Amount)
+}
+
+func TestGetEventsByBeneficiaries(t *testing.T) {
+	db := setupTestDB(t)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	carol := "0x0000000000000000000000000000000000000001"
+
+	// Interleave blocks across beneficiaries
+	events := []struct {
+		beneficiary string
+		block       uint64
+	}{
+		{alice, 100},
+		{bob, 200},
+		{carol, 250},
+		{alice, 300},
+		{bob, 400},
+	}
+	for i, e := range events {
+		err := db.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     e.beneficiary,
+			Amount:          "1",
+			BlockNumber:     e.block,
+			TransactionHash: "0xmulti" + string('0'+rune(i)),
+			Timestamp:       time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	result, err := db.GetEventsByBeneficiaries([]string{alice, bob}, EventFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+
+	// Merged across beneficiaries, ordered by block_number DESC
+	blocks := make([]uint64, len(result))
+	for i, e := range result {
+		blocks[i] = e.BlockNumber
+		assert.NotEqual(t, carol, e.Beneficiary)
+	}
+	assert.Equal(t, []uint64{400, 300, 200, 100}, blocks)
+
+	// Block range narrows the merged result
+	from, to := uint64(200), uint64(300)
+	result, err = db.GetEventsByBeneficiaries([]string{alice, bob}, EventFilter{FromBlock: &from, ToBlock: &to}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestGetEvents_Cursor(t *testing.T) {
+	db := setupTestDB(t)
+
+	alice := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	bob := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+
+	// Several events share a block, and some share a log index across transactions
+	seeded := []struct {
+		beneficiary string
+		block       uint64
+		logIndex    uint
+	}{
+		{alice, 100, 0},
+		{alice, 100, 1},
+		{bob, 100, 1},
+		{alice, 200, 0},
+		{bob, 200, 0},
+		{alice, 200, 3},
+		{bob, 300, 2},
+		{alice, 300, 2},
+		{alice, 400, 0},
+	}
+	for i, e := range seeded {
+		err := db.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     e.beneficiary,
+			Amount:          "1",
+			BlockNumber:     e.block,
+			LogIndex:        e.logIndex,
+			TransactionHash: "0xcursor" + string('0'+rune(i)),
+			Timestamp:       time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	beneficiaries := []string{alice, bob}
+	walk := func(t *testing.T, filter EventFilter, limit int) []uint {
+		var seen []uint
+		for {
+			page, err := db.GetEventsByBeneficiaries(beneficiaries, filter, limit, 0)
+			assert.NoError(t, err)
+			for _, e := range page {
+				seen = append(seen, e.ID)
+			}
+			if len(page) < limit {
+				return seen
+			}
+			last := page[len(page)-1]
+			filter.After = &EventCursor{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex, ID: last.ID}
+		}
+	}
+
+	for _, ascending := range []bool{false, true} {
+		filter := EventFilter{Ascending: ascending}
+		all, err := db.GetEventsByBeneficiaries(beneficiaries, filter, 100, 0)
+		assert.NoError(t, err)
+		assert.Len(t, all, len(seeded))
+		want := make([]uint, len(all))
+		for i, e := range all {
+			want[i] = e.ID
+		}
+
+		for _, limit := range []int{1, 2, 4, 9} {
+			assert.Equal(t, want, walk(t, filter, limit), "ascending %v, limit %d", ascending, limit)
+		}
+	}
+
+	// The cursor combines with other filters
+	from := uint64(200)
+	page, err := db.GetEventsByBeneficiary(alice, EventFilter{
+		FromBlock: &from,
+		After:     &EventCursor{BlockNumber: 300, LogIndex: 2, ID: 8},
+	}, 10, 0)
+	assert.NoError(t, err)
+	blocks := make([]uint64, len(page))
+	for i, e := range page {
+		blocks[i] = e.BlockNumber
+	}
+	assert.Equal(t, []uint64{200, 200}, blocks)
+}
+
+func TestGetLatestEventsByBeneficiaries(t *testing.T) {
+	db := setupTestDB(t)
+
+	active := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	quiet := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+
+	events := []models.VestingEvent{
+		{EventType: "VestingScheduleCreated", Beneficiary: active, Amount: "1000", BlockNumber: 100, TransactionHash: "0x1", Timestamp: time.Now()},
+		{EventType: "TokensReleased", Beneficiary: active, Amount: "250", BlockNumber: 300, TransactionHash: "0x2", Timestamp: time.Now()},
+		{EventType: "TokensReleased", Beneficiary: active, Amount: "100", BlockNumber: 200, TransactionHash: "0x3", Timestamp: time.Now()},
+	}
+	for i := range events {
+		require.NoError(t, db.CreateEvent(&events[i]))
+	}
+
+	latest, err := db.GetLatestEventsByBeneficiaries([]string{active, quiet})
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, uint64(300), latest[active].BlockNumber)
+	assert.Equal(t, "0x2", latest[active].TransactionHash)
+
+	_, ok := latest[quiet]
+	assert.False(t, ok)
+}
+
+func TestGetEventTypeHighWaterMarks(t *testing.T) {
+	db := setupTestDB(t)
+
+	marks, err := db.GetEventTypeHighWaterMarks()
+	require.NoError(t, err)
+	assert.Empty(t, marks)
+
+	events := []struct {
+		eventType string
+		block     uint64
+	}{
+		{"VestingScheduleCreated", 100},
+		{"VestingScheduleCreated", 250},
+		{"TokensReleased", 300},
+		{"TokensReleased", 180},
+		{"TokensReleased", 420},
+		{"VestingRevoked", 275},
+	}
+	for i, e := range events {
+		require.NoError(t, db.CreateEvent(&models.VestingEvent{
+			EventType:       e.eventType,
+			Beneficiary:     "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+			Amount:          "1",
+			BlockNumber:     e.block,
+			TransactionHash: fmt.Sprintf("0x%064d", i),
+		}))
+	}
+
+	marks, err = db.GetEventTypeHighWaterMarks()
+	require.NoError(t, err)
+	assert.Equal(t, []EventTypeHighWater{
+		{EventType: "TokensReleased", LatestBlock: 420, Count: 3},
+		{EventType: "VestingRevoked", LatestBlock: 275, Count: 1},
+		{EventType: "VestingScheduleCreated", LatestBlock: 250, Count: 2},
+	}, marks)
 }
 
 func TestGetLastProcessedBlock(t *testing.T) {
@@ -225,8 +1165,73 @@ func TestGetLastProcessedBlock(t *testing.T) {
 		assert.NoError(t, err)
 	}
 
-	// Get last processed block
+	// Without a saved checkpoint, fall back to the highest event block
 	block, err = db.GetLastProcessedBlock()
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(3000), block)
+
+	// A saved checkpoint takes over, even past blocks without events
+	require.NoError(t, db.SaveLastProcessedBlock(8000))
+	block, err = db.GetLastProcessedBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8000), block)
+
+	// The checkpoint never moves backwards
+	require.NoError(t, db.SaveLastProcessedBlock(5000))
+	block, err = db.GetLastProcessedBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8000), block)
+
+	var rows int64
+	require.NoError(t, db.DB.Model(&models.SyncState{}).Count(&rows).Error)
+	assert.Equal(t, int64(1), rows)
+}
+
+func TestGetBeneficiaryPresence(t *testing.T) {
+	db := setupTestDB(t)
+
+	withSchedule := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	withEvents := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+	unknown := "0x0000000000000000000000000000000000000001"
+
+	require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: withSchedule,
+		Amount:      "1000",
+		Released:    "0",
+		Revoked:     true,
+	}))
+	require.NoError(t, db.CreateEvent(&models.VestingEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     withEvents,
+		Amount:          "10",
+		TransactionHash: "0x01",
+	}))
+
+	presence, err := db.GetBeneficiaryPresence(withSchedule)
+	require.NoError(t, err)
+	assert.Equal(t, BeneficiaryPresence{HasSchedules: true}, presence)
+
+	presence, err = db.GetBeneficiaryPresence(withEvents)
+	require.NoError(t, err)
+	assert.Equal(t, BeneficiaryPresence{HasEvents: true}, presence)
+
+	presence, err = db.GetBeneficiaryPresence(unknown)
+	require.NoError(t, err)
+	assert.Equal(t, BeneficiaryPresence{}, presence)
+}
+
+func TestGetScheduleIncludingDeleted(t *testing.T) {
+	db := setupTestDB(t)
+	db.SetRevocationMode(RevocationModeDelete)
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	require.NoError(t, db.CreateOrUpdateSchedule(&models.VestingSchedule{Beneficiary: beneficiary, Amount: "1000", Released: "0"}))
+	require.NoError(t, db.MarkScheduleAsRevoked(beneficiary, nil))
+
+	_, err := db.GetScheduleIncludingRevoked(beneficiary)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	schedule, err := db.GetScheduleIncludingDeleted(beneficiary)
+	require.NoError(t, err)
+	assert.True(t, schedule.Revoked)
+	assert.True(t, schedule.DeletedAt.Valid)
 }
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RevocationMode controls what happens to a schedule when it is revoked
+type RevocationMode string
+
+const (
+	// RevocationModeFlag keeps revoked schedules, marked revoked, so they stay
+	// visible to lookups and revoked listings
+	RevocationModeFlag RevocationMode = "flag"
+	// RevocationModeDelete marks revoked schedules and soft-deletes them, so
+	// every read treats the beneficiary as never having held the schedule
+	RevocationModeDelete RevocationMode = "delete"
+)
+
+// ParseRevocationMode parses a revocation mode name
+func ParseRevocationMode(value string) (RevocationMode, error) {
+	switch mode := RevocationMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case RevocationModeFlag, RevocationModeDelete:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid revocation mode %q (expected flag or delete)", value)
+	}
+}
+
+// SetRevocationMode sets how MarkScheduleAsRevoked treats revoked schedules.
+// Schedules already deleted stay deleted when switching back to flag mode.
+func (d *Database) SetRevocationMode(mode RevocationMode) {
+	d.revocationMode = mode
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+// ScheduleMode controls how many schedules a beneficiary may hold
+type ScheduleMode string
+
+const (
+	// ScheduleModeSingle allows one schedule per beneficiary; a new schedule
+	// for the same beneficiary replaces the stored one
+	ScheduleModeSingle ScheduleMode = "single"
+	// ScheduleModeMulti allows several schedules per beneficiary, each keyed
+	// by the transaction that created it
+	ScheduleModeMulti ScheduleMode = "multi"
+)
+
+// Unique indexes backing each schedule mode. Soft-deleted rows are excluded.
+const (
+	beneficiaryUniqueIndex = "idx_vesting_schedules_beneficiary_unique"
+	creationTxUniqueIndex  = "idx_vesting_schedules_creation_tx_unique"
+)
+
+// ParseScheduleMode parses a schedule mode name
+func ParseScheduleMode(value string) (ScheduleMode, error) {
+	switch mode := ScheduleMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case ScheduleModeSingle, ScheduleModeMulti:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid schedule mode %q (expected single or multi)", value)
+	}
+}
+
+// ApplyScheduleMode sets the schedule mode and migrates the unique constraint
+// to match: single mode makes beneficiaries unique, multi mode makes creation
+// transactions unique. Switching to single mode fails if a beneficiary already
+// holds several schedules.
+func (d *Database) ApplyScheduleMode(mode ScheduleMode) error {
+	drop, create, columns := creationTxUniqueIndex, beneficiaryUniqueIndex, "beneficiary"
+	where := "deleted_at IS NULL"
+	if mode == ScheduleModeMulti {
+		drop, create, columns = beneficiaryUniqueIndex, creationTxUniqueIndex, "creation_tx"
+		// Schedules backfilled from chain have no creation transaction
+		where += " AND creation_tx <> ''"
+	}
+
+	if err := d.DB.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", drop)).Error; err != nil {
+		return fmt.Errorf("failed to drop index %s: %w", drop, err)
+	}
+	if err := d.DB.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON vesting_schedules (%s) WHERE %s", create, columns, where)).Error; err != nil {
+		return fmt.Errorf("failed to create index %s (are there duplicate schedules?): %w", create, err)
+	}
+
+	d.scheduleMode = mode
+	return nil
+}
+
+// scheduleKey returns the condition matching the stored copy of a schedule. In
+// multi mode schedules are matched by creation transaction; those without one
+// fall back to the beneficiary, as in single mode.
+func (d *Database) scheduleKey(schedule *models.VestingSchedule) (string, interface{}) {
+	if d.scheduleMode == ScheduleModeMulti && schedule.CreationTx != "" {
+		return "creation_tx = ?", schedule.CreationTx
+	}
+	return "beneficiary = ?", schedule.Beneficiary
+}
+
+// eventScheduleKey returns the condition matching the schedule a release or
+// revocation applies to. Those events name only the beneficiary, so in multi
+// mode they apply to the schedule whose creation event most recently precedes
+// the event at the given position (or the latest one when at is nil). When no
+// recorded creation precedes it, such as for schedules backfilled from chain,
+// the beneficiary's newest schedule is used.
+func (d *Database) eventScheduleKey(beneficiary string, at *EventCursor) (string, interface{}, error) {
+	if d.scheduleMode != ScheduleModeMulti {
+		return "beneficiary = ?", beneficiary, nil
+	}
+
+	created := d.DB.Where("beneficiary = ? AND event_type = ?", beneficiary, "VestingScheduleCreated")
+	if at != nil {
+		created = created.Where("block_number < ? OR (block_number = ? AND log_index < ?)", at.BlockNumber, at.BlockNumber, at.LogIndex)
+	}
+	var creation models.VestingEvent
+	err := created.Order("block_number DESC, log_index DESC").Take(&creation).Error
+	if err == nil {
+		return "creation_tx = ?", creation.TransactionHash, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil, err
+	}
+
+	var newest models.VestingSchedule
+	err = d.DB.Where("beneficiary = ?", beneficiary).Order("id DESC").Take(&newest).Error
+	if err == nil {
+		return "id = ?", newest.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil, err
+	}
+	return "beneficiary = ?", beneficiary, nil
+}
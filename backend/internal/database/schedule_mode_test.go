@@ -0,0 +1,131 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaldun-tech/token-vesting-backend/internal/models"
+)
+
+func TestParseScheduleMode(t *testing.T) {
+	mode, err := ParseScheduleMode("Multi")
+	require.NoError(t, err)
+	assert.Equal(t, ScheduleModeMulti, mode)
+
+	_, err = ParseScheduleMode("many")
+	assert.Error(t, err)
+}
+
+func TestApplyScheduleMode(t *testing.T) {
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	schedule := func(amount, creationTx string) *models.VestingSchedule {
+		return &models.VestingSchedule{Beneficiary: beneficiary, Amount: amount, Released: "0", CreationTx: creationTx}
+	}
+
+	t.Run("Single mode keeps one schedule per beneficiary", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.ApplyScheduleMode(ScheduleModeSingle))
+
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("1000", "0xtx1")))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("2000", "0xtx2")))
+
+		schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		assert.Equal(t, "2000", schedules[0].Amount)
+
+		// The constraint rejects a second row even when written directly
+		assert.Error(t, db.DB.Create(schedule("3000", "0xtx3")).Error)
+	})
+
+	t.Run("Multi mode keeps one schedule per creation transaction", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.ApplyScheduleMode(ScheduleModeMulti))
+
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("1000", "0xtx1")))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("2000", "0xtx2")))
+		// Reprocessing the same creation event neither duplicates nor alters it
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("1500", "0xtx1")))
+
+		schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, "1000", schedules[0].Amount)
+		assert.Equal(t, "2000", schedules[1].Amount)
+
+		assert.Error(t, db.DB.Create(schedule("3000", "0xtx2")).Error)
+	})
+
+	for _, mode := range []ScheduleMode{ScheduleModeSingle, ScheduleModeMulti} {
+		t.Run("Replayed creation is a no-op in "+string(mode)+" mode", func(t *testing.T) {
+			db := setupTestDB(t)
+			require.NoError(t, db.ApplyScheduleMode(mode))
+
+			require.NoError(t, db.CreateOrUpdateSchedule(schedule("1000", "0xtx1")))
+			require.NoError(t, db.AddReleased(beneficiary, "250", nil))
+			before, err := db.GetScheduleByBeneficiary(beneficiary)
+			require.NoError(t, err)
+
+			replayed := schedule("1000", "0xtx1")
+			require.NoError(t, db.CreateOrUpdateSchedule(replayed))
+			assert.Equal(t, before.ID, replayed.ID)
+
+			schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+			require.NoError(t, err)
+			require.Len(t, schedules, 1)
+			assert.Equal(t, "250", schedules[0].Released)
+			assert.Equal(t, before.Version, schedules[0].Version)
+			assert.True(t, before.UpdatedAt.Equal(schedules[0].UpdatedAt))
+		})
+	}
+
+	t.Run("Multi mode applies a release to one schedule", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.ApplyScheduleMode(ScheduleModeMulti))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("1000", "0xtx1")))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("2000", "0xtx2")))
+		for i, tx := range []string{"0xtx1", "0xtx2"} {
+			require.NoError(t, db.CreateEvent(&models.VestingEvent{
+				EventType:       "VestingScheduleCreated",
+				Beneficiary:     beneficiary,
+				TransactionHash: tx,
+				BlockNumber:     uint64(10 * (i + 1)),
+			}))
+		}
+
+		require.NoError(t, db.AddReleased(beneficiary, "250", &EventCursor{BlockNumber: 15}))
+		require.NoError(t, db.AddReleased(beneficiary, "50", nil))
+
+		schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, "250", schedules[0].Released)
+		assert.Equal(t, "50", schedules[1].Released)
+	})
+
+	t.Run("Multi mode without recorded creations uses the newest schedule", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.ApplyScheduleMode(ScheduleModeMulti))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("1000", "0xtx1")))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("2000", "0xtx2")))
+
+		require.NoError(t, db.MarkScheduleAsRevoked(beneficiary, &EventCursor{BlockNumber: 30}))
+
+		schedules, err := db.GetSchedulesByBeneficiary(beneficiary)
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.False(t, schedules[0].Revoked)
+		assert.True(t, schedules[1].Revoked)
+	})
+
+	t.Run("Switching to single mode fails with duplicate beneficiaries", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.ApplyScheduleMode(ScheduleModeMulti))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("1000", "0xtx1")))
+		require.NoError(t, db.CreateOrUpdateSchedule(schedule("2000", "0xtx2")))
+
+		assert.Error(t, db.ApplyScheduleMode(ScheduleModeSingle))
+	})
+}
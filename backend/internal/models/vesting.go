@@ -1,25 +1,310 @@
 package models
 
 import (
+	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// ScheduleStatus is the derived lifecycle state of a vesting schedule
+type ScheduleStatus string
+
+const (
+	StatusPending ScheduleStatus = "pending" // Before start
+	StatusCliff   ScheduleStatus = "cliff"   // Started, before cliff
+	StatusVesting ScheduleStatus = "vesting" // After cliff, not fully vested
+	StatusVested  ScheduleStatus = "vested"  // Fully vested
+	StatusRevoked ScheduleStatus = "revoked" // Revoked by owner
+)
+
+// VestingModel selects when linear vesting begins
+type VestingModel string
+
+const (
+	// VestingFromStart vests linearly from start, with the amount accrued
+	// before the cliff unlocking at the cliff. This is the contract's formula.
+	VestingFromStart VestingModel = "from_start"
+	// VestingFromCliff vests linearly from the cliff to start+duration, so
+	// nothing accrues before the cliff
+	VestingFromCliff VestingModel = "from_cliff"
+)
+
+// ParseVestingModel parses a vesting model name
+func ParseVestingModel(value string) (VestingModel, error) {
+	switch model := VestingModel(strings.ToLower(strings.TrimSpace(value))); model {
+	case VestingFromStart, VestingFromCliff:
+		return model, nil
+	default:
+		return "", fmt.Errorf("unknown vesting model %q (want %s or %s)", value, VestingFromStart, VestingFromCliff)
+	}
+}
+
+// VestingParameters are the effective bounds of a schedule's linear vesting
+// under a vesting model
+type VestingParameters struct {
+	Model             VestingModel `json:"model"`
+	EffectiveStart    time.Time    `json:"effective_start"`
+	End               time.Time    `json:"end"`
+	EffectiveDuration int64        `json:"effective_duration"` // Seconds from effective start to end
+}
+
 // VestingSchedule represents a vesting schedule stored in the database
 type VestingSchedule struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	Beneficiary string         `gorm:"index;not null;size:42" json:"beneficiary"` // Ethereum address
+	Token       string         `gorm:"index;size:42" json:"token,omitempty"`      // Vested token contract address
 	Start       time.Time      `json:"start"`
 	Cliff       time.Time      `json:"cliff"`
-	Duration    int64          `json:"duration"` // Duration in seconds
-	Amount      string         `json:"amount"`   // Store as string to handle big numbers
-	Released    string         `json:"released"` // Store as string to handle big numbers
+	Duration    int64          `json:"duration"`       // Duration in seconds
+	EndsAt      time.Time      `gorm:"index" json:"-"` // Start plus duration, when the schedule is fully vested; stored for status filters
+	Amount      string         `json:"amount"`         // Store as string to handle big numbers
+	Released    string         `json:"released"`       // Store as string to handle big numbers
 	Revocable   bool           `json:"revocable"`
 	Revoked     bool           `json:"revoked"`
+	Labels      []string       `gorm:"type:text;serializer:json" json:"labels,omitempty"`        // Free-form admin labels, e.g. "team:engineering"
+	CreationTx  string         `gorm:"size:66;not null;default:''" json:"creation_tx,omitempty"` // Transaction that created the schedule; the upsert key in multi-schedule mode
+	Version     uint           `gorm:"not null;default:1" json:"-"`                              // Optimistic locking counter
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Status is derived at response time and not persisted
+	Status ScheduleStatus `gorm:"-" json:"status,omitempty"`
+	// LatestEvent is attached on request and not persisted
+	LatestEvent *VestingEvent `gorm:"-" json:"latest_event,omitempty"`
+	// Formatted holds human-readable amounts in the token's units, when known
+	Formatted *FormattedAmounts `gorm:"-" json:"formatted,omitempty"`
+	// Vesting holds the effective vesting parameters under the configured model
+	Vesting *VestingParameters `gorm:"-" json:"vesting,omitempty"`
+	// TimeRemainingSeconds and FullyVestedAt are derived at response time;
+	// see SetTimeRemaining
+	TimeRemainingSeconds *int64     `gorm:"-" json:"time_remaining_seconds,omitempty"`
+	FullyVestedAt        *time.Time `gorm:"-" json:"fully_vested_at,omitempty"`
+}
+
+// NormalizeTimestamps converts the schedule's timestamps to UTC so stored
+// values do not depend on the zone they were produced in, and derives EndsAt
+func (s *VestingSchedule) NormalizeTimestamps() {
+	s.Start = s.Start.UTC()
+	s.Cliff = s.Cliff.UTC()
+	s.EndsAt = s.Start.Add(time.Duration(s.Duration) * time.Second)
+}
+
+// BeforeSave stores schedule timestamps in UTC
+func (s *VestingSchedule) BeforeSave(*gorm.DB) error {
+	s.NormalizeTimestamps()
+	return nil
+}
+
+// FormattedAmounts are schedule amounts scaled by the token's decimals
+type FormattedAmounts struct {
+	Decimals uint8  `json:"decimals"`
+	Amount   string `json:"amount"`
+	Released string `json:"released"`
+}
+
+// Format populates Formatted using the given token decimals, rounded to places
+// decimal places. Negative places keep every digit. The raw amounts are untouched.
+func (s *VestingSchedule) Format(decimals uint8, places int) {
+	s.Formatted = &FormattedAmounts{
+		Decimals: decimals,
+		Amount:   FormatUnitsRounded(s.Amount, decimals, places),
+		Released: FormatUnitsRounded(s.Released, decimals, places),
+	}
+}
+
+// FormatUnits converts a base-unit integer string to a decimal string with the
+// given number of decimals, dropping trailing zeros (e.g. "1500000", 6 -> "1.5").
+// Malformed values are returned unchanged.
+func FormatUnits(amount string, decimals uint8) string {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return amount
+	}
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Neg(value)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(value, scale, new(big.Int))
+	if frac.Sign() == 0 {
+		return sign + whole.String()
+	}
+
+	fraction := frac.String()
+	fraction = strings.Repeat("0", int(decimals)-len(fraction)) + fraction
+	return sign + whole.String() + "." + strings.TrimRight(fraction, "0")
+}
+
+// FormatUnitsRounded is FormatUnits rounded to exactly places decimal places,
+// with halves rounded away from zero (e.g. "1234567", 6, 4 -> "1.2346"). The
+// rounding is done on the exact rational value, so no precision is lost before
+// it. Negative places format every digit, as FormatUnits does.
+func FormatUnitsRounded(amount string, decimals uint8, places int) string {
+	if places < 0 {
+		return FormatUnits(amount, decimals)
+	}
+
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return amount
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	rounded := new(big.Rat).SetFrac(value, scale).FloatString(places)
+	if strings.Trim(rounded, "-0.") == "" {
+		// Tiny negative amounts round to zero, not "-0"
+		rounded = strings.TrimPrefix(rounded, "-")
+	}
+	return rounded
+}
+
+// ComputeStatus derives the schedule's status at the given time. The
+// database status filters mirror these boundaries.
+func (s *VestingSchedule) ComputeStatus(now time.Time) ScheduleStatus {
+	end := s.Start.Add(time.Duration(s.Duration) * time.Second)
+
+	switch {
+	case s.Revoked:
+		return StatusRevoked
+	case now.Before(s.Start):
+		return StatusPending
+	case now.Before(s.Cliff):
+		return StatusCliff
+	case now.Before(end):
+		return StatusVesting
+	default:
+		return StatusVested
+	}
+}
+
+// TimeRemaining returns how long until the schedule is fully vested at
+// start+duration, or zero once it is. A revoked schedule vests no further, so
+// it has no time remaining.
+func (s *VestingSchedule) TimeRemaining(now time.Time) time.Duration {
+	end := s.Start.Add(time.Duration(s.Duration) * time.Second)
+	if s.Revoked || !now.Before(end) {
+		return 0
+	}
+	return end.Sub(now)
+}
+
+// SetTimeRemaining populates the derived time remaining, in whole seconds
+// rounded up, and the fully vested time. FullyVestedAt is left unset for
+// revoked schedules, which never become fully vested.
+func (s *VestingSchedule) SetTimeRemaining(now time.Time) {
+	remaining := int64((s.TimeRemaining(now) + time.Second - 1) / time.Second)
+	s.TimeRemainingSeconds = &remaining
+	s.FullyVestedAt = nil
+	if !s.Revoked {
+		end := s.Start.Add(time.Duration(s.Duration) * time.Second)
+		s.FullyVestedAt = &end
+	}
+}
+
+// EffectiveVesting returns the span the schedule vests linearly over under the
+// given model. The end is start+duration under both models; from_cliff only
+// moves the beginning to the cliff, when the cliff falls after start.
+func (s *VestingSchedule) EffectiveVesting(model VestingModel) VestingParameters {
+	if model == "" {
+		model = VestingFromStart
+	}
+
+	start := s.Start
+	end := s.Start.Add(time.Duration(s.Duration) * time.Second)
+	if model == VestingFromCliff && s.Cliff.After(start) {
+		start = s.Cliff
+		if start.After(end) {
+			start = end
+		}
+	}
+
+	return VestingParameters{
+		Model:             model,
+		EffectiveStart:    start.UTC(),
+		End:               end.UTC(),
+		EffectiveDuration: end.Unix() - start.Unix(),
+	}
+}
+
+// VestedAmount computes the amount vested at the given time under the given
+// model: nothing before the start or cliff, everything after start+duration,
+// and amount*elapsed/duration over the effective vesting span in between.
+// Under from_start this mirrors the contract's linear vesting formula.
+func (s *VestingSchedule) VestedAmount(model VestingModel, now time.Time) *big.Int {
+	amount, ok := new(big.Int).SetString(s.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	if s.notStarted(now) {
+		return big.NewInt(0)
+	}
+
+	params := s.EffectiveVesting(model)
+	if s.Duration <= 0 || params.EffectiveDuration <= 0 || !now.Before(params.End) {
+		return amount
+	}
+
+	elapsed := now.Unix() - params.EffectiveStart.Unix()
+	if elapsed <= 0 {
+		return big.NewInt(0)
+	}
+	vested := new(big.Int).Mul(amount, big.NewInt(elapsed))
+	return vested.Div(vested, big.NewInt(params.EffectiveDuration))
+}
+
+// notStarted reports whether nothing can have vested yet. Checking the start
+// as well as the cliff keeps schedules whose cliff is unset or precedes the
+// start, such as zero-duration grants starting in the future, at zero instead
+// of falling through to the fully vested case.
+func (s *VestingSchedule) notStarted(now time.Time) bool {
+	return now.Before(s.Start) || now.Before(s.Cliff)
+}
+
+// VestedFraction returns the exact share of the schedule vested at the given
+// time under the given model, from 0 to 1. It follows VestedAmount without
+// rounding to whole base units.
+func (s *VestingSchedule) VestedFraction(model VestingModel, now time.Time) *big.Rat {
+	if s.notStarted(now) {
+		return new(big.Rat)
+	}
+
+	params := s.EffectiveVesting(model)
+	if s.Duration <= 0 || params.EffectiveDuration <= 0 || !now.Before(params.End) {
+		return big.NewRat(1, 1)
+	}
+
+	elapsed := now.Unix() - params.EffectiveStart.Unix()
+	if elapsed <= 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(elapsed, params.EffectiveDuration)
+}
+
+// ReleasableAmount computes the vested but unreleased amount at the given time
+// under the given model. Revoked schedules have nothing left to release.
+func (s *VestingSchedule) ReleasableAmount(model VestingModel, now time.Time) *big.Int {
+	if s.Revoked {
+		return big.NewInt(0)
+	}
+
+	released, ok := new(big.Int).SetString(s.Released, 10)
+	if !ok {
+		released = big.NewInt(0)
+	}
+
+	releasable := new(big.Int).Sub(s.VestedAmount(model, now), released)
+	if releasable.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return releasable
 }
 
 // VestingEvent represents blockchain events
@@ -29,19 +314,104 @@ type VestingEvent struct {
 	Beneficiary     string    `gorm:"index;not null;size:42" json:"beneficiary"`
 	Amount          string    `json:"amount"`
 	BlockNumber     uint64    `gorm:"index" json:"block_number"`
-	TransactionHash string    `gorm:"uniqueIndex;not null;size:66" json:"transaction_hash"`
+	TransactionHash string    `gorm:"uniqueIndex:idx_vesting_events_tx_log;not null;size:66" json:"transaction_hash"`
+	LogIndex        uint      `gorm:"uniqueIndex:idx_vesting_events_tx_log;not null;default:0" json:"log_index"` // Position of the log in its block; one transaction can emit several events
+	GasUsed         *uint64   `json:"gas_used,omitempty"`                                                        // Only recorded when INDEX_GAS_USED is enabled
+	DecodeError     string    `gorm:"not null;default:''" json:"decode_error,omitempty"`                         // Set when the log data only partially decoded; amounts may be missing
 	Timestamp       time.Time `json:"timestamp"`
 	CreatedAt       time.Time `json:"created_at"`
 }
 
+// BeforeSave stores the event timestamp in UTC
+func (e *VestingEvent) BeforeSave(*gorm.DB) error {
+	e.Timestamp = e.Timestamp.UTC()
+	return nil
+}
+
+// MerkleAllocation represents a beneficiary's leaf in a merkle distribution
+type MerkleAllocation struct {
+	ID          uint      `gorm:"primaryKey" json:"-"`
+	Beneficiary string    `gorm:"uniqueIndex;not null;size:42" json:"beneficiary"`
+	Amount      string    `gorm:"not null" json:"amount"`
+	Leaf        string    `gorm:"not null;size:66" json:"leaf"`
+	Proof       []string  `gorm:"serializer:json" json:"proof"`
+	Root        string    `gorm:"size:66" json:"root"`
+	CreatedAt   time.Time `json:"-"`
+	UpdatedAt   time.Time `json:"-"`
+}
+
+// SyncCheckpoint records sync metadata for a vesting contract that should
+// survive restarts, such as the block it was deployed in
+type SyncCheckpoint struct {
+	ContractAddress string    `gorm:"primaryKey;size:42" json:"contract_address"`
+	DeploymentBlock uint64    `gorm:"not null" json:"deployment_block"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// SyncStateID is the primary key of the single SyncState row
+const SyncStateID = 1
+
+// SyncState is the indexer's single-row progress record. LastProcessedBlock
+// advances after every scanned batch, including batches without events, so a
+// restart resumes after it rather than rescanning empty ranges.
+type SyncState struct {
+	ID                 uint      `gorm:"primaryKey" json:"-"`
+	LastProcessedBlock uint64    `gorm:"not null" json:"last_processed_block"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// Sources of scanned block ranges
+const (
+	SyncSourceHistorical = "historical" // A batch of the startup historical sync
+	SyncSourceLive       = "live"       // Blocks covered while watching live events
+)
+
+// SyncRange records an inclusive block range the indexer has scanned for
+// events. Together the ranges form the sync coverage history.
+type SyncRange struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	FromBlock uint64    `gorm:"not null;index" json:"from_block"`
+	ToBlock   uint64    `gorm:"not null" json:"to_block"`
+	Source    string    `gorm:"not null;size:16" json:"source"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// DeadLetterEvent records an event that still failed to process after every
+// retry. The listener stops retrying it, so it is kept here for inspection and
+// manual replay.
+type DeadLetterEvent struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	EventType       string    `gorm:"not null" json:"event_type"`
+	Beneficiary     string    `gorm:"index;not null;size:42" json:"beneficiary"`
+	BlockNumber     uint64    `gorm:"index" json:"block_number"`
+	TransactionHash string    `gorm:"uniqueIndex:idx_dead_letter_events_tx_log;not null;size:66" json:"transaction_hash"`
+	LogIndex        uint      `gorm:"uniqueIndex:idx_dead_letter_events_tx_log;not null;default:0" json:"log_index"`
+	Payload         string    `gorm:"type:text" json:"payload"` // The decoded event as JSON
+	Error           string    `json:"error"`                    // Error from the last attempt
+	Attempts        int       `json:"attempts"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// OutboxEvent marks a recorded event that has not been published downstream
+// yet. It is written in the same transaction as the event and removed once a
+// publish succeeds, so unpublished events survive a restart.
+type OutboxEvent struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	EventID     uint      `gorm:"uniqueIndex;not null" json:"event_id"`
+	BlockNumber uint64    `gorm:"index;not null" json:"block_number"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // BeneficiaryStats represents aggregated statistics for a beneficiary
 type BeneficiaryStats struct {
-	Beneficiary     string    `json:"beneficiary"`
-	TotalVested     string    `json:"total_vested"`
-	TotalReleased   string    `json:"total_released"`
-	TotalPending    string    `json:"total_pending"`
-	ScheduleCount   int       `json:"schedule_count"`
-	LastReleaseDate time.Time `json:"last_release_date,omitempty"`
+	Beneficiary     string     `json:"beneficiary"`
+	TotalVested     string     `json:"total_vested"`
+	TotalReleased   string     `json:"total_released"`
+	TotalPending    string     `json:"total_pending"`
+	ScheduleCount   int        `json:"schedule_count"`
+	LastReleaseDate *time.Time `json:"last_release_date,omitempty"` // Nil until the first TokensReleased event
+	VestedSource    string     `json:"vested_source"`               // onchain or local
 }
 
 // TableName overrides the table name
@@ -52,3 +422,23 @@ func (VestingSchedule) TableName() string {
 func (VestingEvent) TableName() string {
 	return "vesting_events"
 }
+
+func (MerkleAllocation) TableName() string {
+	return "merkle_allocations"
+}
+
+func (SyncRange) TableName() string {
+	return "sync_ranges"
+}
+
+func (SyncState) TableName() string {
+	return "sync_state"
+}
+
+func (DeadLetterEvent) TableName() string {
+	return "dead_letter_events"
+}
+
+func (OutboxEvent) TableName() string {
+	return "event_outbox"
+}
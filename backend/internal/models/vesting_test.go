@@ -0,0 +1,273 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeStatus(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cliff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	duration := int64(4 * 365 * 24 * 60 * 60)
+	end := start.Add(time.Duration(duration) * time.Second)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		revoked  bool
+		expected ScheduleStatus
+	}{
+		{"Before start", start.Add(-time.Second), false, StatusPending},
+		{"At start", start, false, StatusCliff},
+		{"Before cliff", cliff.Add(-time.Second), false, StatusCliff},
+		{"At cliff", cliff, false, StatusVesting},
+		{"Mid vesting", start.Add(2 * 365 * 24 * time.Hour), false, StatusVesting},
+		{"At end", end, false, StatusVested},
+		{"After end", end.Add(24 * time.Hour), false, StatusVested},
+		{"Revoked before start", start.Add(-time.Second), true, StatusRevoked},
+		{"Revoked mid vesting", cliff.Add(time.Hour), true, StatusRevoked},
+		{"Revoked after end", end.Add(time.Hour), true, StatusRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &VestingSchedule{
+				Start:    start,
+				Cliff:    cliff,
+				Duration: duration,
+				Revoked:  tt.revoked,
+			}
+			assert.Equal(t, tt.expected, schedule.ComputeStatus(tt.now))
+		})
+	}
+}
+
+func TestSetTimeRemaining(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	duration := int64(1000)
+	end := start.Add(time.Duration(duration) * time.Second)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		revoked   bool
+		remaining int64
+	}{
+		{"Before start", start.Add(-100 * time.Second), false, 1100},
+		{"Mid vesting", start.Add(400 * time.Second), false, 600},
+		{"Rounds partial seconds up", start.Add(400*time.Second + time.Millisecond), false, 600},
+		{"At end", end, false, 0},
+		{"Fully vested", end.Add(time.Hour), false, 0},
+		{"Revoked mid vesting", start.Add(400 * time.Second), true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &VestingSchedule{
+				Start:    start,
+				Cliff:    start.Add(100 * time.Second),
+				Duration: duration,
+				Revoked:  tt.revoked,
+			}
+			schedule.SetTimeRemaining(tt.now)
+
+			if assert.NotNil(t, schedule.TimeRemainingSeconds) {
+				assert.Equal(t, tt.remaining, *schedule.TimeRemainingSeconds)
+			}
+			if tt.revoked {
+				assert.Nil(t, schedule.FullyVestedAt)
+			} else if assert.NotNil(t, schedule.FullyVestedAt) {
+				assert.Equal(t, end, *schedule.FullyVestedAt)
+			}
+		})
+	}
+}
+
+func TestVestingMath_FutureStart(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := now.Add(10 * 24 * time.Hour)
+
+	schedules := map[string]VestingSchedule{
+		"Cliff after start":   {Start: start, Cliff: start.Add(time.Hour), Duration: 3600 * 24, Amount: "1000"},
+		"Cliff at start":      {Start: start, Cliff: start, Duration: 3600 * 24, Amount: "1000"},
+		"Cliff unset":         {Start: start, Duration: 3600 * 24, Amount: "1000"},
+		"Cliff before start":  {Start: start, Cliff: now.Add(-time.Hour), Duration: 3600 * 24, Amount: "1000"},
+		"Zero duration grant": {Start: start, Duration: 0, Amount: "1000"},
+	}
+
+	for name, schedule := range schedules {
+		t.Run(name, func(t *testing.T) {
+			for _, model := range []VestingModel{VestingFromStart, VestingFromCliff} {
+				assert.Equal(t, "0", schedule.VestedAmount(model, now).String(), model)
+				assert.Equal(t, 0, schedule.VestedFraction(model, now).Sign(), model)
+				assert.Equal(t, "0", schedule.ReleasableAmount(model, now).String(), model)
+				assert.GreaterOrEqual(t, schedule.EffectiveVesting(model).EffectiveDuration, int64(0), model)
+			}
+			assert.Equal(t, StatusPending, schedule.ComputeStatus(now))
+
+			end := start.Add(time.Duration(schedule.Duration) * time.Second)
+			assert.Equal(t, end.Sub(now), schedule.TimeRemaining(now))
+
+			// Everything has vested once the schedule ends
+			assert.Equal(t, "1000", schedule.VestedAmount(VestingFromStart, end).String())
+		})
+	}
+}
+
+func TestVestedAmount(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := &VestingSchedule{
+		Start:    start,
+		Cliff:    start.Add(100 * time.Second),
+		Duration: 1000,
+		Amount:   "1000000",
+		Released: "0",
+	}
+
+	// The same timestamps under both models: from_cliff vests the whole amount
+	// over the 900s between cliff and end instead of unlocking a lump at the cliff
+	tests := []struct {
+		name      string
+		now       time.Time
+		fromStart string
+		fromCliff string
+	}{
+		{"Before start", start.Add(-time.Second), "0", "0"},
+		{"Before cliff", start.Add(99 * time.Second), "0", "0"},
+		{"At cliff", start.Add(100 * time.Second), "100000", "0"},
+		{"Midway", start.Add(500 * time.Second), "500000", "444444"},
+		{"Rounds down", start.Add(333 * time.Second), "333000", "258888"},
+		{"Just before end", start.Add(999 * time.Second), "999000", "998888"},
+		{"At end", start.Add(1000 * time.Second), "1000000", "1000000"},
+		{"After end", start.Add(5000 * time.Second), "1000000", "1000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fromStart, schedule.VestedAmount(VestingFromStart, tt.now).String())
+			assert.Equal(t, tt.fromCliff, schedule.VestedAmount(VestingFromCliff, tt.now).String())
+		})
+	}
+
+	// The zero model is from_start
+	assert.Equal(t, "500000", schedule.VestedAmount("", start.Add(500*time.Second)).String())
+
+	// Without a cliff both models agree
+	noCliff := *schedule
+	noCliff.Cliff = start
+	for _, offset := range []time.Duration{0, 250 * time.Second, 999 * time.Second} {
+		assert.Equal(t,
+			noCliff.VestedAmount(VestingFromStart, start.Add(offset)).String(),
+			noCliff.VestedAmount(VestingFromCliff, start.Add(offset)).String())
+	}
+}
+
+func TestEffectiveVesting(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := &VestingSchedule{Start: start, Cliff: start.Add(100 * time.Second), Duration: 1000}
+	end := start.Add(1000 * time.Second)
+
+	assert.Equal(t, VestingParameters{
+		Model:             VestingFromStart,
+		EffectiveStart:    start,
+		End:               end,
+		EffectiveDuration: 1000,
+	}, schedule.EffectiveVesting(VestingFromStart))
+
+	assert.Equal(t, VestingParameters{
+		Model:             VestingFromCliff,
+		EffectiveStart:    start.Add(100 * time.Second),
+		End:               end,
+		EffectiveDuration: 900,
+	}, schedule.EffectiveVesting(VestingFromCliff))
+
+	// A cliff past the end collapses the span
+	schedule.Cliff = start.Add(2000 * time.Second)
+	params := schedule.EffectiveVesting(VestingFromCliff)
+	assert.Equal(t, end, params.EffectiveStart)
+	assert.Equal(t, int64(0), params.EffectiveDuration)
+}
+
+func TestParseVestingModel(t *testing.T) {
+	model, err := ParseVestingModel(" From_Cliff ")
+	assert.NoError(t, err)
+	assert.Equal(t, VestingFromCliff, model)
+
+	model, err = ParseVestingModel("from_start")
+	assert.NoError(t, err)
+	assert.Equal(t, VestingFromStart, model)
+
+	_, err = ParseVestingModel("from_end")
+	assert.Error(t, err)
+}
+
+func TestReleasableAmount(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(500 * time.Second)
+
+	schedule := &VestingSchedule{
+		Start:    start,
+		Cliff:    start,
+		Duration: 1000,
+		Amount:   "1000",
+		Released: "200",
+	}
+	assert.Equal(t, "300", schedule.ReleasableAmount(VestingFromStart, now).String())
+
+	// Released ahead of local math never goes negative
+	schedule.Released = "800"
+	assert.Equal(t, "0", schedule.ReleasableAmount(VestingFromStart, now).String())
+
+	schedule.Released = "0"
+	schedule.Revoked = true
+	assert.Equal(t, "0", schedule.ReleasableAmount(VestingFromStart, now).String())
+}
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		expected string
+	}{
+		{"1000000000000000000000", 18, "1000"},
+		{"1500000", 6, "1.5"},
+		{"1", 6, "0.000001"},
+		{"123", 0, "123"},
+		{"0", 18, "0"},
+		{"-2500", 3, "-2.5"},
+		{"not-a-number", 18, "not-a-number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatUnits(tt.amount, tt.decimals))
+		})
+	}
+}
+
+func TestFormatUnitsRounded(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		places   int
+		expected string
+	}{
+		{"1234567890123456789012", 18, 4, "1234.5679"},
+		{"1234567890123456789012", 18, 0, "1235"},
+		{"1500000", 6, 4, "1.5000"},
+		{"1234550", 6, 4, "1.2346"}, // Halves round away from zero
+		{"-1234550", 6, 4, "-1.2346"},
+		{"-1", 6, 2, "0.00"},
+		{"123456789012345678901234567890", 18, 2, "123456789012.35"},
+		{"1234567890123456789012", 18, -1, "1234.567890123456789012"},
+		{"not-a-number", 18, 4, "not-a-number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatUnitsRounded(tt.amount, tt.decimals, tt.places))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC20MetaData contains the subset of the ERC20 ABI the backend reads
+var ERC20MetaData = &bind.MetaData{
+	ABI: `[
+		{
+			"inputs": [],
+			"name": "decimals",
+			"outputs": [{"internalType": "uint8", "name": "", "type": "uint8"}],
+			"stateMutability": "view",
+			"type": "function"
+		}
+	]`,
+}
+
+// ERC20 reads metadata from an ERC20 token contract
+type ERC20 struct {
+	address common.Address
+	caller  bind.ContractCaller
+	abi     abi.ABI
+}
+
+// NewERC20 creates a reader for the token at address
+func NewERC20(address common.Address, caller bind.ContractCaller) (*ERC20, error) {
+	parsed, err := abi.JSON(strings.NewReader(ERC20MetaData.ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20{address: address, caller: caller, abi: parsed}, nil
+}
+
+// Decimals reads the token's decimals
+func (t *ERC20) Decimals(ctx context.Context) (uint8, error) {
+	input, err := t.abi.Pack("decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := t.caller.CallContract(ctx, ethereum.CallMsg{To: &t.address, Data: input}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := t.abi.Unpack("decimals", output)
+	if err != nil {
+		return 0, err
+	}
+	decimals, ok := values[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected decimals type %T", values[0])
+	}
+	return decimals, nil
+}
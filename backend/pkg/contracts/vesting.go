@@ -1,8 +1,13 @@
 package contracts
 
 import (
+	"context"
+	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -97,30 +102,96 @@ type TokenVestingVestingRevoked struct {
 	Refunded    *big.Int
 }
 
-// TokenVesting represents the contract interface
+// TokenVesting reads vesting schedules from the contract
 type TokenVesting struct {
 	address common.Address
 	caller  bind.ContractCaller
+	abi     abi.ABI
+	// methods maps internal method names to the names called on the deployed contract
+	methods map[string]string
 }
 
-// NewTokenVesting creates a new instance of the contract
-func NewTokenVesting(address common.Address, backend bind.ContractBackend) (*TokenVesting, error) {
-	return &TokenVesting{
-		address: address,
-		caller:  backend,
-	}, nil
+// NewTokenVesting creates a reader for the contract at address. methodNames
+// maps a deployed contract's method name to the internal method it stands in
+// for, for contracts exposing the same call under a different name; each
+// internal method must exist in the ABI.
+func NewTokenVesting(address common.Address, caller bind.ContractCaller, methodNames map[string]string) (*TokenVesting, error) {
+	parsed, err := abi.JSON(strings.NewReader(TokenVestingMetaData.ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make(map[string]string, len(parsed.Methods))
+	for name := range parsed.Methods {
+		methods[name] = name
+	}
+
+	for deployed, internal := range methodNames {
+		base, ok := parsed.Methods[internal]
+		if !ok {
+			return nil, fmt.Errorf("unknown internal method %q for override %q", internal, deployed)
+		}
+		if existing, ok := parsed.Methods[deployed]; ok && existing.Name != internal {
+			return nil, fmt.Errorf("override %q for %q collides with ABI method %q", deployed, internal, existing.Name)
+		}
+		if methods[internal] != internal {
+			return nil, fmt.Errorf("method %q is overridden more than once", internal)
+		}
+		// The selector depends on the name, so derive it with the same inputs
+		parsed.Methods[deployed] = abi.NewMethod(deployed, deployed, base.Type, base.StateMutability, base.Constant, base.Payable, base.Inputs, base.Outputs)
+		methods[internal] = deployed
+	}
+
+	return &TokenVesting{address: address, caller: caller, abi: parsed, methods: methods}, nil
+}
+
+// MethodName returns the name an internal method is called by on the deployed contract
+func (tv *TokenVesting) MethodName(internal string) string {
+	return tv.methods[internal]
 }
 
 // VestingSchedules retrieves a vesting schedule
 func (tv *TokenVesting) VestingSchedules(opts *bind.CallOpts, beneficiary common.Address) (VestingSchedule, error) {
 	var out VestingSchedule
-	// This is a simplified version - in production, use abigen-generated bindings
-	// For now, return empty struct - will be implemented with full bindings
-	return out, nil
+	output, err := tv.call(opts, "vestingSchedules", beneficiary)
+	if err != nil {
+		return out, err
+	}
+	err = tv.abi.UnpackIntoInterface(&out, tv.methods["vestingSchedules"], output)
+	return out, err
 }
 
 // VestedAmount gets the vested amount for a beneficiary
 func (tv *TokenVesting) VestedAmount(opts *bind.CallOpts, beneficiary common.Address) (*big.Int, error) {
-	// This is a simplified version - in production, use abigen-generated bindings
-	return big.NewInt(0), nil
+	output, err := tv.call(opts, "vestedAmount", beneficiary)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := tv.abi.Unpack(tv.methods["vestedAmount"], output)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected vested amount type %T", values[0])
+	}
+	return amount, nil
+}
+
+// call invokes an internal method under its deployed name and returns the raw output
+func (tv *TokenVesting) call(opts *bind.CallOpts, internal string, args ...interface{}) ([]byte, error) {
+	if opts == nil {
+		opts = new(bind.CallOpts)
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	input, err := tv.abi.Pack(tv.methods[internal], args...)
+	if err != nil {
+		return nil, err
+	}
+	return tv.caller.CallContract(ctx, ethereum.CallMsg{From: opts.From, To: &tv.address, Data: input}, opts.BlockNumber)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,7 +37,7 @@ func setupTestServer(t *testing.T) *TestServer {
 	require.NoError(t, err)
 
 	// Auto-migrate
-	err = gormDB.AutoMigrate(&models.VestingSchedule{}, &models.VestingEvent{})
+	err = gormDB.AutoMigrate(&models.VestingSchedule{}, &models.VestingEvent{}, &models.MerkleAllocation{}, &models.SyncCheckpoint{}, &models.SyncRange{}, &models.SyncState{}, &models.DeadLetterEvent{}, &models.OutboxEvent{})
 	require.NoError(t, err)
 
 	db := &database.Database{DB: gormDB}
@@ -45,14 +46,22 @@ func setupTestServer(t *testing.T) *TestServer {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	handler := api.NewHandler(db, nil) // No blockchain client for integration tests
+	handler := api.NewHandler(db, nil, nil) // No blockchain client for integration tests
 
 	// Register routes
 	router.GET("/health", handler.HealthCheck)
 	router.GET("/api/v1/schedules", handler.GetAllSchedules)
+	router.GET("/api/v1/schedules/by-status", handler.GetSchedulesByStatus)
 	router.GET("/api/v1/schedules/:address", handler.GetSchedule)
+	router.GET("/api/v1/schedules/:address/released-at", handler.GetReleasedAt)
+	router.GET("/api/v1/events", handler.GetEventsForBeneficiaries)
 	router.GET("/api/v1/events/:address", handler.GetEvents)
+	router.GET("/api/v1/revocations", handler.GetRevocations)
+	router.GET("/api/v1/sync/coverage", handler.GetSyncCoverage)
 	router.GET("/api/v1/stats", handler.GetStats)
+	router.GET("/api/v1/stats/:address", handler.GetBeneficiaryStats)
+	router.GET("/api/v1/allocations/:address/proof", handler.GetAllocationProof)
+	router.PUT("/api/v1/admin/schedules/:address/labels", handler.SetScheduleLabels)
 	// Note: /api/v1/vested/:address requires blockchain client, skip in integration tests
 
 	// Create test server
@@ -221,6 +230,182 @@ func TestGetAllSchedules(t *testing.T) {
 	}
 }
 
+// TestGetAllSchedules_FilterByToken tests filtering schedules by vested token
+func TestGetAllSchedules_FilterByToken(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	token := "0x751f3c0aF0Ed18d9F70108CD0c4d878Aa0De59A8"
+	err := ts.DB.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+		Token:       token,
+		Start:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Cliff:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration:    4 * 365 * 24 * 60 * 60,
+		Amount:      "1000",
+		Released:    "0",
+	})
+	require.NoError(t, err)
+	err = ts.DB.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea",
+		Token:       "0x1111111111111111111111111111111111111111",
+		Start:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Cliff:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration:    4 * 365 * 24 * 60 * 60,
+		Amount:      "2000",
+		Released:    "0",
+	})
+	require.NoError(t, err)
+
+	// Lowercase filter is normalized to match the stored checksummed address
+	resp, err := http.Get(ts.Server.URL + "/api/v1/schedules?token=0x751f3c0af0ed18d9f70108cd0c4d878aa0de59a8")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Schedules []models.VestingSchedule `json:"schedules"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+	require.Len(t, result.Schedules, 1)
+	assert.Equal(t, token, result.Schedules[0].Token)
+
+	resp, err = http.Get(ts.Server.URL + "/api/v1/schedules?token=invalid")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestGetAllSchedules_IncludeLatestEvent tests attaching each schedule's newest event
+func TestGetAllSchedules_IncludeLatestEvent(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	seedTestData(t, ts.DB)
+
+	// A schedule with no events must still be listed
+	quiet := "0x1111111111111111111111111111111111111111"
+	err := ts.DB.CreateOrUpdateSchedule(&models.VestingSchedule{
+		Beneficiary: quiet,
+		Start:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Cliff:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration:    4 * 365 * 24 * 60 * 60,
+		Amount:      "1000",
+		Released:    "0",
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Get(ts.Server.URL + "/api/v1/schedules?include_latest_event=true")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Schedules []models.VestingSchedule `json:"schedules"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+	require.Len(t, result.Schedules, 3)
+
+	byBeneficiary := make(map[string]models.VestingSchedule)
+	for _, schedule := range result.Schedules {
+		byBeneficiary[schedule.Beneficiary] = schedule
+	}
+
+	first := byBeneficiary["0xF25DA65784D566fFCC60A1f113650afB688A14ED"]
+	require.NotNil(t, first.LatestEvent)
+	assert.Equal(t, "TokensReleased", first.LatestEvent.EventType)
+	assert.Equal(t, uint64(12345679), first.LatestEvent.BlockNumber)
+
+	second := byBeneficiary["0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"]
+	require.NotNil(t, second.LatestEvent)
+	assert.Equal(t, "VestingScheduleCreated", second.LatestEvent.EventType)
+
+	assert.Nil(t, byBeneficiary[quiet].LatestEvent)
+}
+
+// TestScheduleLabels tests setting labels and filtering the listing by label
+func TestScheduleLabels(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	seedTestData(t, ts.DB)
+
+	setLabels := func(address, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPut, ts.Server.URL+"/api/v1/admin/schedules/"+address+"/labels", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := setLabels("0xF25DA65784D566fFCC60A1f113650afB688A14ED", `{"labels": ["team:engineering", "round:seed", "team:engineering"]}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = setLabels("0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea", `{"labels": ["team:engineering-ops"]}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = setLabels("0x1111111111111111111111111111111111111111", `{"labels": ["team:engineering"]}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp = setLabels("0xF25DA65784D566fFCC60A1f113650afB688A14ED", `{"labels": [" "]}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// An exact label match must not pick up labels that merely share a prefix
+	resp, err := http.Get(ts.Server.URL + "/api/v1/schedules?label=team:engineering")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result struct {
+		Schedules []models.VestingSchedule `json:"schedules"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+	require.Len(t, result.Schedules, 1)
+	assert.Equal(t, "0xF25DA65784D566fFCC60A1f113650afB688A14ED", result.Schedules[0].Beneficiary)
+	assert.Equal(t, []string{"team:engineering", "round:seed"}, result.Schedules[0].Labels)
+
+	// LIKE wildcards in the filter are matched literally
+	resp, err = http.Get(ts.Server.URL + "/api/v1/schedules?label=team%25")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	result.Schedules = nil
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+	assert.Empty(t, result.Schedules)
+}
+
+// TestGetSchedulesByStatus tests the by-status route alongside the address route
+func TestGetSchedulesByStatus(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	seedTestData(t, ts.DB)
+
+	resp, err := http.Get(ts.Server.URL + "/api/v1/schedules/by-status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Groups map[string]struct {
+			Count int `json:"count"`
+		} `json:"groups"`
+		Total int `json:"total"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	// All seeded schedules, including the revoked one
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 1, result.Groups["revoked"].Count)
+}
+
 // TestGetScheduleByAddress tests retrieving a specific vesting schedule
 func TestGetScheduleByAddress(t *testing.T) {
 	ts := setupTestServer(t)
@@ -289,6 +474,7 @@ func TestGetScheduleByAddress(t *testing.T) {
 				assert.NotEmpty(t, schedule.Beneficiary)
 				assert.NotEmpty(t, schedule.Amount)
 				assert.NotZero(t, schedule.Duration)
+				assert.Equal(t, models.StatusVesting, schedule.Status)
 			}
 		})
 	}
@@ -367,6 +553,66 @@ func TestGetEvents(t *testing.T) {
 	}
 }
 
+// TestGetEventsForBeneficiaries tests retrieving events for multiple beneficiaries
+func TestGetEventsForBeneficiaries(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	seedTestData(t, ts.DB)
+
+	t.Run("Merges and orders events", func(t *testing.T) {
+		url := fmt.Sprintf("%s/api/v1/events?beneficiaries=%s,%s", ts.Server.URL,
+			"0xf25da65784d566ffcc60a1f113650afb688a14ed",
+			"0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea")
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		require.NoError(t, err)
+
+		events := result["events"].([]interface{})
+		require.Len(t, events, 3)
+
+		var blocks []float64
+		for _, e := range events {
+			blocks = append(blocks, e.(map[string]interface{})["block_number"].(float64))
+		}
+		assert.Equal(t, []float64{12345680, 12345679, 12345678}, blocks)
+	})
+
+	t.Run("Invalid address", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/events?beneficiaries=0xF25DA65784D566fFCC60A1f113650afB688A14ED,invalid")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Missing parameter", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Too many beneficiaries", func(t *testing.T) {
+		addresses := make([]string, 51)
+		for i := range addresses {
+			addresses[i] = fmt.Sprintf("0x%040x", i+1)
+		}
+		resp, err := http.Get(ts.Server.URL + "/api/v1/events?beneficiaries=" + strings.Join(addresses, ","))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
 // TestGetStats tests the statistics endpoint
 func TestGetStats(t *testing.T) {
 	ts := setupTestServer(t)
@@ -384,9 +630,126 @@ func TestGetStats(t *testing.T) {
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	require.NoError(t, err)
 
-	// Should have 2 active schedules (1 is revoked)
-	assert.Equal(t, float64(2), result["total_schedules"])
+	// 3 schedules, 1 of them revoked; amounts cover the 2 active ones
+	assert.Equal(t, float64(3), result["total_schedules"])
 	assert.Equal(t, float64(2), result["active_schedules"])
+	assert.Equal(t, float64(1), result["revoked_schedules"])
+	assert.Equal(t, "1500000000000000000000", result["total_vesting"])
+	assert.Equal(t, "250000000000000000000", result["total_released"])
+	assert.Equal(t, "1250000000000000000000", result["total_locked"])
+}
+
+// TestGetBeneficiaryStats tests the per-beneficiary statistics endpoint
+func TestGetBeneficiaryStats(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	beneficiary := "0x04d45a31e94D2Ba0007Fa4b58DEf1254d83302ea"
+
+	// Fully vested, so the local vested amount is the whole allocation
+	schedule := &models.VestingSchedule{
+		Beneficiary: beneficiary,
+		Start:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Cliff:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration:    365 * 24 * 60 * 60,
+		Amount:      "1000000000000000000000",
+		Released:    "300000000000000000000",
+		Revocable:   true,
+	}
+	require.NoError(t, ts.DB.CreateOrUpdateSchedule(schedule))
+
+	newest := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []models.VestingEvent{
+		{EventType: "VestingScheduleCreated", Beneficiary: beneficiary, Amount: "1000000000000000000000", BlockNumber: 100, TransactionHash: "0xa1", Timestamp: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "100000000000000000000", BlockNumber: 200, TransactionHash: "0xa2", Timestamp: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{EventType: "TokensReleased", Beneficiary: beneficiary, Amount: "200000000000000000000", BlockNumber: 300, TransactionHash: "0xa3", Timestamp: newest},
+	}
+	for i := range events {
+		require.NoError(t, ts.DB.CreateEvent(&events[i]))
+	}
+
+	resp, err := http.Get(ts.Server.URL + "/api/v1/stats/" + strings.ToLower(beneficiary))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats models.BeneficiaryStats
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+
+	assert.Equal(t, beneficiary, stats.Beneficiary)
+	assert.Equal(t, 1, stats.ScheduleCount)
+	assert.Equal(t, "1000000000000000000000", stats.TotalVested)
+	assert.Equal(t, "300000000000000000000", stats.TotalReleased)
+	assert.Equal(t, "700000000000000000000", stats.TotalPending)
+	assert.Equal(t, "local", stats.VestedSource)
+	require.NotNil(t, stats.LastReleaseDate)
+	assert.True(t, newest.Equal(*stats.LastReleaseDate))
+
+	t.Run("Invalid address", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/stats/not-an-address")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("No schedule", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/stats/0xF25DA65784D566fFCC60A1f113650afB688A14ED")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+// TestGetAllocationProof tests retrieving a beneficiary's merkle proof
+func TestGetAllocationProof(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	err := ts.DB.UpsertMerkleAllocations([]models.MerkleAllocation{
+		{
+			Beneficiary: "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+			Amount:      "1000000000000000000000",
+			Leaf:        "0xaaaa",
+			Proof:       []string{"0xbbbb", "0xcccc"},
+			Root:        "0x1111",
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("Known address", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/allocations/0xf25da65784d566ffcc60a1f113650afb688a14ed/proof")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var allocation models.MerkleAllocation
+		err = json.NewDecoder(resp.Body).Decode(&allocation)
+		require.NoError(t, err)
+		assert.Equal(t, "0xF25DA65784D566fFCC60A1f113650afB688A14ED", allocation.Beneficiary)
+		assert.Equal(t, "0xaaaa", allocation.Leaf)
+		assert.Equal(t, []string{"0xbbbb", "0xcccc"}, allocation.Proof)
+		assert.Equal(t, "0x1111", allocation.Root)
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/allocations/0x0000000000000000000000000000000000000999/proof")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Invalid address", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/allocations/invalid/proof")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
 }
 
 // TestAddressNormalization tests that addresses are normalized (checksummed)
@@ -445,6 +808,507 @@ func TestEventOrdering(t *testing.T) {
 	block2 := event2["block_number"].(float64)
 
 	assert.True(t, block1 >= block2, "Events should be ordered by block number DESC")
+
+	// Ascending order puts the oldest event first
+	resp, err = http.Get(url + "?order=asc")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	events = result["events"].([]interface{})
+	require.Len(t, events, 2)
+	first := events[0].(map[string]interface{})
+	assert.Equal(t, float64(12345678), first["block_number"])
+	assert.Equal(t, "VestingScheduleCreated", first["event_type"])
+
+	// Unknown order is rejected
+	resp, err = http.Get(url + "?order=sideways")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestGetRevocations tests the revocation audit over a block window
+func TestGetRevocations(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	seedTestData(t, ts.DB)
+
+	// Revocations spread across blocks, plus a release inside the window
+	revocations := []struct {
+		beneficiary string
+		block       uint64
+		refunded    string
+	}{
+		{"0x0000000000000000000000000000000000000001", 100, "10"},
+		{"0x0000000000000000000000000000000000000002", 200, "20"},
+		{"0x0000000000000000000000000000000000000003", 300, "30"},
+		{"0x0000000000000000000000000000000000000004", 300, "40"},
+		{"0x0000000000000000000000000000000000000005", 400, "50"},
+	}
+	for i, r := range revocations {
+		require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+			EventType:       "VestingRevoked",
+			Beneficiary:     r.beneficiary,
+			Amount:          r.refunded,
+			BlockNumber:     r.block,
+			LogIndex:        uint(i),
+			TransactionHash: fmt.Sprintf("0x%064x", i),
+			Timestamp:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}))
+	}
+	require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+		EventType:       "TokensReleased",
+		Beneficiary:     "0x0000000000000000000000000000000000000002",
+		Amount:          "5",
+		BlockNumber:     250,
+		TransactionHash: "0xrelease",
+		Timestamp:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	type response struct {
+		Revocations   []api.Revocation `json:"revocations"`
+		Count         int              `json:"count"`
+		TotalRefunded string           `json:"total_refunded"`
+	}
+	get := func(t *testing.T, query string) (int, response) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/revocations" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result response
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+	blocks := func(r response) []uint64 {
+		out := make([]uint64, len(r.Revocations))
+		for i, revocation := range r.Revocations {
+			out[i] = revocation.BlockNumber
+		}
+		return out
+	}
+
+	t.Run("Window", func(t *testing.T) {
+		status, result := get(t, "?from_block=200&to_block=300")
+		require.Equal(t, http.StatusOK, status)
+
+		// Only in-range revocations, ordered by block, bounds inclusive
+		assert.Equal(t, []uint64{200, 300, 300}, blocks(result))
+		assert.Equal(t, 3, result.Count)
+		assert.Equal(t, "90", result.TotalRefunded)
+		assert.Equal(t, "0x0000000000000000000000000000000000000002", result.Revocations[0].Beneficiary)
+		assert.Equal(t, "20", result.Revocations[0].Refunded)
+		assert.Equal(t, "0x0000000000000000000000000000000000000004", result.Revocations[2].Beneficiary)
+	})
+
+	t.Run("Open bounds", func(t *testing.T) {
+		_, result := get(t, "?from_block=300")
+		assert.Equal(t, []uint64{300, 300, 400}, blocks(result))
+
+		_, result = get(t, "?to_block=100")
+		assert.Equal(t, []uint64{100}, blocks(result))
+
+		_, result = get(t, "")
+		assert.Equal(t, []uint64{100, 200, 300, 300, 400}, blocks(result))
+	})
+
+	t.Run("Empty window", func(t *testing.T) {
+		status, result := get(t, "?from_block=500&to_block=600")
+		require.Equal(t, http.StatusOK, status)
+		assert.Empty(t, result.Revocations)
+		assert.Equal(t, "0", result.TotalRefunded)
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		status, _ := get(t, "?from_block=300&to_block=200")
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = get(t, "?from_block=-1")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+// TestCursorPagination walks schedules and events page by page with next_cursor
+func TestSyncCoverage(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	// Two overlapping historical batches, then live coverage after a gap
+	scans := []struct {
+		from, to uint64
+		source   string
+	}{
+		{100, 200, models.SyncSourceHistorical},
+		{200, 300, models.SyncSourceHistorical},
+		{401, 450, models.SyncSourceLive},
+	}
+	for _, scan := range scans {
+		_, err := ts.DB.RecordScannedRange(scan.from, scan.to, scan.source)
+		require.NoError(t, err)
+	}
+	for i, block := range []uint64{150, 150, 420, 600} {
+		require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     "0xF25DA65784D566fFCC60A1f113650afB688A14ED",
+			Amount:          "1",
+			BlockNumber:     block,
+			LogIndex:        uint(i),
+			TransactionHash: fmt.Sprintf("0x%064x", i),
+			Timestamp:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}))
+	}
+
+	type response struct {
+		FromBlock     *uint64          `json:"from_block"`
+		ToBlock       *uint64          `json:"to_block"`
+		Scanned       []api.BlockRange `json:"scanned"`
+		Gaps          []api.BlockRange `json:"gaps"`
+		ScannedBlocks uint64           `json:"scanned_blocks"`
+		WindowBlocks  uint64           `json:"window_blocks"`
+		EventBlocks   []uint64         `json:"event_blocks"`
+	}
+	get := func(t *testing.T, query string) (int, response) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/sync/coverage" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result response
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+
+	t.Run("Window around the scanned history", func(t *testing.T) {
+		status, result := get(t, "?from_block=50&to_block=500")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, []api.BlockRange{{FromBlock: 100, ToBlock: 300}, {FromBlock: 401, ToBlock: 450}}, result.Scanned)
+		assert.Equal(t, []api.BlockRange{{FromBlock: 50, ToBlock: 99}, {FromBlock: 301, ToBlock: 400}, {FromBlock: 451, ToBlock: 500}}, result.Gaps)
+		assert.Equal(t, uint64(251), result.ScannedBlocks)
+		assert.Equal(t, uint64(451), result.WindowBlocks)
+		assert.Equal(t, []uint64{150, 420}, result.EventBlocks)
+	})
+
+	t.Run("Window inside a scanned range", func(t *testing.T) {
+		status, result := get(t, "?from_block=150&to_block=250")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, []api.BlockRange{{FromBlock: 150, ToBlock: 250}}, result.Scanned)
+		assert.Empty(t, result.Gaps)
+		assert.Equal(t, result.WindowBlocks, result.ScannedBlocks)
+	})
+
+	t.Run("Open bounds default to the scanned history", func(t *testing.T) {
+		status, result := get(t, "")
+		require.Equal(t, http.StatusOK, status)
+
+		require.NotNil(t, result.FromBlock)
+		require.NotNil(t, result.ToBlock)
+		assert.Equal(t, uint64(100), *result.FromBlock)
+		assert.Equal(t, uint64(450), *result.ToBlock)
+		assert.Equal(t, []api.BlockRange{{FromBlock: 301, ToBlock: 400}}, result.Gaps)
+	})
+
+	t.Run("Unscanned window is one gap", func(t *testing.T) {
+		status, result := get(t, "?from_block=500&to_block=700")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Empty(t, result.Scanned)
+		assert.Equal(t, []api.BlockRange{{FromBlock: 500, ToBlock: 700}}, result.Gaps)
+		assert.Equal(t, []uint64{600}, result.EventBlocks)
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		status, _ := get(t, "?from_block=300&to_block=200")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+// TestReleasedAt tests the cumulative released amount as of a block
+func TestReleasedAt(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	releases := []struct {
+		block  uint64
+		amount string
+	}{
+		{100, "1000"},
+		{200, "2500"},
+		{200, "500"},
+		{300, "4000"},
+	}
+	for i, release := range releases {
+		require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     beneficiary,
+			Amount:          release.amount,
+			BlockNumber:     release.block,
+			LogIndex:        uint(i),
+			TransactionHash: fmt.Sprintf("0x%064x", i),
+			Timestamp:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}))
+	}
+	// Other event types at earlier blocks must not count towards the total
+	require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+		EventType:       "VestingScheduleCreated",
+		Beneficiary:     beneficiary,
+		Amount:          "1000000",
+		BlockNumber:     50,
+		TransactionHash: fmt.Sprintf("0x%064x", 99),
+		Timestamp:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	type response struct {
+		Beneficiary string `json:"beneficiary"`
+		Block       uint64 `json:"block"`
+		Released    string `json:"released"`
+		Releases    int    `json:"releases"`
+	}
+	get := func(t *testing.T, address, query string) (int, response) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/schedules/" + address + "/released-at" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result response
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+
+	t.Run("Intermediate block includes releases at that block", func(t *testing.T) {
+		status, result := get(t, strings.ToLower(beneficiary), "?block=200")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, beneficiary, result.Beneficiary)
+		assert.Equal(t, uint64(200), result.Block)
+		assert.Equal(t, "4000", result.Released)
+		assert.Equal(t, 3, result.Releases)
+	})
+
+	t.Run("Block before the first release", func(t *testing.T) {
+		status, result := get(t, beneficiary, "?block=99")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, "0", result.Released)
+		assert.Equal(t, 0, result.Releases)
+	})
+
+	t.Run("Block after the last release", func(t *testing.T) {
+		status, result := get(t, beneficiary, "?block=1000")
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, "8000", result.Released)
+		assert.Equal(t, 4, result.Releases)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		for _, tt := range []struct{ address, query string }{
+			{beneficiary, ""},
+			{beneficiary, "?block=-1"},
+			{beneficiary, "?block=abc"},
+			{"invalid", "?block=200"},
+			{"0x0000000000000000000000000000000000000000", "?block=200"},
+		} {
+			status, _ := get(t, tt.address, tt.query)
+			assert.Equal(t, http.StatusBadRequest, status, "address=%s query=%s", tt.address, tt.query)
+		}
+	})
+}
+
+func TestCursorPagination(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	now := time.Now()
+	for i := 1; i <= 11; i++ {
+		require.NoError(t, ts.DB.CreateOrUpdateSchedule(&models.VestingSchedule{
+			Beneficiary: fmt.Sprintf("0x%040x", i),
+			Start:       now,
+			Cliff:       now,
+			Duration:    86400,
+			Amount:      "1000",
+			Released:    "0",
+		}))
+	}
+	// Three events per block, so pages split blocks
+	for i := 0; i < 12; i++ {
+		require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     beneficiary,
+			Amount:          "1",
+			BlockNumber:     uint64(1000 + i/3),
+			LogIndex:        uint(i % 3),
+			TransactionHash: fmt.Sprintf("0x%064x", i),
+			Timestamp:       now,
+		}))
+	}
+
+	// walk follows next_cursor until it is null, collecting the IDs of the listed items
+	walk := func(t *testing.T, path, key string) []float64 {
+		var ids []float64
+		url := ts.Server.URL + path
+		for pages := 0; pages < 20; pages++ {
+			resp, err := http.Get(url)
+			require.NoError(t, err)
+			var result map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode, result)
+
+			for _, item := range result[key].([]interface{}) {
+				ids = append(ids, item.(map[string]interface{})["id"].(float64))
+			}
+			next, ok := result["next_cursor"].(string)
+			if !ok {
+				return ids
+			}
+			url = ts.Server.URL + path + "&cursor=" + next
+		}
+		t.Fatal("cursor pagination did not terminate")
+		return nil
+	}
+
+	assertComplete := func(t *testing.T, ids []float64, want int) {
+		seen := make(map[float64]bool, len(ids))
+		for _, id := range ids {
+			assert.False(t, seen[id], "id %v repeated", id)
+			seen[id] = true
+		}
+		assert.Len(t, seen, want)
+	}
+
+	t.Run("Schedules", func(t *testing.T) {
+		ids := walk(t, "/api/v1/schedules?limit=4", "schedules")
+		assertComplete(t, ids, 11)
+		assert.IsIncreasing(t, ids)
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		ids := walk(t, "/api/v1/events/"+beneficiary+"?limit=5", "events")
+		assertComplete(t, ids, 12)
+		assert.IsDecreasing(t, ids)
+
+		ids = walk(t, "/api/v1/events/"+beneficiary+"?limit=5&order=asc", "events")
+		assertComplete(t, ids, 12)
+		assert.IsIncreasing(t, ids)
+	})
+
+	t.Run("Events for beneficiaries", func(t *testing.T) {
+		ids := walk(t, "/api/v1/events?beneficiaries="+beneficiary+"&limit=2", "events")
+		assertComplete(t, ids, 12)
+	})
+
+	t.Run("Cursor takes precedence over offset", func(t *testing.T) {
+		resp, err := http.Get(ts.Server.URL + "/api/v1/schedules?limit=2")
+		require.NoError(t, err)
+		var first map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&first))
+		resp.Body.Close()
+
+		resp, err = http.Get(ts.Server.URL + "/api/v1/schedules?limit=2&offset=8&cursor=" + first["next_cursor"].(string))
+		require.NoError(t, err)
+		var second map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&second))
+		resp.Body.Close()
+
+		assert.Equal(t, float64(0), second["offset"])
+		schedules := second["schedules"].([]interface{})
+		require.Len(t, schedules, 2)
+		assert.Equal(t, float64(3), schedules[0].(map[string]interface{})["id"])
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		for _, path := range []string{
+			"/api/v1/schedules?cursor=not-a-cursor",
+			"/api/v1/events/" + beneficiary + "?cursor=e30", // {}
+		} {
+			resp, err := http.Get(ts.Server.URL + path)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+		}
+	})
+}
+
+// TestPaginationTotal checks that total counts every matching row while count
+// only covers the page
+func TestPaginationTotal(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.teardown()
+
+	beneficiary := "0xF25DA65784D566fFCC60A1f113650afB688A14ED"
+	now := time.Now()
+	for i := 1; i <= 6; i++ {
+		require.NoError(t, ts.DB.CreateOrUpdateSchedule(&models.VestingSchedule{
+			Beneficiary: fmt.Sprintf("0x%040x", i),
+			Start:       now,
+			Cliff:       now,
+			Duration:    86400,
+			Amount:      "1000",
+			Released:    "0",
+			Revoked:     i == 6, // Revoked schedules are neither listed nor counted
+		}))
+	}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, ts.DB.CreateEvent(&models.VestingEvent{
+			EventType:       "TokensReleased",
+			Beneficiary:     beneficiary,
+			Amount:          "1",
+			BlockNumber:     uint64(1000 + i),
+			TransactionHash: fmt.Sprintf("0x%064x", i),
+			Timestamp:       now,
+		}))
+	}
+
+	get := func(t *testing.T, path string) map[string]interface{} {
+		resp, err := http.Get(ts.Server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		counts []float64
+		total  float64
+	}{
+		{"Schedules", "/api/v1/schedules?limit=2", []float64{2, 2, 1, 0}, 5},
+		{"Schedules by status", "/api/v1/schedules?status=vesting&limit=2", []float64{2, 2, 1, 0}, 5},
+		{"Revoked schedules", "/api/v1/schedules?status=revoked&limit=2", []float64{1, 0}, 1},
+		{"Events", "/api/v1/events/" + beneficiary + "?limit=2", []float64{2, 2, 1, 0}, 5},
+		{"Filtered events", "/api/v1/events/" + beneficiary + "?limit=2&from_block=1002", []float64{2, 1, 0}, 3},
+		{"Events for beneficiaries", "/api/v1/events?beneficiaries=" + beneficiary + "&limit=2", []float64{2, 2, 1, 0}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for page, count := range tt.counts {
+				result := get(t, fmt.Sprintf("%s&offset=%d", tt.path, page*2))
+				assert.Equal(t, count, result["count"], "page %d", page)
+				assert.Equal(t, tt.total, result["total"], "page %d", page)
+			}
+		})
+	}
+
+	// Cursor pages report the same total
+	first := get(t, "/api/v1/schedules?limit=2")
+	next := get(t, "/api/v1/schedules?limit=2&cursor="+first["next_cursor"].(string))
+	assert.Equal(t, float64(5), next["total"])
 }
 
 // TestConcurrentRequests tests handling multiple concurrent read requests